@@ -57142,6 +57142,13 @@ func schema_tke_api_platform_v1_MachineSpec(ref common.ReferenceCallback) common
 							},
 						},
 					},
+					"paused": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Paused holds the provisioning of the initializing machine until it's unset.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
 				},
 				Required: []string{"clusterName", "type", "ip", "port", "username"},
 			},
@@ -57230,11 +57237,38 @@ func schema_tke_api_platform_v1_MachineStatus(ref common.ReferenceCallback) comm
 							Ref:         ref("tkestack.io/tke/api/platform/v1.MachineSystemInfo"),
 						},
 					},
+					"nodeName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The name of the node resolved by machine IP.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"reconcileCount": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The number of meaningful reconciles of the machine.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"lastReconcileTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The last time the reconcile count was recorded.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"podCount": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The number of pods scheduled to the node of the machine which haven't terminated.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"tkestack.io/tke/api/platform/v1.MachineAddress", "tkestack.io/tke/api/platform/v1.MachineCondition", "tkestack.io/tke/api/platform/v1.MachineSystemInfo"},
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time", "tkestack.io/tke/api/platform/v1.MachineAddress", "tkestack.io/tke/api/platform/v1.MachineCondition", "tkestack.io/tke/api/platform/v1.MachineSystemInfo"},
 	}
 }
 
@@ -1272,6 +1272,9 @@ type MachineSpec struct {
 	PassPhrase  []byte
 	Labels      map[string]string
 	Taints      []corev1.Taint
+	// Paused holds the provisioning of the initializing machine until it's unset.
+	// +optional
+	Paused bool
 }
 
 // MachineStatus represents information about the status of an machine.
@@ -1299,6 +1302,18 @@ type MachineStatus struct {
 	// Set of ids/uuids to uniquely identify the node.
 	// +optional
 	MachineInfo MachineSystemInfo
+	// The name of the node resolved by machine IP.
+	// +optional
+	NodeName string
+	// The number of meaningful reconciles of the machine.
+	// +optional
+	ReconcileCount int32
+	// The last time the reconcile count was recorded.
+	// +optional
+	LastReconcileTime *metav1.Time
+	// The number of pods scheduled to the node of the machine which haven't terminated.
+	// +optional
+	PodCount int32
 }
 
 // MachineSystemInfo is a set of ids/uuids to uniquely identify the node.
@@ -29,12 +29,14 @@ import (
 	github_com_gogo_protobuf_sortkeys "github.com/gogo/protobuf/sortkeys"
 	v12 "k8s.io/api/core/v1"
 
+	"k8s.io/apimachinery/pkg/api/resource"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
 	math "math"
 	math_bits "math/bits"
 	reflect "reflect"
 	strings "strings"
 
-	"k8s.io/apimachinery/pkg/api/resource"
 	intstr "k8s.io/apimachinery/pkg/util/intstr"
 )
 
@@ -2338,364 +2340,368 @@ func init() {
 }
 
 var fileDescriptor_6e12a3c1f6fbf61e = []byte{
-	// 5708 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x7d, 0x4b, 0x6c, 0x24, 0xc7,
-	0x79, 0xb0, 0x66, 0x86, 0xc3, 0x1d, 0x7e, 0xc3, 0x67, 0x2d, 0x57, 0x1a, 0x51, 0xf2, 0x72, 0x3d,
-	0x6b, 0x1b, 0xeb, 0x87, 0x86, 0x5a, 0x4a, 0x5e, 0xaf, 0x24, 0x5b, 0xf6, 0x3c, 0x28, 0xef, 0x78,
-	0x49, 0xee, 0xb8, 0x66, 0x77, 0xfd, 0xdb, 0x7f, 0x62, 0xab, 0xd8, 0x5d, 0x1c, 0xb6, 0xd9, 0xd3,
-	0xdd, 0xee, 0xee, 0xa1, 0x97, 0x4a, 0x0e, 0xce, 0xe3, 0x90, 0x43, 0x10, 0x38, 0xc9, 0x21, 0x40,
-	0x0c, 0x23, 0x89, 0x13, 0x20, 0x89, 0x1f, 0x80, 0x81, 0x00, 0x3e, 0x18, 0x4e, 0x0e, 0x81, 0x81,
-	0x08, 0x41, 0x10, 0x18, 0x39, 0xf9, 0x62, 0x26, 0x62, 0x1e, 0xc8, 0x25, 0xc8, 0x7d, 0x4f, 0x41,
-	0x3d, 0xba, 0xba, 0xba, 0x67, 0x86, 0x33, 0xbd, 0x92, 0xe8, 0x3d, 0xe8, 0xc6, 0xfe, 0x5e, 0xf5,
-	0x55, 0xd5, 0x57, 0x5f, 0x7d, 0xdf, 0x57, 0x55, 0x43, 0xd8, 0x08, 0x0f, 0x69, 0x10, 0x12, 0xe3,
-	0xb0, 0x66, 0xb9, 0xec, 0xef, 0x0d, 0xe2, 0x59, 0x1b, 0x9e, 0x4d, 0xc2, 0x7d, 0xd7, 0xef, 0x6f,
-	0x1c, 0x5d, 0xdf, 0xe8, 0x51, 0x87, 0xfa, 0x24, 0xa4, 0x66, 0xcd, 0xf3, 0xdd, 0xd0, 0x45, 0xeb,
-	0x1a, 0x43, 0x2d, 0x3c, 0xa4, 0x35, 0xe2, 0x59, 0xb5, 0x88, 0xa1, 0x76, 0x74, 0x7d, 0xed, 0xb9,
-	0x9e, 0x15, 0x1e, 0x0c, 0xf6, 0x6a, 0x86, 0xdb, 0xdf, 0xe8, 0xb9, 0x3d, 0x77, 0x83, 0xf3, 0xed,
-	0x0d, 0xf6, 0xf9, 0x17, 0xff, 0xe0, 0x7f, 0x09, 0x79, 0x6b, 0xd5, 0xc3, 0x9b, 0x01, 0x6b, 0x9b,
-	0xb5, 0x6b, 0xb8, 0x3e, 0x1d, 0xd1, 0xe6, 0xda, 0x8b, 0x31, 0x4d, 0x9f, 0x18, 0x07, 0x96, 0x43,
-	0xfd, 0xe3, 0x0d, 0xef, 0xb0, 0xc7, 0x99, 0x7c, 0x1a, 0xb8, 0x03, 0xdf, 0xa0, 0x99, 0xb8, 0x82,
-	0x8d, 0x3e, 0x0d, 0xc9, 0xa8, 0xb6, 0x36, 0xc6, 0x71, 0xf9, 0x03, 0x27, 0xb4, 0xfa, 0xc3, 0xcd,
-	0xdc, 0x98, 0xc4, 0x10, 0x18, 0x07, 0xb4, 0x4f, 0x86, 0xf8, 0x5e, 0x18, 0xc7, 0x37, 0x08, 0x2d,
-	0x7b, 0xc3, 0x72, 0xc2, 0x20, 0xf4, 0x87, 0x98, 0x36, 0x47, 0x4d, 0x17, 0xf1, 0x3c, 0xdb, 0x32,
-	0x48, 0x68, 0xb9, 0xce, 0x88, 0x1e, 0x55, 0xbf, 0x95, 0x83, 0xb9, 0xba, 0x69, 0xba, 0x4e, 0xd7,
-	0xa3, 0x06, 0xfa, 0x18, 0x94, 0x42, 0xea, 0x10, 0x27, 0x6c, 0xb7, 0x2a, 0xb9, 0x2b, 0xb9, 0x6b,
-	0x73, 0x8d, 0xe5, 0x37, 0x4f, 0xd6, 0x9f, 0x38, 0x3d, 0x59, 0x2f, 0xdd, 0x95, 0x70, 0xac, 0x28,
-	0xd0, 0xc7, 0xa1, 0x6c, 0xd8, 0x83, 0x20, 0xa4, 0xfe, 0x2e, 0xe9, 0xd3, 0x4a, 0x9e, 0x33, 0x5c,
-	0x94, 0x0c, 0xe5, 0x66, 0x8c, 0xc2, 0x3a, 0x1d, 0xfa, 0x30, 0x5c, 0x38, 0xa2, 0x7e, 0x60, 0xb9,
-	0x4e, 0xa5, 0xc0, 0x59, 0x96, 0x24, 0xcb, 0x85, 0xfb, 0x02, 0x8c, 0x23, 0x7c, 0xf5, 0x47, 0x39,
-	0x28, 0xd4, 0x3d, 0x0f, 0xbd, 0x0e, 0x25, 0x36, 0x25, 0x26, 0x09, 0x09, 0xd7, 0xab, 0xbc, 0xf9,
-	0x7c, 0x4d, 0x8c, 0x50, 0x4d, 0x1f, 0xa1, 0x9a, 0x77, 0xd8, 0x63, 0x80, 0xa0, 0xc6, 0xa8, 0x6b,
-	0x47, 0xd7, 0x6b, 0x77, 0xf6, 0xbe, 0x4a, 0x8d, 0x70, 0x87, 0x86, 0xa4, 0x81, 0x64, 0x2b, 0x10,
-	0xc3, 0xb0, 0x92, 0x8a, 0x76, 0x60, 0x26, 0xf0, 0xa8, 0xc1, 0x3b, 0x51, 0xde, 0xfc, 0x68, 0x6d,
-	0x94, 0x21, 0x6b, 0x43, 0xc9, 0x64, 0xd7, 0x3d, 0x8f, 0x0d, 0x5a, 0x63, 0x5e, 0x0a, 0x9e, 0x61,
-	0x5f, 0x98, 0x8b, 0xa9, 0xfe, 0x3c, 0x07, 0xcb, 0xf5, 0x41, 0x78, 0xf0, 0xc6, 0x17, 0xe8, 0xde,
-	0x81, 0xeb, 0x1e, 0xd6, 0x4d, 0xd3, 0x47, 0x5f, 0x81, 0x0b, 0x7b, 0x03, 0xcb, 0x0e, 0x2d, 0x47,
-	0x76, 0xe2, 0x66, 0x6d, 0xc2, 0x7a, 0xa9, 0x35, 0x04, 0x7d, 0x5a, 0x54, 0xa3, 0xcc, 0x86, 0x4b,
-	0x22, 0x71, 0x24, 0x15, 0x19, 0x50, 0xa2, 0x0f, 0x42, 0xea, 0x3b, 0xc4, 0x96, 0x1d, 0x79, 0x69,
-	0x62, 0x0b, 0x5b, 0x92, 0x61, 0xa8, 0x89, 0x79, 0x36, 0xeb, 0x11, 0x16, 0x2b, 0xc1, 0xd5, 0x2e,
-	0xcc, 0x37, 0x5c, 0x97, 0x19, 0x20, 0xf1, 0xd8, 0xdc, 0x34, 0xa1, 0x40, 0x3c, 0x4f, 0xf6, 0xe8,
-	0x03, 0x13, 0xdb, 0xab, 0x7b, 0x5e, 0xa3, 0x2c, 0x47, 0x8c, 0xcd, 0x2d, 0x66, 0xdc, 0xd5, 0xa7,
-	0xe1, 0xa9, 0x31, 0x5d, 0xad, 0xfe, 0x49, 0x1e, 0xca, 0xcd, 0x6e, 0xfb, 0x8e, 0xc7, 0xec, 0xd6,
-	0xf5, 0xcf, 0xc1, 0x16, 0x70, 0xc2, 0x16, 0x9e, 0x9f, 0xd8, 0x25, 0x4d, 0xbb, 0x71, 0x06, 0x81,
-	0xbe, 0x04, 0xb3, 0x41, 0x48, 0xc2, 0x41, 0xc0, 0x6d, 0xbe, 0xbc, 0xb9, 0x99, 0x49, 0x2a, 0xe7,
-	0x6c, 0x2c, 0x4a, 0xb9, 0xb3, 0xe2, 0x1b, 0x4b, 0x89, 0xd5, 0x4f, 0x03, 0xd2, 0x88, 0x5f, 0xa3,
-	0x24, 0x1c, 0xf8, 0x89, 0x65, 0x96, 0x9b, 0xb0, 0xcc, 0xfe, 0x3e, 0x07, 0x4b, 0x9a, 0x84, 0x6d,
-	0x2b, 0x08, 0xd1, 0xaf, 0x0c, 0x0d, 0x73, 0x6d, 0xba, 0x61, 0x66, 0xdc, 0x7c, 0x90, 0x95, 0xeb,
-	0x88, 0x20, 0xda, 0x10, 0x7f, 0x1e, 0x8a, 0x56, 0x48, 0xfb, 0x41, 0x25, 0x7f, 0xa5, 0x70, 0xad,
-	0xbc, 0xf9, 0xb1, 0x2c, 0xa3, 0xd1, 0x58, 0x90, 0x82, 0x8b, 0x6d, 0x26, 0x02, 0x0b, 0x49, 0xd5,
-	0x3f, 0x4b, 0x76, 0xe2, 0xb1, 0xf4, 0x67, 0x7f, 0x53, 0x80, 0x95, 0xa1, 0x79, 0xcd, 0x30, 0x53,
-	0xa8, 0x03, 0xab, 0x41, 0xe8, 0xfa, 0xa4, 0x47, 0xef, 0x53, 0xc7, 0x74, 0x7d, 0x49, 0x20, 0x75,
-	0x7d, 0x56, 0xf2, 0xad, 0x76, 0x47, 0xd0, 0xe0, 0x91, 0x9c, 0xe8, 0x3a, 0x14, 0xbd, 0x03, 0x12,
-	0x50, 0xa9, 0xfb, 0x33, 0xd1, 0xd8, 0x76, 0x18, 0xf0, 0xe1, 0xc9, 0x3a, 0xf0, 0xdd, 0x81, 0x7f,
-	0x61, 0x41, 0x89, 0x3e, 0x04, 0xb3, 0x3e, 0x25, 0x81, 0xeb, 0x54, 0x66, 0x38, 0x8f, 0xb2, 0x4b,
-	0xcc, 0xa1, 0x58, 0x62, 0xd1, 0x26, 0x80, 0x4f, 0x43, 0xff, 0xb8, 0xe9, 0x0e, 0x9c, 0xb0, 0x52,
-	0xbc, 0x92, 0xbb, 0x56, 0x8c, 0x57, 0x1e, 0x56, 0x18, 0xac, 0x51, 0xa1, 0xdf, 0xcf, 0xc1, 0x33,
-	0x36, 0x09, 0x42, 0x4c, 0xdb, 0x8e, 0x15, 0x5a, 0xc4, 0xb6, 0xde, 0xb0, 0x9c, 0xde, 0x5d, 0xab,
-	0xcf, 0xcc, 0xa3, 0xef, 0x55, 0x66, 0xb9, 0x29, 0x7e, 0x64, 0x3a, 0x53, 0x64, 0x6c, 0x8d, 0xab,
-	0xb2, 0xc5, 0x67, 0xb6, 0xc7, 0x8b, 0xc5, 0x67, 0xb5, 0x59, 0x35, 0xb9, 0x61, 0x75, 0x7c, 0xf7,
-	0xc1, 0xf1, 0x1d, 0x8f, 0x79, 0xff, 0x00, 0x6d, 0xc0, 0x9c, 0x43, 0xfa, 0x34, 0xf0, 0x88, 0x41,
-	0xe5, 0xa4, 0xad, 0xc8, 0x76, 0xe6, 0x76, 0x23, 0x04, 0x8e, 0x69, 0xd0, 0x15, 0x98, 0x71, 0x62,
-	0xa3, 0x52, 0x1e, 0x82, 0x5b, 0x13, 0xc7, 0x54, 0xff, 0x30, 0x0f, 0x17, 0xa4, 0x8d, 0x9d, 0x83,
-	0x8f, 0xdb, 0x4d, 0xf8, 0xb8, 0x29, 0xd6, 0x9f, 0xd0, 0x6c, 0xac, 0x7f, 0xbb, 0x9f, 0xf2, 0x6f,
-	0xb5, 0xa9, 0x25, 0x9e, 0xed, 0xdb, 0xbe, 0x93, 0x87, 0x79, 0x49, 0xc9, 0x0d, 0xf1, 0x1c, 0x86,
-	0xa6, 0x9b, 0x18, 0x9a, 0xeb, 0xd3, 0x76, 0x44, 0x45, 0x51, 0x23, 0xc7, 0xe7, 0xff, 0xa7, 0xc6,
-	0xe7, 0x85, 0x6c, 0x62, 0xcf, 0x1e, 0xa4, 0x9f, 0xe6, 0x60, 0x59, 0x27, 0x3f, 0x07, 0x07, 0x8e,
-	0x93, 0x0e, 0xfc, 0xb9, 0x4c, 0xdd, 0x19, 0xe3, 0xc1, 0xff, 0x20, 0xd5, 0x0d, 0xee, 0xc2, 0xaf,
-	0xc0, 0x4c, 0x78, 0xec, 0x45, 0x8b, 0x4c, 0x0d, 0xed, 0xdd, 0x63, 0x8f, 0x62, 0x8e, 0x61, 0x1e,
-	0xcc, 0xa6, 0x47, 0xd4, 0x96, 0x6b, 0x4b, 0x79, 0xb0, 0x6d, 0x06, 0x54, 0x1e, 0x8c, 0x7f, 0x61,
-	0x41, 0x99, 0xc5, 0x65, 0xff, 0x6e, 0x0e, 0xd0, 0xf0, 0x54, 0x64, 0xf1, 0xd9, 0x57, 0x23, 0x0f,
-	0x2b, 0xf4, 0x5b, 0x48, 0x78, 0xd8, 0x61, 0x9f, 0x5a, 0x38, 0xcb, 0xa7, 0x56, 0x7f, 0xaf, 0x90,
-	0x1c, 0x23, 0x36, 0x0e, 0xe7, 0xb0, 0x26, 0xa2, 0x59, 0xc8, 0x4f, 0x9e, 0x85, 0xc2, 0xd4, 0xb3,
-	0xf0, 0x0a, 0x2c, 0xd8, 0x24, 0xa4, 0x41, 0x18, 0xed, 0x62, 0x62, 0x3b, 0xb9, 0x24, 0x59, 0x17,
-	0xb6, 0x75, 0x24, 0x4e, 0xd2, 0xb2, 0xcd, 0xda, 0xa4, 0x81, 0xe1, 0x5b, 0xdc, 0x23, 0xf3, 0xdd,
-	0x45, 0xdb, 0xac, 0x5b, 0x31, 0x0a, 0xeb, 0x74, 0xe8, 0x0e, 0x5c, 0x32, 0xdc, 0xbe, 0x47, 0x42,
-	0x6b, 0xcf, 0xa6, 0x72, 0x20, 0x59, 0x2f, 0x2a, 0xb3, 0x57, 0x0a, 0xd7, 0xe6, 0x1a, 0x4f, 0x9f,
-	0x9e, 0xac, 0x5f, 0x6a, 0x8e, 0x22, 0xc0, 0xa3, 0xf9, 0xaa, 0xff, 0x94, 0x83, 0xd5, 0xf4, 0x84,
-	0x9c, 0xc3, 0xfa, 0xbb, 0x9f, 0x5c, 0x7f, 0xd9, 0xbc, 0x14, 0xd3, 0x71, 0xcc, 0x1a, 0xfc, 0xcb,
-	0x1c, 0x2c, 0xc6, 0xa4, 0x3e, 0x0d, 0xd8, 0x5e, 0xa7, 0xaf, 0xc0, 0x67, 0xf4, 0xb9, 0x7f, 0x78,
-	0xb2, 0x5e, 0x96, 0x64, 0x9a, 0x29, 0x5c, 0x81, 0x99, 0x03, 0x37, 0x08, 0xd3, 0xc6, 0x72, 0xcb,
-	0x0d, 0x42, 0xcc, 0x31, 0x8c, 0xc2, 0x73, 0xfd, 0x90, 0xdb, 0x4a, 0x31, 0xa6, 0xe8, 0xb8, 0x7e,
-	0x88, 0x39, 0x86, 0x53, 0x90, 0xf0, 0x40, 0x9a, 0x44, 0x4c, 0x41, 0xc2, 0x03, 0xcc, 0x31, 0xd5,
-	0xd7, 0xe0, 0x62, 0xa4, 0xa8, 0xe7, 0xd9, 0x89, 0x9d, 0xd9, 0x0d, 0xef, 0x79, 0x26, 0x09, 0x85,
-	0xca, 0x25, 0x6d, 0x67, 0x8e, 0x10, 0x38, 0xa6, 0xa9, 0xfe, 0x38, 0xf6, 0x3a, 0x6c, 0xe2, 0x5d,
-	0x87, 0x3a, 0xe1, 0x14, 0x5e, 0xe7, 0xb7, 0x72, 0x50, 0xf2, 0x29, 0x4f, 0x08, 0x83, 0xa9, 0x93,
-	0xad, 0x74, 0x3b, 0x58, 0x0a, 0x68, 0x7c, 0x2c, 0x9a, 0xea, 0x08, 0xf2, 0xf0, 0x64, 0xbd, 0x32,
-	0x8e, 0x1a, 0xab, 0x86, 0x99, 0xf5, 0x8d, 0x25, 0x63, 0x3e, 0xca, 0xa4, 0x81, 0xe5, 0x53, 0x93,
-	0xf7, 0xa3, 0x18, 0xfb, 0xa8, 0x96, 0x00, 0xe3, 0x08, 0xcf, 0x48, 0x8d, 0x81, 0xef, 0x53, 0x47,
-	0xcc, 0x9a, 0x46, 0xda, 0x14, 0x60, 0x1c, 0xe1, 0xd9, 0x00, 0x93, 0x23, 0x62, 0xd9, 0x64, 0xcf,
-	0xa6, 0x72, 0x02, 0xd5, 0x00, 0xd7, 0x23, 0x04, 0x8e, 0x69, 0x98, 0xec, 0x01, 0x1f, 0x6a, 0x93,
-	0xcf, 0xa6, 0x26, 0x5b, 0xcc, 0x80, 0x89, 0x23, 0x7c, 0xf5, 0xcf, 0x0b, 0xda, 0x5c, 0x38, 0xa6,
-	0xc5, 0x97, 0xec, 0xe4, 0xb9, 0x78, 0x49, 0x6d, 0xae, 0xc2, 0xe4, 0xde, 0x9f, 0xdc, 0x27, 0x1f,
-	0x9e, 0xac, 0x2f, 0x29, 0x71, 0xc9, 0xad, 0x13, 0xf5, 0x98, 0x0f, 0x0a, 0xc2, 0x8e, 0xef, 0xee,
-	0x51, 0x16, 0xf1, 0xc9, 0xed, 0x39, 0x4b, 0x80, 0xa9, 0xf9, 0x2b, 0x4d, 0x10, 0x4e, 0xca, 0x45,
-	0x47, 0x80, 0x18, 0xe0, 0xae, 0x4f, 0x9c, 0x80, 0x2b, 0xc2, 0x5b, 0x9b, 0xc9, 0xdc, 0xda, 0x9a,
-	0x6c, 0x0d, 0x6d, 0x0f, 0x49, 0xc3, 0x23, 0x5a, 0xd0, 0x36, 0x96, 0xe2, 0x99, 0xc1, 0xfa, 0x87,
-	0xe1, 0x42, 0x9f, 0x06, 0x01, 0xe9, 0x51, 0x1e, 0x63, 0x6b, 0x1b, 0xda, 0x8e, 0x00, 0xe3, 0x08,
-	0x5f, 0xfd, 0x45, 0x09, 0x56, 0xa2, 0x59, 0xf2, 0xa9, 0x49, 0x1d, 0x16, 0x33, 0x9f, 0xc3, 0x26,
-	0xa4, 0x67, 0x73, 0xf9, 0xac, 0xd9, 0x5c, 0x61, 0xca, 0x6c, 0xae, 0x06, 0x40, 0x43, 0xc3, 0x6c,
-	0xd6, 0x9b, 0xd4, 0x0f, 0xf9, 0xfc, 0xcc, 0x37, 0x16, 0x99, 0x4a, 0x5b, 0x77, 0x9b, 0x2d, 0x01,
-	0xc5, 0x1a, 0x05, 0xfa, 0x28, 0xcc, 0x89, 0xaf, 0xdb, 0xf4, 0x98, 0x0f, 0xf1, 0x7c, 0x63, 0x81,
-	0x2d, 0x05, 0x41, 0x7e, 0x9b, 0x1e, 0xe3, 0x18, 0x8f, 0x9a, 0xb0, 0xc2, 0x3e, 0xea, 0x9d, 0x76,
-	0xd3, 0xb6, 0xa8, 0x13, 0xf2, 0x36, 0x66, 0x39, 0xd3, 0xa5, 0xd3, 0x93, 0xf5, 0x15, 0xc6, 0x94,
-	0x40, 0xe2, 0x61, 0x7a, 0xf4, 0x19, 0x58, 0x4e, 0x00, 0x59, 0xc3, 0x17, 0xb8, 0x8c, 0xd5, 0xd3,
-	0x93, 0xf5, 0xe5, 0x84, 0x0c, 0xd6, 0xfe, 0x10, 0x35, 0xaa, 0xc2, 0xac, 0x41, 0x78, 0xdb, 0x25,
-	0xce, 0x07, 0xcc, 0x1e, 0x64, 0xdf, 0x24, 0x06, 0xad, 0x43, 0xd1, 0x20, 0x4c, 0xf4, 0x1c, 0x27,
-	0x99, 0x63, 0x3b, 0x85, 0xe8, 0x8f, 0x80, 0xb3, 0x81, 0x32, 0xe2, 0x4e, 0x40, 0x3c, 0x50, 0x9a,
-	0xf6, 0x1a, 0x05, 0x1b, 0x28, 0x43, 0xe9, 0x5b, 0x8e, 0x07, 0x2a, 0x56, 0x34, 0xc6, 0xb3, 0xd6,
-	0x43, 0xf7, 0x90, 0x3a, 0x95, 0x79, 0x3e, 0x6d, 0xbc, 0xf5, 0xbb, 0x0c, 0x80, 0x05, 0x1c, 0xbd,
-	0x0c, 0x8b, 0x7b, 0x51, 0x15, 0x8a, 0x23, 0x2a, 0x0b, 0x9c, 0x12, 0x9d, 0x9e, 0xac, 0x2f, 0x36,
-	0x12, 0x18, 0x9c, 0xa2, 0x64, 0xbc, 0x06, 0xf5, 0x43, 0x6b, 0xdf, 0x32, 0x48, 0x48, 0x99, 0x3a,
-	0x8b, 0x31, 0x6f, 0x33, 0x81, 0xc1, 0x29, 0x4a, 0x66, 0x83, 0x83, 0x80, 0xfa, 0x3c, 0x97, 0x5b,
-	0x4a, 0xda, 0xe0, 0x3d, 0x09, 0xc7, 0x8a, 0x02, 0x5d, 0x85, 0x3c, 0x09, 0x2a, 0xcb, 0x49, 0xd3,
-	0x6b, 0xf7, 0x3d, 0xea, 0x07, 0xae, 0xc3, 0xf6, 0xa1, 0x3c, 0x09, 0xd0, 0x75, 0x28, 0x91, 0xe0,
-	0xb3, 0xbe, 0x3b, 0xf0, 0x82, 0xca, 0x0a, 0x8f, 0x42, 0xb8, 0x2d, 0x68, 0x64, 0x02, 0x89, 0x15,
-	0x19, 0xfa, 0x56, 0x0e, 0xca, 0x24, 0x60, 0x0d, 0x6e, 0x3d, 0x08, 0x7d, 0x52, 0x41, 0x3c, 0x08,
-	0x68, 0x4e, 0xbd, 0xff, 0xa8, 0x55, 0x5b, 0xab, 0xc7, 0x52, 0xb6, 0x9c, 0xd0, 0x3f, 0x6e, 0xbc,
-	0x18, 0xd5, 0x10, 0xb4, 0xf6, 0x15, 0xc9, 0xc3, 0x31, 0x70, 0xac, 0x6b, 0xb3, 0xf6, 0x2a, 0x2c,
-	0xa7, 0xc5, 0xa2, 0x65, 0x28, 0x1c, 0xd2, 0x63, 0xe1, 0xc3, 0x31, 0xfb, 0x13, 0xad, 0x42, 0xf1,
-	0x88, 0xd8, 0x03, 0x19, 0x53, 0x62, 0xf1, 0xf1, 0x72, 0xfe, 0x66, 0xae, 0xfa, 0xcf, 0x39, 0xb8,
-	0x34, 0xa4, 0xe9, 0x39, 0xc4, 0x54, 0x5f, 0x48, 0xc6, 0x54, 0x9b, 0xd9, 0x87, 0x73, 0x4c, 0x50,
-	0xf5, 0xa3, 0x39, 0x15, 0x54, 0x45, 0xd5, 0xb9, 0x67, 0x61, 0xc6, 0xf2, 0x8e, 0x02, 0x19, 0xa1,
-	0x94, 0xd8, 0x86, 0xd6, 0xee, 0xdc, 0xef, 0x62, 0x0e, 0x45, 0xd7, 0xa0, 0xe4, 0x0d, 0xf6, 0x6c,
-	0xcb, 0xd8, 0x6e, 0xf0, 0xe1, 0x29, 0x89, 0x6a, 0x6c, 0x47, 0xc2, 0xb0, 0xc2, 0xb2, 0x55, 0x68,
-	0x39, 0xa2, 0x32, 0xbb, 0xdd, 0xe0, 0x4e, 0xae, 0x24, 0x56, 0x61, 0x5b, 0x41, 0xb1, 0x46, 0x81,
-	0x9e, 0x87, 0x0b, 0x3d, 0x6f, 0xc0, 0x23, 0x5e, 0x11, 0x5a, 0x3d, 0xc9, 0x5c, 0xfc, 0x67, 0x3b,
-	0xf7, 0x64, 0x38, 0x17, 0xfd, 0x89, 0x23, 0x32, 0xd4, 0x81, 0x55, 0xea, 0xb0, 0x8d, 0x7c, 0x87,
-	0xf0, 0x7c, 0xdd, 0x38, 0xa0, 0xe6, 0xc0, 0xa6, 0xdc, 0xd7, 0x95, 0xe2, 0x92, 0xd3, 0xd6, 0x08,
-	0x1a, 0x3c, 0x92, 0x13, 0xbd, 0x02, 0xf9, 0x03, 0x22, 0x2b, 0x39, 0x57, 0x27, 0x0e, 0xf2, 0xad,
-	0x7a, 0x63, 0xf6, 0xf4, 0x64, 0x3d, 0x7f, 0xab, 0x8e, 0xf3, 0x07, 0x84, 0x2d, 0xde, 0xe0, 0xd0,
-	0xf2, 0xd4, 0x7e, 0x1e, 0x54, 0x2e, 0xf0, 0x35, 0xc3, 0x17, 0x6f, 0x37, 0x81, 0xc1, 0x29, 0x4a,
-	0xf4, 0x39, 0x28, 0xee, 0x5b, 0x36, 0x0d, 0x2a, 0x25, 0x3e, 0xc1, 0x1f, 0x9c, 0xd8, 0xf6, 0x6b,
-	0x96, 0xad, 0x05, 0xca, 0xec, 0x2b, 0xc0, 0x42, 0x04, 0x3a, 0x84, 0xe2, 0x81, 0xeb, 0x1e, 0x06,
-	0x95, 0x39, 0x2e, 0xeb, 0xe5, 0x69, 0x8d, 0x45, 0x1a, 0x40, 0xed, 0x16, 0x63, 0x16, 0x4b, 0xee,
-	0xe9, 0xa8, 0x01, 0x0e, 0xfb, 0xcd, 0x7f, 0x5d, 0x2f, 0xb1, 0x3f, 0xf8, 0x2c, 0x88, 0x36, 0xd0,
-	0x3e, 0x94, 0x8d, 0xc0, 0x8a, 0xca, 0x86, 0xdc, 0xd9, 0x4e, 0x55, 0x42, 0x18, 0xaa, 0x0a, 0x37,
-	0x96, 0xf8, 0xe6, 0x17, 0xc3, 0xb1, 0x2e, 0x18, 0x05, 0xb0, 0x4c, 0x52, 0xf5, 0x77, 0xee, 0xaa,
-	0xa7, 0x49, 0x30, 0x86, 0x0e, 0x10, 0xf8, 0x6e, 0x94, 0x86, 0xe2, 0xa1, 0x06, 0xd0, 0x0e, 0x5c,
-	0x94, 0x66, 0x42, 0x43, 0xdf, 0x32, 0x82, 0x2e, 0xf5, 0x8f, 0xa8, 0xcf, 0x3d, 0x7f, 0x49, 0xa5,
-	0x1b, 0x17, 0xb7, 0x86, 0x49, 0xf0, 0x28, 0x3e, 0x96, 0x55, 0x5a, 0xde, 0xd1, 0x8d, 0xd6, 0x80,
-	0xd8, 0x5d, 0xa6, 0x2f, 0xdf, 0x18, 0x4a, 0x71, 0x94, 0xd6, 0xee, 0x68, 0x48, 0x9c, 0xa4, 0x45,
-	0x37, 0x61, 0x5e, 0xc8, 0x6c, 0x5a, 0xb6, 0x35, 0xe8, 0xf3, 0x8d, 0xa1, 0xd4, 0x58, 0x95, 0xbc,
-	0xf3, 0x5b, 0x1a, 0x0e, 0x27, 0x28, 0x51, 0x0b, 0x96, 0x0d, 0xd7, 0x09, 0x09, 0x73, 0x40, 0x58,
-	0x1c, 0xee, 0xc9, 0x0d, 0xa2, 0x22, 0xb9, 0x97, 0x9b, 0x29, 0x3c, 0x1e, 0xe2, 0x40, 0x5d, 0x16,
-	0x2b, 0xf7, 0x7c, 0x62, 0xd2, 0xca, 0x93, 0x7c, 0xdc, 0xaf, 0x4d, 0x1c, 0xf7, 0x7b, 0x82, 0x5e,
-	0x8f, 0xaa, 0x39, 0x00, 0x47, 0x92, 0xd6, 0x6e, 0x02, 0xc4, 0xd6, 0x96, 0xc9, 0x13, 0xff, 0x69,
-	0x01, 0x9e, 0x91, 0x76, 0xcb, 0x77, 0x9e, 0x7a, 0xa7, 0x8d, 0xe5, 0x89, 0x2a, 0x73, 0x70, 0xaa,
-	0xaa, 0x99, 0x1b, 0x57, 0xd5, 0x64, 0x03, 0x1a, 0x58, 0x4e, 0x6f, 0x60, 0x13, 0xbd, 0xa8, 0xae,
-	0x06, 0xb4, 0xab, 0xe1, 0x70, 0x82, 0x12, 0x6d, 0x02, 0xa8, 0xf2, 0xa9, 0x29, 0x3d, 0x9b, 0x8a,
-	0x0f, 0x55, 0x8d, 0xd5, 0xc4, 0x1a, 0x15, 0xba, 0x0a, 0xc5, 0x1e, 0xd3, 0x53, 0xfa, 0x36, 0xb5,
-	0x72, 0xb9, 0xf2, 0x58, 0xe0, 0xf4, 0xd2, 0x4d, 0x71, 0x42, 0xe9, 0xe6, 0x0a, 0xcc, 0x1c, 0x5a,
-	0x8e, 0x29, 0x23, 0x62, 0xd5, 0xbf, 0xdb, 0x96, 0x63, 0x62, 0x8e, 0x61, 0x81, 0xca, 0x11, 0xf5,
-	0xf7, 0x22, 0x2f, 0xc4, 0x03, 0x95, 0xfb, 0x0c, 0x80, 0x05, 0x9c, 0x39, 0xe8, 0xe0, 0xc0, 0xf5,
-	0x43, 0xae, 0x31, 0x77, 0x3c, 0x73, 0xc2, 0x41, 0x77, 0x15, 0x14, 0x6b, 0x14, 0x3c, 0xac, 0x22,
-	0x21, 0xed, 0xb9, 0xbe, 0x45, 0x85, 0x73, 0x91, 0xf4, 0x4d, 0x05, 0xc5, 0x1a, 0x45, 0xf5, 0xfb,
-	0x79, 0x78, 0xf6, 0x8c, 0x29, 0x0a, 0xce, 0x21, 0x2e, 0xbf, 0x09, 0xf3, 0x7c, 0x64, 0x93, 0x87,
-	0x11, 0x6a, 0x8e, 0x3f, 0xab, 0xe1, 0x70, 0x82, 0x12, 0x79, 0x30, 0x17, 0x9d, 0xd0, 0x07, 0x95,
-	0x02, 0x77, 0xa4, 0x9f, 0x9c, 0xd6, 0x91, 0x8e, 0xea, 0x6d, 0xdc, 0xa8, 0x86, 0x08, 0x70, 0xdc,
-	0x48, 0xf5, 0xbb, 0x79, 0xb8, 0x72, 0xd6, 0x70, 0x0d, 0x85, 0x19, 0xf9, 0x77, 0x3c, 0xcc, 0xd8,
-	0x8b, 0xc2, 0x0c, 0xd1, 0xe1, 0x4f, 0xbd, 0x9d, 0x0e, 0x07, 0xa3, 0x23, 0x0e, 0xe6, 0x8d, 0xf6,
-	0x89, 0x65, 0x53, 0x93, 0x33, 0x6d, 0xf9, 0xbe, 0xeb, 0xcb, 0x35, 0xa1, 0xbc, 0xd1, 0x6b, 0x29,
-	0x3c, 0x1e, 0xe2, 0xa8, 0x5e, 0x81, 0xcb, 0x63, 0xda, 0x96, 0xd5, 0x96, 0xea, 0x8f, 0x73, 0x10,
-	0xa5, 0x52, 0xe7, 0x10, 0xa0, 0xed, 0x24, 0x03, 0xb4, 0x6b, 0xd3, 0x8e, 0xdc, 0x98, 0xb0, 0xec,
-	0xa7, 0x33, 0x2a, 0x2c, 0xdb, 0x11, 0x9a, 0xa1, 0x35, 0xc8, 0x5b, 0x9e, 0x74, 0x67, 0x20, 0x99,
-	0xf2, 0xed, 0x0e, 0xce, 0x5b, 0x9e, 0x2a, 0x5a, 0xe5, 0xc7, 0x16, 0xad, 0xf4, 0xe4, 0xa0, 0x30,
-	0x31, 0x39, 0x60, 0x41, 0x1e, 0x09, 0x82, 0xaf, 0xbb, 0xbe, 0x29, 0xf3, 0x4c, 0x11, 0xe4, 0x49,
-	0x18, 0x56, 0x58, 0xe6, 0x13, 0x3c, 0xdf, 0x3a, 0x92, 0xc9, 0x4a, 0x31, 0x4e, 0xb5, 0x3a, 0x0a,
-	0x8a, 0x35, 0x0a, 0x4e, 0x4f, 0x82, 0xa0, 0x73, 0xe0, 0x93, 0x80, 0xca, 0xfc, 0x52, 0xd0, 0x2b,
-	0x28, 0xd6, 0x28, 0x90, 0x01, 0xb3, 0x36, 0xd9, 0xa3, 0xb6, 0xf0, 0x62, 0xe5, 0xcd, 0x57, 0xa6,
-	0x1d, 0x58, 0x39, 0x6c, 0xb5, 0x6d, 0xce, 0x2d, 0xa2, 0x19, 0x55, 0x60, 0x10, 0x40, 0x2c, 0x45,
-	0xa3, 0x3a, 0xcc, 0xb2, 0xbd, 0x2e, 0x8c, 0xa2, 0xaf, 0xa7, 0x35, 0xc3, 0xa8, 0x19, 0xae, 0x4f,
-	0x79, 0x89, 0x83, 0x51, 0xc4, 0x22, 0xf8, 0x67, 0x80, 0x25, 0x23, 0xfa, 0x22, 0x14, 0x3d, 0xdf,
-	0x7d, 0x20, 0x72, 0xd2, 0xf2, 0xe6, 0x8b, 0x19, 0xd5, 0xe4, 0x27, 0x78, 0x5a, 0xfd, 0x9d, 0x7d,
-	0x62, 0x21, 0x71, 0xed, 0x25, 0x28, 0x6b, 0x9d, 0xc8, 0xb4, 0x49, 0xfe, 0x38, 0xaf, 0x2a, 0x91,
-	0x7a, 0x43, 0xe8, 0xb9, 0x44, 0xdd, 0xea, 0xe9, 0x54, 0xdd, 0x74, 0x8e, 0x13, 0x69, 0x45, 0x2c,
-	0x61, 0x7a, 0xf9, 0x33, 0x4d, 0xaf, 0x30, 0x95, 0xe9, 0xcd, 0x64, 0x32, 0xbd, 0x62, 0x06, 0xd3,
-	0x9b, 0xcd, 0x68, 0x7a, 0x17, 0x26, 0x99, 0x5e, 0xf5, 0x17, 0x79, 0x58, 0x92, 0x83, 0xd7, 0xf1,
-	0x5d, 0x8f, 0xfa, 0xe1, 0x31, 0xda, 0x86, 0xd5, 0x3e, 0x79, 0x10, 0x9d, 0x0f, 0x52, 0xff, 0xc8,
-	0x32, 0xe8, 0xee, 0xa0, 0x2f, 0x8b, 0x98, 0x15, 0x96, 0x6d, 0xec, 0x8c, 0xc0, 0xe3, 0x91, 0x5c,
-	0xe8, 0x13, 0xb0, 0xd0, 0x27, 0x0f, 0x76, 0x5d, 0x93, 0x76, 0x5c, 0x93, 0x89, 0x11, 0xeb, 0x77,
-	0x85, 0xc5, 0x82, 0x3b, 0x3a, 0x02, 0x27, 0xe9, 0xd0, 0x37, 0x72, 0xb0, 0xe0, 0xb2, 0x48, 0xc0,
-	0xb5, 0x4d, 0x4c, 0x42, 0xcb, 0x95, 0x0e, 0x7b, 0xea, 0x34, 0x3b, 0xea, 0x50, 0xed, 0x8e, 0x2e,
-	0x45, 0xac, 0x12, 0x15, 0x8e, 0x26, 0x70, 0x38, 0xd9, 0xe0, 0xda, 0x67, 0x00, 0x0d, 0xf3, 0x66,
-	0x32, 0xce, 0xff, 0x2e, 0xaa, 0xf1, 0x8d, 0x7c, 0x37, 0xfa, 0x75, 0x28, 0x19, 0xc4, 0x23, 0x86,
-	0x15, 0x32, 0x21, 0xac, 0x4b, 0xaf, 0x4e, 0xdb, 0xa5, 0x48, 0x46, 0xad, 0x29, 0x05, 0x88, 0xde,
-	0x5c, 0x89, 0x6c, 0x2d, 0x02, 0x3f, 0x3c, 0x59, 0x9f, 0x8f, 0x68, 0x99, 0x23, 0xc7, 0xaa, 0x45,
-	0xf4, 0x3b, 0x39, 0x28, 0x13, 0xdb, 0x76, 0x0d, 0x12, 0xf2, 0x12, 0xb2, 0xf0, 0xe5, 0xf5, 0xcc,
-	0x1a, 0xd4, 0x63, 0x19, 0x42, 0x89, 0xe8, 0xa0, 0xbf, 0xac, 0x61, 0x86, 0xf4, 0xd0, 0x9b, 0x66,
-	0x33, 0x3c, 0x27, 0xbf, 0x79, 0x88, 0xc9, 0x14, 0xf9, 0xf4, 0xa3, 0x2a, 0x42, 0x4d, 0xa1, 0xc6,
-	0xfb, 0x55, 0x31, 0x3c, 0x82, 0x0f, 0x29, 0x11, 0x37, 0xba, 0x76, 0x08, 0x0b, 0x89, 0xa1, 0x1c,
-	0x31, 0xb9, 0x2d, 0x7d, 0x72, 0x27, 0x6c, 0xa8, 0xb5, 0x28, 0xd2, 0xa9, 0x7d, 0x7e, 0x40, 0x9c,
-	0xd0, 0x0a, 0x8f, 0x35, 0x63, 0x58, 0x73, 0x60, 0x39, 0x3d, 0x6a, 0xef, 0x6a, 0x7b, 0x36, 0x2c,
-	0x26, 0x07, 0xe7, 0xdd, 0x6c, 0xad, 0xfa, 0xd6, 0x25, 0x15, 0x8b, 0xf0, 0x93, 0xe3, 0x4f, 0x03,
-	0xec, 0x5b, 0x0e, 0xb1, 0xad, 0x37, 0xa8, 0x1f, 0x70, 0x43, 0x9f, 0x6b, 0xac, 0x33, 0x57, 0xf4,
-	0x9a, 0x82, 0x3e, 0x3c, 0x59, 0x5f, 0x50, 0x5f, 0x3c, 0x07, 0xd1, 0x58, 0xb2, 0xd7, 0x9b, 0x4d,
-	0x2b, 0xf0, 0x6c, 0x72, 0x3c, 0xaa, 0xde, 0xdc, 0x8a, 0x51, 0x58, 0xa7, 0x53, 0xa7, 0x1b, 0x33,
-	0x63, 0x4f, 0x37, 0x32, 0xe4, 0x2b, 0x2d, 0x28, 0x3b, 0x34, 0xfc, 0xba, 0xeb, 0x1f, 0xca, 0x33,
-	0x4d, 0x46, 0x5e, 0x8d, 0x74, 0xd8, 0x8d, 0x51, 0x0f, 0x93, 0x9f, 0x58, 0x67, 0x63, 0x19, 0xb4,
-	0xfc, 0x6c, 0x51, 0xe6, 0x45, 0xb9, 0x1b, 0xd7, 0xce, 0x65, 0x77, 0x75, 0x24, 0x4e, 0xd2, 0x6a,
-	0x65, 0xf7, 0x66, 0xbb, 0x85, 0x79, 0x81, 0x79, 0xb8, 0xec, 0xce, 0x50, 0x58, 0xa7, 0x43, 0xd7,
-	0xa1, 0x1c, 0x08, 0x9f, 0xcd, 0xd9, 0x2e, 0x8a, 0x8e, 0x32, 0x96, 0x6e, 0x0c, 0xc6, 0x3a, 0x0d,
-	0xda, 0x80, 0x39, 0xd3, 0x09, 0x5a, 0x6e, 0x9f, 0x58, 0x0e, 0x8f, 0x08, 0xb4, 0x3b, 0x38, 0xad,
-	0xdd, 0xae, 0x40, 0xe0, 0x98, 0x06, 0x61, 0x78, 0x52, 0xd4, 0xcd, 0xea, 0x36, 0xaf, 0x87, 0x85,
-	0xd6, 0x11, 0x15, 0x69, 0x19, 0x70, 0xe3, 0x58, 0x3b, 0x3d, 0x59, 0x7f, 0xb2, 0x33, 0x92, 0x02,
-	0x8f, 0xe1, 0x44, 0x2e, 0x94, 0xf6, 0x45, 0x69, 0x25, 0x90, 0x95, 0x92, 0x8d, 0x8c, 0x95, 0x20,
-	0x35, 0x3f, 0x25, 0x09, 0x60, 0x56, 0x99, 0x2a, 0x17, 0x62, 0xd5, 0x08, 0xfa, 0x3a, 0xdb, 0x90,
-	0xf9, 0xbe, 0xc2, 0xf2, 0xc3, 0xf9, 0x69, 0xaf, 0x28, 0x26, 0x77, 0xa4, 0xc6, 0x07, 0xa3, 0x84,
-	0xae, 0xa3, 0x64, 0xf1, 0x53, 0xb2, 0x24, 0x19, 0xd6, 0x9a, 0x42, 0x5f, 0x81, 0x39, 0x22, 0x8e,
-	0x7a, 0x69, 0x50, 0x59, 0xe0, 0xbe, 0x72, 0x23, 0x63, 0x00, 0x16, 0xaf, 0x1f, 0x09, 0x08, 0x70,
-	0x2c, 0x13, 0xfd, 0x76, 0x0e, 0x96, 0x4c, 0xd7, 0x38, 0x94, 0x75, 0xe3, 0xba, 0xdf, 0x0b, 0x2a,
-	0x8b, 0xd9, 0x36, 0x07, 0xb6, 0xee, 0x6b, 0xad, 0xa4, 0x0c, 0xe1, 0x95, 0x9f, 0x92, 0x2d, 0x2f,
-	0xa5, 0xb0, 0x38, 0xdd, 0x24, 0xdb, 0x9f, 0x96, 0x0f, 0x07, 0x7b, 0xd4, 0xa6, 0x61, 0xac, 0xc7,
-	0x12, 0xd7, 0xa3, 0x91, 0x49, 0x8f, 0xdb, 0x29, 0x21, 0x42, 0x11, 0x95, 0x7f, 0xa5, 0xd1, 0x78,
-	0xa8, 0x55, 0xf4, 0xcd, 0x1c, 0x20, 0xe2, 0x59, 0xa2, 0xb0, 0x15, 0x2b, 0xb3, 0xcc, 0x95, 0x69,
-	0x65, 0x52, 0xa6, 0x3e, 0x24, 0x46, 0xa8, 0xa3, 0x8e, 0x13, 0xeb, 0x9d, 0x76, 0x8a, 0x00, 0x8f,
-	0x68, 0x1b, 0xfd, 0x30, 0x07, 0x6b, 0x86, 0xeb, 0x84, 0xbe, 0x6b, 0xdb, 0x6c, 0x5e, 0x1d, 0xd2,
-	0xd3, 0x55, 0x5b, 0xe1, 0xaa, 0x6d, 0x67, 0x52, 0xad, 0x39, 0x56, 0x9c, 0x50, 0x31, 0x5a, 0x1f,
-	0x6b, 0xe3, 0x09, 0xf1, 0x19, 0x3a, 0xf1, 0x51, 0x0c, 0x64, 0xed, 0x59, 0x53, 0x15, 0x3d, 0xc2,
-	0x28, 0x76, 0x87, 0xc4, 0xa4, 0x46, 0x71, 0x98, 0x00, 0x8f, 0x68, 0x1b, 0x1d, 0xc1, 0xaa, 0x91,
-	0x3e, 0x3b, 0xc0, 0x74, 0xbf, 0xb2, 0x2a, 0x6b, 0x7e, 0x23, 0x32, 0xa3, 0x6d, 0xd7, 0x20, 0xb6,
-	0x28, 0xbf, 0x60, 0xba, 0x4f, 0x7d, 0xea, 0x18, 0x54, 0xc4, 0xc2, 0xcd, 0x11, 0x92, 0xf0, 0x48,
-	0xf9, 0xa8, 0x09, 0x33, 0x34, 0x34, 0xcc, 0xca, 0x25, 0xde, 0xce, 0xe4, 0xfa, 0xf7, 0x56, 0x68,
-	0x98, 0xe2, 0x70, 0x82, 0xfd, 0x85, 0x39, 0x33, 0xfa, 0x1c, 0xa0, 0x03, 0x37, 0x08, 0x59, 0x22,
-	0x51, 0x0f, 0x58, 0xbc, 0xcc, 0x93, 0x8e, 0xa7, 0x78, 0x81, 0x4e, 0x0d, 0xc4, 0xad, 0x21, 0x0a,
-	0x3c, 0x82, 0x0b, 0x85, 0x6a, 0xc3, 0xe2, 0x73, 0x52, 0xc9, 0x56, 0x11, 0xe1, 0x73, 0xb2, 0x1b,
-	0xf3, 0x8b, 0xc9, 0xb8, 0x98, 0xda, 0xef, 0xf8, 0x2c, 0xe8, 0xcd, 0x20, 0x1f, 0x96, 0x02, 0x83,
-	0xd8, 0x96, 0xd3, 0x8b, 0xfc, 0x50, 0xe5, 0xe9, 0x47, 0x73, 0x68, 0xca, 0xad, 0x74, 0x93, 0xf2,
-	0x70, 0xba, 0x01, 0xf4, 0x55, 0x58, 0xd8, 0xd3, 0xae, 0xcd, 0x07, 0x95, 0xb5, 0x29, 0x2f, 0xce,
-	0xe9, 0x97, 0xed, 0xe3, 0x3d, 0x58, 0x87, 0x06, 0x38, 0x29, 0x1a, 0x6d, 0x02, 0x10, 0x4f, 0x95,
-	0xe3, 0x9e, 0x11, 0x87, 0x9b, 0x91, 0xc7, 0xaf, 0x2b, 0x0c, 0xd6, 0xa8, 0xd6, 0x1a, 0xb0, 0x3a,
-	0xca, 0x71, 0x66, 0x49, 0x36, 0xd6, 0x9a, 0x70, 0x69, 0xa4, 0xd3, 0xcb, 0x24, 0x64, 0x0b, 0x9e,
-	0x1a, 0xe3, 0xac, 0x32, 0x89, 0xd9, 0x81, 0xf5, 0x09, 0x8e, 0x25, 0xab, 0x56, 0x63, 0x16, 0x7f,
-	0x26, 0x31, 0xaf, 0xc2, 0x72, 0xda, 0x5e, 0x33, 0xa5, 0x73, 0x7f, 0x5d, 0x86, 0x85, 0xc4, 0xc5,
-	0x59, 0x54, 0x85, 0x59, 0x9b, 0xcd, 0x9b, 0x29, 0x8f, 0x12, 0xf9, 0x59, 0xfe, 0x36, 0x87, 0x60,
-	0x89, 0xd1, 0x23, 0xc8, 0xfc, 0x84, 0x08, 0xf2, 0x85, 0xe4, 0x75, 0xf0, 0xf7, 0xa5, 0xaf, 0x83,
-	0x47, 0x97, 0x71, 0x13, 0x97, 0x17, 0x29, 0x80, 0x11, 0x9f, 0xc7, 0xcd, 0x64, 0xbb, 0x91, 0xa6,
-	0xce, 0xe7, 0x62, 0x13, 0xd5, 0x8e, 0xf0, 0x34, 0xc1, 0xfa, 0x15, 0x95, 0xe2, 0xd9, 0x57, 0x54,
-	0xb4, 0x5b, 0x2f, 0xb3, 0x67, 0xde, 0x7a, 0x79, 0x5d, 0x0f, 0x6a, 0x2e, 0x64, 0xf3, 0x01, 0xf2,
-	0xe2, 0x9b, 0x76, 0xfb, 0x29, 0x92, 0xa4, 0x47, 0x35, 0x5f, 0x83, 0x52, 0x94, 0xb5, 0xc8, 0xb2,
-	0xd5, 0xf3, 0x59, 0x33, 0x4c, 0x95, 0xd9, 0x96, 0x22, 0x88, 0x16, 0xab, 0x45, 0x20, 0xac, 0x9a,
-	0x11, 0xd3, 0x21, 0x2f, 0x83, 0x89, 0xd8, 0x36, 0xd3, 0x74, 0x48, 0x4e, 0x7d, 0x3a, 0x22, 0x61,
-	0x58, 0x13, 0xcc, 0x22, 0x7d, 0x3d, 0x64, 0x2f, 0x27, 0x23, 0xfd, 0xb1, 0x61, 0x7b, 0x0b, 0x96,
-	0x1d, 0xd7, 0xe4, 0x7f, 0xef, 0x90, 0xe0, 0xb0, 0x6b, 0xbd, 0x41, 0x79, 0x18, 0x5b, 0x8c, 0x43,
-	0xa3, 0xdd, 0x14, 0x1e, 0x0f, 0x71, 0xa0, 0xab, 0x50, 0x34, 0x9d, 0xa0, 0xdd, 0x91, 0xd7, 0x3e,
-	0x54, 0x51, 0xaf, 0xb5, 0xdb, 0x6d, 0x77, 0xb0, 0xc0, 0xb1, 0xa4, 0xc2, 0xa7, 0x3d, 0x2b, 0x08,
-	0xfd, 0xe3, 0x76, 0x47, 0x04, 0x93, 0x32, 0xa9, 0xc0, 0x31, 0x18, 0xeb, 0x34, 0xfc, 0x81, 0x05,
-	0x65, 0x36, 0x47, 0xfc, 0x63, 0xad, 0x0b, 0xf2, 0x28, 0x2f, 0x7e, 0x60, 0x31, 0x82, 0x06, 0x8f,
-	0xe4, 0x4c, 0x27, 0x44, 0xcb, 0x53, 0x26, 0x44, 0xba, 0x22, 0x1a, 0x51, 0x65, 0x65, 0x8c, 0x22,
-	0xba, 0xa0, 0x91, 0x9c, 0x4c, 0x62, 0x7a, 0x18, 0xdb, 0x9d, 0xa3, 0x17, 0x2b, 0x88, 0x0f, 0xbe,
-	0x92, 0xb8, 0x3b, 0x82, 0x06, 0x8f, 0xe4, 0x1c, 0x23, 0xf1, 0x06, 0xcf, 0xde, 0xce, 0x96, 0x78,
-	0x63, 0xa4, 0xc4, 0x1b, 0xa8, 0x05, 0xc0, 0xa2, 0x60, 0xf1, 0x44, 0x85, 0x87, 0x43, 0x73, 0x8d,
-	0x0f, 0x44, 0x76, 0x78, 0x5b, 0x61, 0x58, 0x86, 0x14, 0x7f, 0xf1, 0x0c, 0x56, 0xe3, 0x4b, 0xed,
-	0x7f, 0x97, 0xa6, 0xd9, 0xff, 0x50, 0x07, 0x16, 0x95, 0x6d, 0x73, 0xe7, 0xc6, 0x0f, 0x60, 0xe7,
-	0x1a, 0xd7, 0x24, 0xdf, 0x62, 0x33, 0x81, 0x7d, 0x38, 0x04, 0xc1, 0x29, 0xfe, 0xea, 0x0f, 0x0a,
-	0x30, 0xd7, 0x74, 0x9d, 0x7d, 0xab, 0xb7, 0x43, 0xce, 0xe3, 0x09, 0xe3, 0x7d, 0x98, 0x91, 0x27,
-	0x56, 0x85, 0xe9, 0x8a, 0xe3, 0x91, 0x6e, 0xb5, 0x16, 0x09, 0xe5, 0xed, 0x1f, 0x55, 0x7f, 0x60,
-	0x20, 0xcc, 0xe5, 0x21, 0x07, 0x60, 0xcf, 0x72, 0x88, 0x7f, 0xcc, 0x60, 0xb2, 0x4a, 0xf6, 0x72,
-	0x06, 0xe9, 0x0d, 0xc5, 0x2c, 0xda, 0x50, 0xbd, 0x88, 0x11, 0x58, 0x6b, 0x61, 0xed, 0x13, 0x30,
-	0xa7, 0x88, 0x33, 0x6d, 0xae, 0x9f, 0x82, 0xa5, 0x54, 0x5b, 0x93, 0xd8, 0xe7, 0xf5, 0xbd, 0xf5,
-	0xef, 0x72, 0xb0, 0xa0, 0xb4, 0x3e, 0x87, 0xd3, 0xac, 0x3b, 0xc9, 0xd3, 0xac, 0x8f, 0x4c, 0x3f,
-	0xa4, 0x63, 0xce, 0xb3, 0xf8, 0x0b, 0x22, 0xdf, 0x75, 0x6e, 0x75, 0xea, 0x8f, 0xe3, 0x0b, 0x22,
-	0xa1, 0xd9, 0x3b, 0xf9, 0x82, 0x48, 0x4a, 0x3c, 0xfb, 0x71, 0x0c, 0x3f, 0xa2, 0x14, 0x94, 0x8f,
-	0xe5, 0x11, 0xa5, 0x50, 0x6d, 0xcc, 0x94, 0x1e, 0xc0, 0x45, 0x49, 0xf0, 0x6e, 0x3f, 0x3f, 0xfb,
-	0x76, 0x3c, 0x4c, 0x8f, 0xe5, 0xd3, 0xc9, 0x5f, 0xe4, 0x61, 0x21, 0x31, 0xe1, 0x59, 0x9e, 0xe0,
-	0x5c, 0x4f, 0x3e, 0xc1, 0xc9, 0xf6, 0xc8, 0xb1, 0x90, 0xe1, 0x91, 0xe3, 0xcc, 0x3b, 0xf2, 0xc8,
-	0xb1, 0xf8, 0x4b, 0x78, 0xe4, 0xf8, 0xfd, 0x1c, 0xf0, 0x24, 0x1f, 0xdd, 0x86, 0xa2, 0xed, 0x1a,
-	0xc4, 0x96, 0x8b, 0x63, 0xb2, 0x5b, 0xe2, 0x95, 0x09, 0x5e, 0x29, 0xe0, 0xb7, 0x5f, 0xf8, 0x27,
-	0x16, 0x32, 0xd0, 0x17, 0x86, 0x5e, 0xa4, 0x3f, 0x37, 0xf5, 0x8b, 0x74, 0x2e, 0x72, 0xdc, 0x2b,
-	0xf4, 0xff, 0x07, 0x95, 0x71, 0x2f, 0xd7, 0xdf, 0xde, 0x21, 0x7e, 0xf5, 0x27, 0x39, 0x98, 0xd7,
-	0x55, 0xe0, 0x37, 0xbc, 0x1d, 0xd3, 0x73, 0xf9, 0xd9, 0xb5, 0x38, 0x46, 0x10, 0x37, 0xbc, 0x23,
-	0x20, 0x8e, 0xf1, 0xcc, 0x6c, 0x0c, 0xf2, 0x9a, 0x65, 0x47, 0xa6, 0xa6, 0xcc, 0xa6, 0x59, 0x67,
-	0x50, 0x2c, 0xb1, 0x6c, 0x79, 0x19, 0xd4, 0x0f, 0x39, 0x65, 0xea, 0xaa, 0x40, 0x53, 0xc2, 0xb1,
-	0xa2, 0x60, 0xa6, 0x7e, 0x48, 0x8f, 0x39, 0xf1, 0x4c, 0xd2, 0xd4, 0x6f, 0x0b, 0x30, 0x8e, 0xf0,
-	0xd5, 0x16, 0xcc, 0x70, 0x96, 0xf7, 0x41, 0x21, 0xf0, 0x0d, 0x39, 0x0a, 0xea, 0xc1, 0x7d, 0xd7,
-	0x37, 0x30, 0x83, 0x33, 0xb4, 0xa9, 0x9e, 0xe8, 0x28, 0x74, 0x2b, 0x08, 0x31, 0x83, 0x57, 0xbf,
-	0x9b, 0x83, 0xfc, 0xad, 0x3a, 0x6a, 0x42, 0x21, 0x3c, 0xa4, 0xd2, 0x12, 0x3e, 0x34, 0x71, 0xe6,
-	0xee, 0xde, 0xde, 0xba, 0x55, 0x97, 0x97, 0xb5, 0xd9, 0x9f, 0x98, 0x71, 0xa3, 0xaf, 0x00, 0x84,
-	0x07, 0x96, 0x6f, 0x76, 0x88, 0x1f, 0x1e, 0x4f, 0x6d, 0x05, 0x77, 0x15, 0xcb, 0xad, 0x7a, 0x63,
-	0xf9, 0xf4, 0x64, 0x7d, 0x5e, 0x87, 0x60, 0x4d, 0x64, 0xf5, 0x5f, 0xf2, 0x30, 0xa7, 0x8c, 0x90,
-	0xbf, 0x7a, 0x21, 0x21, 0x69, 0x59, 0x7e, 0xda, 0x2d, 0xb4, 0x04, 0x18, 0x47, 0x78, 0xf4, 0x55,
-	0x98, 0xa3, 0xaa, 0x1e, 0x28, 0x1c, 0xf6, 0x4b, 0xd3, 0x9b, 0x7b, 0x2d, 0x55, 0x04, 0x54, 0x1e,
-	0x38, 0xae, 0xfd, 0xc5, 0xe2, 0xf9, 0xbd, 0x55, 0x5e, 0xd3, 0x60, 0xd3, 0xdb, 0xad, 0xef, 0x8a,
-	0xeb, 0x3f, 0xd1, 0xbd, 0xd5, 0x04, 0x06, 0xa7, 0x28, 0xd1, 0x8b, 0x30, 0xef, 0x51, 0x8d, 0x73,
-	0x86, 0x73, 0xf2, 0x41, 0xe9, 0x68, 0x70, 0x9c, 0xa0, 0x5a, 0xfb, 0x24, 0x2c, 0x3e, 0x7a, 0xa5,
-	0x82, 0x07, 0x13, 0xd1, 0xad, 0x98, 0xc7, 0x2f, 0x98, 0x90, 0x9a, 0xbd, 0x83, 0xc1, 0x44, 0x24,
-	0xf1, 0xec, 0x60, 0x22, 0x80, 0x45, 0x49, 0x18, 0xbd, 0x8e, 0xbb, 0x91, 0xb8, 0xe5, 0x51, 0x4d,
-	0xdd, 0xf2, 0x40, 0x49, 0xea, 0xe4, 0xa9, 0x9e, 0x2c, 0x12, 0xa4, 0x6b, 0x32, 0x92, 0x16, 0x47,
-	0x78, 0xfe, 0x2a, 0x4a, 0xca, 0x79, 0xef, 0x55, 0xd4, 0x63, 0xfb, 0x2a, 0x8a, 0xc5, 0x99, 0x72,
-	0x96, 0x1e, 0xc7, 0x38, 0x33, 0xaa, 0x58, 0x8f, 0x8e, 0x33, 0xbf, 0x57, 0x54, 0xca, 0xff, 0x92,
-	0xce, 0xce, 0x1f, 0xe5, 0xad, 0xd6, 0xe4, 0xb3, 0x73, 0x11, 0x0a, 0x14, 0xcf, 0x0c, 0x05, 0x66,
-	0xa7, 0xba, 0x54, 0x75, 0x21, 0xd3, 0xa5, 0xaa, 0x52, 0x86, 0x4b, 0x55, 0x73, 0x19, 0x2f, 0x55,
-	0xc1, 0xc4, 0xfb, 0x7c, 0xaf, 0xab, 0xfb, 0x7c, 0x65, 0x6e, 0x1d, 0x37, 0xb3, 0xf8, 0xd3, 0x8c,
-	0x97, 0xf9, 0xe6, 0x1f, 0xf1, 0x32, 0xdf, 0xdb, 0xb9, 0x71, 0xf7, 0xbf, 0x05, 0x58, 0x48, 0xf8,
-	0xeb, 0xa9, 0xaa, 0xe0, 0x2f, 0x24, 0x93, 0x80, 0xe1, 0xd2, 0x76, 0x74, 0x7b, 0x6f, 0x7c, 0x69,
-	0xbb, 0x30, 0x65, 0x2d, 0x35, 0xed, 0xad, 0xb3, 0x94, 0xb6, 0x67, 0xa6, 0x2e, 0x6d, 0x17, 0xa7,
-	0x2f, 0x6d, 0xcf, 0x4e, 0x59, 0xda, 0x4e, 0x6e, 0x57, 0x13, 0x4a, 0xdb, 0x16, 0x94, 0xa5, 0x1b,
-	0x6b, 0x3b, 0xfb, 0x2e, 0x5f, 0x21, 0xd3, 0xbc, 0x9a, 0x8a, 0x66, 0xee, 0x38, 0x08, 0x69, 0x9f,
-	0x71, 0xc6, 0x2b, 0x7d, 0x27, 0x16, 0x87, 0x75, 0xd9, 0xd5, 0xff, 0x9a, 0x81, 0x95, 0x21, 0x3e,
-	0x96, 0x06, 0x47, 0x44, 0xad, 0x74, 0x1a, 0x1c, 0x89, 0x6a, 0xe1, 0x98, 0x86, 0x25, 0x6b, 0x01,
-	0x67, 0xbf, 0x77, 0x4f, 0xf9, 0x25, 0x35, 0x35, 0x5d, 0x85, 0xc1, 0x1a, 0x15, 0x1b, 0xef, 0x3d,
-	0xd7, 0x65, 0x7e, 0x2c, 0x95, 0x08, 0x36, 0x38, 0x14, 0x4b, 0x2c, 0x7a, 0x05, 0x16, 0x0e, 0xa9,
-	0xef, 0x50, 0x7b, 0xcc, 0xaf, 0x19, 0xdc, 0xd6, 0x91, 0x38, 0x49, 0xcb, 0xe6, 0xdf, 0x0d, 0xda,
-	0xfd, 0x11, 0x47, 0x1b, 0x77, 0xba, 0x1c, 0x8c, 0x23, 0x3c, 0xfa, 0x22, 0x3c, 0x95, 0x7e, 0x36,
-	0x12, 0xb5, 0x28, 0xb6, 0xa8, 0x75, 0xc9, 0xfa, 0x54, 0x73, 0x34, 0x19, 0x1e, 0xc7, 0x8f, 0x5e,
-	0x85, 0x45, 0x79, 0x07, 0x21, 0x92, 0x28, 0xbc, 0xde, 0x93, 0x51, 0x0d, 0xf4, 0x76, 0x02, 0x8b,
-	0x53, 0xd4, 0xa8, 0x25, 0x6e, 0x4e, 0xf0, 0x52, 0x45, 0x24, 0xa1, 0x94, 0xbc, 0x75, 0x7e, 0x3b,
-	0x85, 0xc7, 0x43, 0x1c, 0xa8, 0x0e, 0x4b, 0x2e, 0x7f, 0x90, 0x64, 0x39, 0x3d, 0x31, 0x27, 0xf2,
-	0x76, 0x8f, 0x3a, 0x6c, 0xbd, 0x93, 0x44, 0xe3, 0x34, 0x3d, 0xba, 0x09, 0xf3, 0xc4, 0x37, 0x0e,
-	0xac, 0x90, 0x1a, 0xe1, 0xc0, 0x17, 0x2e, 0x53, 0x7b, 0x91, 0x50, 0xd7, 0x70, 0x38, 0x41, 0x59,
-	0xfd, 0x41, 0x0e, 0x56, 0x3a, 0x4c, 0x91, 0x20, 0xa4, 0x4e, 0xd8, 0x20, 0xc6, 0xe1, 0x96, 0x63,
-	0xa2, 0x1d, 0x28, 0x18, 0x76, 0x20, 0xb7, 0xf1, 0xc9, 0x16, 0x2e, 0x7f, 0x7e, 0x49, 0x72, 0x37,
-	0xb7, 0xbb, 0x8d, 0x0b, 0x2c, 0xbb, 0x6a, 0x6e, 0x77, 0x31, 0x93, 0x83, 0xda, 0x90, 0xa7, 0xc1,
-	0xd4, 0xbf, 0x2f, 0x93, 0x94, 0xb6, 0xd5, 0x15, 0xcf, 0xe1, 0xb6, 0xba, 0x38, 0x4f, 0x83, 0xea,
-	0xf7, 0xf2, 0xb0, 0x14, 0xeb, 0xbb, 0x75, 0x44, 0x9d, 0xf0, 0x7c, 0x4a, 0xcd, 0x5a, 0xb8, 0x3e,
-	0xb9, 0xd4, 0x9c, 0xd2, 0x70, 0x6c, 0xd8, 0xfe, 0xe5, 0x54, 0xd8, 0x7e, 0x23, 0xb3, 0xe4, 0xb3,
-	0xc3, 0xf7, 0x7f, 0xcc, 0xc1, 0xc5, 0x14, 0xc7, 0x39, 0xc4, 0x6a, 0xf7, 0x92, 0xb1, 0xda, 0xf3,
-	0x59, 0x3b, 0x35, 0x26, 0x66, 0xfb, 0x4e, 0x7e, 0xa8, 0x33, 0xe7, 0x57, 0xb9, 0xfb, 0x35, 0x58,
-	0xf1, 0xd2, 0xcb, 0x64, 0xea, 0x9f, 0xb6, 0x1b, 0x5a, 0x60, 0xea, 0x76, 0xfc, 0xf0, 0xda, 0xc3,
-	0xc3, 0xed, 0xe8, 0x95, 0xbf, 0x99, 0x09, 0x65, 0xc3, 0xff, 0xcc, 0xc3, 0xa5, 0x91, 0x36, 0xf2,
-	0x5e, 0xf9, 0xf0, 0x1d, 0x2d, 0x1f, 0x3e, 0x0f, 0xf3, 0x89, 0x0a, 0x75, 0xf4, 0xfb, 0x2d, 0xb9,
-	0xb1, 0xbf, 0xdf, 0xf2, 0xb7, 0x39, 0x28, 0x45, 0xc7, 0xb0, 0xe7, 0xe0, 0xb2, 0xee, 0x24, 0x5c,
-	0xd6, 0xe4, 0xfa, 0x53, 0xa4, 0xda, 0xd8, 0x9f, 0xf8, 0xfc, 0x49, 0x0e, 0xe6, 0x23, 0xa2, 0x73,
-	0x70, 0x22, 0xbb, 0x49, 0x27, 0xf2, 0xe1, 0xa9, 0x3b, 0x30, 0xc6, 0x7b, 0x7c, 0x3b, 0x1f, 0xab,
-	0xff, 0x68, 0x6e, 0x43, 0xbf, 0xed, 0x9c, 0x9f, 0xf2, 0xb6, 0xf3, 0x23, 0x26, 0x7a, 0xef, 0x83,
-	0xc2, 0xc0, 0xb7, 0xe5, 0x62, 0x57, 0xd5, 0xca, 0x7b, 0x78, 0x1b, 0x33, 0x38, 0xcb, 0xbc, 0x58,
-	0x16, 0xc6, 0x45, 0x8a, 0xf0, 0x69, 0x3e, 0xca, 0xd1, 0x76, 0x55, 0x8e, 0xb6, 0x9b, 0xce, 0xd1,
-	0x66, 0x63, 0xca, 0xe1, 0x1c, 0xad, 0xfa, 0x3f, 0x05, 0x58, 0x55, 0x77, 0x2b, 0xe8, 0xd7, 0x06,
-	0x96, 0x4f, 0xfb, 0xfc, 0xda, 0xc3, 0x31, 0xcc, 0xda, 0x56, 0xdf, 0x92, 0xb5, 0xe0, 0x69, 0x2e,
-	0xa7, 0x8e, 0x12, 0x53, 0xdb, 0xe6, 0x32, 0x44, 0x96, 0x75, 0x59, 0x65, 0x59, 0x1c, 0x38, 0xf4,
-	0x5e, 0x40, 0x36, 0x88, 0x7e, 0x83, 0xff, 0xe6, 0xd0, 0xd7, 0x06, 0x34, 0x08, 0x23, 0x3b, 0x68,
-	0x3e, 0x5a, 0xeb, 0x58, 0x4a, 0x49, 0x3d, 0xdf, 0x88, 0xc0, 0xc3, 0xcf, 0x37, 0xa2, 0x66, 0xd7,
-	0x2c, 0x28, 0x6b, 0xaa, 0xbf, 0xab, 0xcf, 0x07, 0x0e, 0x61, 0x21, 0xa1, 0xe7, 0xbb, 0xfa, 0x7a,
-	0xc0, 0x86, 0x95, 0xa1, 0xb0, 0x8d, 0xad, 0x09, 0xdb, 0xed, 0x75, 0xe9, 0x88, 0x35, 0xb1, 0x2d,
-	0xe1, 0x58, 0x51, 0xb0, 0x1d, 0x25, 0x74, 0x3d, 0xcb, 0x50, 0xa9, 0x85, 0xda, 0x51, 0xee, 0x0a,
-	0x30, 0x8e, 0xf0, 0xd5, 0x1f, 0xe6, 0x61, 0x39, 0x1d, 0xd7, 0xbd, 0xcd, 0xc7, 0x87, 0x1f, 0x82,
-	0x59, 0xfe, 0x63, 0xd2, 0x34, 0xbd, 0xe3, 0x74, 0x39, 0x14, 0x4b, 0x2c, 0x4b, 0x9a, 0x2c, 0xc7,
-	0xa4, 0x0f, 0x76, 0xe3, 0xa7, 0x62, 0x2a, 0x69, 0x6a, 0x47, 0x08, 0x1c, 0xd3, 0xb0, 0xa6, 0xd9,
-	0xfa, 0x89, 0x56, 0x56, 0xd4, 0x34, 0x5b, 0x5d, 0x98, 0x63, 0xd8, 0x30, 0xa5, 0x56, 0x95, 0x1a,
-	0xa6, 0x11, 0xd5, 0x8f, 0x8f, 0x43, 0xd9, 0xa7, 0xbc, 0xc2, 0xdd, 0x22, 0xc7, 0x01, 0x4f, 0x31,
-	0x8a, 0xb1, 0x0f, 0xc0, 0x31, 0x0a, 0xeb, 0x74, 0xd5, 0x16, 0x88, 0x43, 0x05, 0xe6, 0x0c, 0x8e,
-	0xd4, 0x38, 0x29, 0x67, 0x70, 0xbf, 0xdd, 0xc1, 0x0c, 0x8e, 0x9e, 0x85, 0x99, 0x23, 0xdf, 0x32,
-	0xe5, 0x48, 0xf1, 0xcb, 0xab, 0xf7, 0x71, 0xbb, 0x85, 0x39, 0xb4, 0xfa, 0x57, 0x79, 0x58, 0xbc,
-	0x4b, 0x3c, 0x2f, 0xbe, 0x1b, 0x78, 0x0e, 0x7b, 0xcf, 0xbd, 0xc4, 0xde, 0x33, 0xf9, 0x77, 0x1b,
-	0x92, 0x0a, 0x8e, 0x8d, 0x96, 0x7f, 0x35, 0x15, 0x2d, 0x7f, 0x3c, 0xab, 0xe0, 0xb3, 0x83, 0xe5,
-	0x37, 0x73, 0x80, 0x92, 0x0c, 0xe7, 0xb0, 0xcd, 0xdd, 0x4d, 0x6e, 0x73, 0x1b, 0x19, 0xbb, 0x34,
-	0x66, 0xb3, 0xfb, 0xa3, 0x1c, 0xac, 0x25, 0x09, 0xdf, 0xe5, 0xe3, 0x74, 0xb6, 0x1a, 0x89, 0x11,
-	0x5a, 0xc3, 0xf1, 0x5f, 0x9d, 0x43, 0xb1, 0xc4, 0x56, 0xff, 0x62, 0x68, 0x90, 0x1f, 0xcb, 0xd3,
-	0xf7, 0xff, 0xc8, 0xc3, 0xea, 0x28, 0xe3, 0x79, 0x2f, 0x8a, 0x7e, 0x47, 0xa3, 0x68, 0x0c, 0x89,
-	0x53, 0xce, 0x49, 0xae, 0xee, 0x2a, 0x14, 0x8f, 0xb4, 0x5d, 0x41, 0xd9, 0xfe, 0x7d, 0xbe, 0x2d,
-	0x08, 0x5c, 0xf5, 0x8f, 0x73, 0x10, 0xfd, 0x24, 0x08, 0xda, 0x80, 0x99, 0xbe, 0x6b, 0x0e, 0xfd,
-	0x94, 0xe3, 0x8e, 0x6b, 0xf2, 0x17, 0x61, 0x92, 0x8c, 0x7d, 0x62, 0x4e, 0x88, 0xbe, 0x0c, 0xa5,
-	0x20, 0xf4, 0x49, 0x48, 0x7b, 0xc7, 0x53, 0xff, 0x1c, 0xba, 0x94, 0xd2, 0x95, 0x7c, 0xb1, 0xe5,
-	0x46, 0x10, 0xac, 0x64, 0x56, 0xff, 0x21, 0x07, 0x4b, 0x29, 0x7a, 0xf4, 0x3a, 0x40, 0x9f, 0x3c,
-	0xb8, 0xe7, 0xf8, 0x94, 0x98, 0xc7, 0x13, 0x3d, 0xf2, 0x20, 0xb4, 0xec, 0x9a, 0xf8, 0x87, 0x08,
-	0xb5, 0xb6, 0x13, 0xde, 0xf1, 0xbb, 0xa1, 0x6f, 0x39, 0x3d, 0x51, 0x1f, 0xdf, 0x51, 0x72, 0xb0,
-	0x26, 0x13, 0x61, 0x78, 0xd2, 0xf4, 0x89, 0xe5, 0xec, 0xba, 0x26, 0x6d, 0xd0, 0x7d, 0xd7, 0xa7,
-	0x52, 0x07, 0xf9, 0x63, 0x4b, 0xfc, 0x21, 0x58, 0x6b, 0x24, 0x05, 0x1e, 0xc3, 0xd9, 0xb8, 0xf6,
-	0xe6, 0x5b, 0x97, 0x9f, 0xf8, 0xd9, 0x5b, 0x97, 0x9f, 0xf8, 0xf9, 0x5b, 0x97, 0x9f, 0xf8, 0xc6,
-	0xe9, 0xe5, 0xdc, 0x9b, 0xa7, 0x97, 0x73, 0x3f, 0x3b, 0xbd, 0x9c, 0xfb, 0xf9, 0xe9, 0xe5, 0xdc,
-	0xbf, 0x9d, 0x5e, 0xce, 0x7d, 0xf3, 0xdf, 0x2f, 0x3f, 0xf1, 0xa5, 0xfc, 0xd1, 0xf5, 0xff, 0x0b,
-	0x00, 0x00, 0xff, 0xff, 0xf9, 0x51, 0xa0, 0x1a, 0x56, 0x63, 0x00, 0x00,
+	// 5774 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x3d, 0x4b, 0x6f, 0x24, 0xc7,
+	0x79, 0x9a, 0x17, 0x39, 0x53, 0xc3, 0x67, 0x2d, 0x57, 0xdb, 0xcb, 0xb5, 0x97, 0xf4, 0xc8, 0x16,
+	0xd6, 0x0f, 0x0d, 0xb5, 0x2b, 0x79, 0xbd, 0xf2, 0x43, 0xf6, 0x3c, 0x28, 0xef, 0x78, 0x49, 0xee,
+	0xb8, 0x66, 0x77, 0x1d, 0x3b, 0x89, 0xa5, 0x66, 0x77, 0x71, 0xd8, 0xe2, 0x4c, 0x77, 0xab, 0xbb,
+	0x87, 0x5a, 0x2a, 0x39, 0x38, 0x8f, 0x43, 0x0e, 0x41, 0xe0, 0x24, 0x87, 0x00, 0x31, 0x8c, 0x24,
+	0x4e, 0x80, 0x24, 0x8e, 0x0d, 0x18, 0x08, 0xe0, 0x83, 0x91, 0xe4, 0x10, 0x18, 0x88, 0x10, 0x04,
+	0x81, 0x91, 0x5c, 0x74, 0x11, 0x13, 0x31, 0x0f, 0xe4, 0x92, 0x3f, 0xb0, 0xc8, 0x21, 0xf8, 0xaa,
+	0xaa, 0xab, 0xab, 0x7b, 0x66, 0x38, 0xd3, 0xab, 0x5d, 0x7a, 0x0f, 0xba, 0xb1, 0xbf, 0x57, 0x7d,
+	0x55, 0xf5, 0xd5, 0x57, 0xdf, 0xf7, 0x55, 0xd5, 0x10, 0x6d, 0x04, 0x07, 0xd4, 0x0f, 0x74, 0xe3,
+	0xa0, 0x6a, 0x39, 0xf0, 0xf7, 0x86, 0xee, 0x5a, 0x1b, 0x6e, 0x4f, 0x0f, 0xf6, 0x1c, 0xaf, 0xbf,
+	0x71, 0x78, 0x75, 0xa3, 0x4b, 0x6d, 0xea, 0xe9, 0x01, 0x35, 0xab, 0xae, 0xe7, 0x04, 0x0e, 0x5e,
+	0x53, 0x18, 0xaa, 0xc1, 0x01, 0xad, 0xea, 0xae, 0x55, 0x0d, 0x19, 0xaa, 0x87, 0x57, 0x57, 0x9f,
+	0xeb, 0x5a, 0xc1, 0xfe, 0x60, 0xb7, 0x6a, 0x38, 0xfd, 0x8d, 0xae, 0xd3, 0x75, 0x36, 0x18, 0xdf,
+	0xee, 0x60, 0x8f, 0x7d, 0xb1, 0x0f, 0xf6, 0x17, 0x97, 0xb7, 0x5a, 0x39, 0xb8, 0xe1, 0x43, 0xdb,
+	0xd0, 0xae, 0xe1, 0x78, 0x74, 0x44, 0x9b, 0xab, 0x2f, 0x46, 0x34, 0x7d, 0xdd, 0xd8, 0xb7, 0x6c,
+	0xea, 0x1d, 0x6d, 0xb8, 0x07, 0x5d, 0xc6, 0xe4, 0x51, 0xdf, 0x19, 0x78, 0x06, 0x4d, 0xc5, 0xe5,
+	0x6f, 0xf4, 0x69, 0xa0, 0x8f, 0x6a, 0x6b, 0x63, 0x1c, 0x97, 0x37, 0xb0, 0x03, 0xab, 0x3f, 0xdc,
+	0xcc, 0xf5, 0x49, 0x0c, 0xbe, 0xb1, 0x4f, 0xfb, 0xfa, 0x10, 0xdf, 0x0b, 0xe3, 0xf8, 0x06, 0x81,
+	0xd5, 0xdb, 0xb0, 0xec, 0xc0, 0x0f, 0xbc, 0x21, 0xa6, 0x6b, 0xa3, 0xa6, 0x4b, 0x77, 0xdd, 0x9e,
+	0x65, 0xe8, 0x81, 0xe5, 0xd8, 0x23, 0x7a, 0x54, 0xf9, 0x4e, 0x06, 0x95, 0x6a, 0xa6, 0xe9, 0xd8,
+	0x1d, 0x97, 0x1a, 0xf8, 0x53, 0xa8, 0x18, 0x50, 0x5b, 0xb7, 0x83, 0x56, 0x53, 0xcb, 0xac, 0x67,
+	0xae, 0x94, 0xea, 0x4b, 0x6f, 0x1f, 0xaf, 0x3d, 0x75, 0x72, 0xbc, 0x56, 0xbc, 0x23, 0xe0, 0x44,
+	0x52, 0xe0, 0x4f, 0xa3, 0xb2, 0xd1, 0x1b, 0xf8, 0x01, 0xf5, 0x76, 0xf4, 0x3e, 0xd5, 0xb2, 0x8c,
+	0xe1, 0x9c, 0x60, 0x28, 0x37, 0x22, 0x14, 0x51, 0xe9, 0xf0, 0xc7, 0xd1, 0xec, 0x21, 0xf5, 0x7c,
+	0xcb, 0xb1, 0xb5, 0x1c, 0x63, 0x59, 0x14, 0x2c, 0xb3, 0xf7, 0x38, 0x98, 0x84, 0xf8, 0xca, 0x8f,
+	0x33, 0x28, 0x57, 0x73, 0x5d, 0xfc, 0x1a, 0x2a, 0xc2, 0x94, 0x98, 0x7a, 0xa0, 0x33, 0xbd, 0xca,
+	0xd7, 0x9e, 0xaf, 0xf2, 0x11, 0xaa, 0xaa, 0x23, 0x54, 0x75, 0x0f, 0xba, 0x00, 0xf0, 0xab, 0x40,
+	0x5d, 0x3d, 0xbc, 0x5a, 0xbd, 0xbd, 0xfb, 0x3a, 0x35, 0x82, 0x6d, 0x1a, 0xe8, 0x75, 0x2c, 0x5a,
+	0x41, 0x11, 0x8c, 0x48, 0xa9, 0x78, 0x1b, 0xe5, 0x7d, 0x97, 0x1a, 0xac, 0x13, 0xe5, 0x6b, 0x9f,
+	0xac, 0x8e, 0x32, 0x64, 0x65, 0x28, 0x41, 0x76, 0xcd, 0x75, 0x61, 0xd0, 0xea, 0x73, 0x42, 0x70,
+	0x1e, 0xbe, 0x08, 0x13, 0x53, 0x79, 0x27, 0x83, 0x96, 0x6a, 0x83, 0x60, 0xff, 0xad, 0xaf, 0xd1,
+	0xdd, 0x7d, 0xc7, 0x39, 0xa8, 0x99, 0xa6, 0x87, 0x5f, 0x45, 0xb3, 0xbb, 0x03, 0xab, 0x17, 0x58,
+	0xb6, 0xe8, 0xc4, 0x8d, 0xea, 0x84, 0xf5, 0x52, 0xad, 0x73, 0xfa, 0xa4, 0xa8, 0x7a, 0x19, 0x86,
+	0x4b, 0x20, 0x49, 0x28, 0x15, 0x1b, 0xa8, 0x48, 0xef, 0x07, 0xd4, 0xb3, 0xf5, 0x9e, 0xe8, 0xc8,
+	0x4b, 0x13, 0x5b, 0xd8, 0x14, 0x0c, 0x43, 0x4d, 0xcc, 0xc1, 0xac, 0x87, 0x58, 0x22, 0x05, 0x57,
+	0x3a, 0x68, 0xae, 0xee, 0x38, 0x60, 0x80, 0xba, 0x0b, 0x73, 0xd3, 0x40, 0x39, 0xdd, 0x75, 0x45,
+	0x8f, 0x3e, 0x3a, 0xb1, 0xbd, 0x9a, 0xeb, 0xd6, 0xcb, 0x62, 0xc4, 0x60, 0x6e, 0x09, 0x70, 0x57,
+	0x2e, 0xa2, 0x0b, 0x63, 0xba, 0x5a, 0xf9, 0xa3, 0x2c, 0x2a, 0x37, 0x3a, 0xad, 0xdb, 0x2e, 0xd8,
+	0xad, 0xe3, 0x9d, 0x81, 0x2d, 0x90, 0x98, 0x2d, 0x3c, 0x3f, 0xb1, 0x4b, 0x8a, 0x76, 0xe3, 0x0c,
+	0x02, 0x7f, 0x03, 0xcd, 0xf8, 0x81, 0x1e, 0x0c, 0x7c, 0x66, 0xf3, 0xe5, 0x6b, 0xd7, 0x52, 0x49,
+	0x65, 0x9c, 0xf5, 0x05, 0x21, 0x77, 0x86, 0x7f, 0x13, 0x21, 0xb1, 0xf2, 0x45, 0x84, 0x15, 0xe2,
+	0x57, 0xa8, 0x1e, 0x0c, 0xbc, 0xd8, 0x32, 0xcb, 0x4c, 0x58, 0x66, 0x7f, 0x9f, 0x41, 0x8b, 0x8a,
+	0x84, 0x2d, 0xcb, 0x0f, 0xf0, 0x2f, 0x0d, 0x0d, 0x73, 0x75, 0xba, 0x61, 0x06, 0x6e, 0x36, 0xc8,
+	0xd2, 0x75, 0x84, 0x10, 0x65, 0x88, 0xbf, 0x8a, 0x0a, 0x56, 0x40, 0xfb, 0xbe, 0x96, 0x5d, 0xcf,
+	0x5d, 0x29, 0x5f, 0xfb, 0x54, 0x9a, 0xd1, 0xa8, 0xcf, 0x0b, 0xc1, 0x85, 0x16, 0x88, 0x20, 0x5c,
+	0x52, 0xe5, 0x4f, 0xe2, 0x9d, 0x78, 0x22, 0xfd, 0xd9, 0x5f, 0xe7, 0xd0, 0xf2, 0xd0, 0xbc, 0xa6,
+	0x98, 0x29, 0xdc, 0x46, 0x2b, 0x7e, 0xe0, 0x78, 0x7a, 0x97, 0xde, 0xa3, 0xb6, 0xe9, 0x78, 0x82,
+	0x40, 0xe8, 0xfa, 0x21, 0xc1, 0xb7, 0xd2, 0x19, 0x41, 0x43, 0x46, 0x72, 0xe2, 0xab, 0xa8, 0xe0,
+	0xee, 0xeb, 0x3e, 0x15, 0xba, 0x5f, 0x0a, 0xc7, 0xb6, 0x0d, 0xc0, 0x07, 0xc7, 0x6b, 0x88, 0xed,
+	0x0e, 0xec, 0x8b, 0x70, 0x4a, 0xfc, 0x2c, 0x9a, 0xf1, 0xa8, 0xee, 0x3b, 0xb6, 0x96, 0x67, 0x3c,
+	0xd2, 0x2e, 0x09, 0x83, 0x12, 0x81, 0xc5, 0xd7, 0x10, 0xf2, 0x68, 0xe0, 0x1d, 0x35, 0x9c, 0x81,
+	0x1d, 0x68, 0x85, 0xf5, 0xcc, 0x95, 0x42, 0xb4, 0xf2, 0x88, 0xc4, 0x10, 0x85, 0x0a, 0xff, 0x6e,
+	0x06, 0x5d, 0xea, 0xe9, 0x7e, 0x40, 0x68, 0xcb, 0xb6, 0x02, 0x4b, 0xef, 0x59, 0x6f, 0x59, 0x76,
+	0xf7, 0x8e, 0xd5, 0x07, 0xf3, 0xe8, 0xbb, 0xda, 0x0c, 0x33, 0xc5, 0x4f, 0x4c, 0x67, 0x8a, 0xc0,
+	0x56, 0x7f, 0x46, 0xb4, 0x78, 0x69, 0x6b, 0xbc, 0x58, 0x72, 0x5a, 0x9b, 0x15, 0x93, 0x19, 0x56,
+	0xdb, 0x73, 0xee, 0x1f, 0xdd, 0x76, 0xc1, 0xfb, 0xfb, 0x78, 0x03, 0x95, 0x6c, 0xbd, 0x4f, 0x7d,
+	0x57, 0x37, 0xa8, 0x98, 0xb4, 0x65, 0xd1, 0x4e, 0x69, 0x27, 0x44, 0x90, 0x88, 0x06, 0xaf, 0xa3,
+	0xbc, 0x1d, 0x19, 0x95, 0xf4, 0x10, 0xcc, 0x9a, 0x18, 0xa6, 0xf2, 0xfb, 0x59, 0x34, 0x2b, 0x6c,
+	0xec, 0x0c, 0x7c, 0xdc, 0x4e, 0xcc, 0xc7, 0x4d, 0xb1, 0xfe, 0xb8, 0x66, 0x63, 0xfd, 0xdb, 0xbd,
+	0x84, 0x7f, 0xab, 0x4e, 0x2d, 0xf1, 0x74, 0xdf, 0xf6, 0xbd, 0x2c, 0x9a, 0x13, 0x94, 0xcc, 0x10,
+	0xcf, 0x60, 0x68, 0x3a, 0xb1, 0xa1, 0xb9, 0x3a, 0x6d, 0x47, 0x64, 0x14, 0x35, 0x72, 0x7c, 0x7e,
+	0x31, 0x31, 0x3e, 0x2f, 0xa4, 0x13, 0x7b, 0xfa, 0x20, 0xfd, 0x34, 0x83, 0x96, 0x54, 0xf2, 0x33,
+	0x70, 0xe0, 0x24, 0xee, 0xc0, 0x9f, 0x4b, 0xd5, 0x9d, 0x31, 0x1e, 0xfc, 0xf7, 0x12, 0xdd, 0x60,
+	0x2e, 0x7c, 0x1d, 0xe5, 0x83, 0x23, 0x37, 0x5c, 0x64, 0x72, 0x68, 0xef, 0x1c, 0xb9, 0x94, 0x30,
+	0x0c, 0x78, 0xb0, 0x1e, 0x3d, 0xa4, 0x3d, 0x2d, 0x1b, 0xf7, 0x60, 0x5b, 0x00, 0x94, 0x1e, 0x8c,
+	0x7d, 0x11, 0x4e, 0x99, 0xc6, 0x65, 0xff, 0x76, 0x06, 0xe1, 0xe1, 0xa9, 0x48, 0xe3, 0xb3, 0x9f,
+	0x09, 0x3d, 0x2c, 0xd7, 0x6f, 0x3e, 0xe6, 0x61, 0x87, 0x7d, 0x6a, 0xee, 0x34, 0x9f, 0x5a, 0xf9,
+	0x9d, 0x5c, 0x7c, 0x8c, 0x60, 0x1c, 0xce, 0x60, 0x4d, 0x84, 0xb3, 0x90, 0x9d, 0x3c, 0x0b, 0xb9,
+	0xa9, 0x67, 0xe1, 0x73, 0x68, 0xbe, 0xa7, 0x07, 0xd4, 0x0f, 0xc2, 0x5d, 0x8c, 0x6f, 0x27, 0xe7,
+	0x05, 0xeb, 0xfc, 0x96, 0x8a, 0x24, 0x71, 0x5a, 0xd8, 0xac, 0x4d, 0xea, 0x1b, 0x9e, 0xc5, 0x3c,
+	0xb2, 0x56, 0x88, 0x6f, 0xd6, 0xcd, 0x08, 0x45, 0x54, 0x3a, 0x7c, 0x1b, 0x9d, 0x37, 0x9c, 0xbe,
+	0xab, 0x07, 0xd6, 0x6e, 0x8f, 0x8a, 0x81, 0x84, 0x5e, 0x68, 0x33, 0xeb, 0xb9, 0x2b, 0xa5, 0xfa,
+	0xc5, 0x93, 0xe3, 0xb5, 0xf3, 0x8d, 0x51, 0x04, 0x64, 0x34, 0x5f, 0xe5, 0x9f, 0x32, 0x68, 0x25,
+	0x39, 0x21, 0x67, 0xb0, 0xfe, 0xee, 0xc5, 0xd7, 0x5f, 0x3a, 0x2f, 0x05, 0x3a, 0x8e, 0x59, 0x83,
+	0x7f, 0x9e, 0x41, 0x0b, 0x11, 0xa9, 0x47, 0x7d, 0xd8, 0xeb, 0xd4, 0x15, 0x78, 0x49, 0x9d, 0xfb,
+	0x07, 0xc7, 0x6b, 0x65, 0x41, 0xa6, 0x98, 0xc2, 0x3a, 0xca, 0xef, 0x3b, 0x7e, 0x90, 0x34, 0x96,
+	0x9b, 0x8e, 0x1f, 0x10, 0x86, 0x01, 0x0a, 0xd7, 0xf1, 0x02, 0x66, 0x2b, 0x85, 0x88, 0xa2, 0xed,
+	0x78, 0x01, 0x61, 0x18, 0x46, 0xa1, 0x07, 0xfb, 0xc2, 0x24, 0x22, 0x0a, 0x3d, 0xd8, 0x27, 0x0c,
+	0x53, 0x79, 0x05, 0x9d, 0x0b, 0x15, 0x75, 0xdd, 0x5e, 0x6c, 0x67, 0x76, 0x82, 0xbb, 0xae, 0xa9,
+	0x07, 0x5c, 0xe5, 0xa2, 0xb2, 0x33, 0x87, 0x08, 0x12, 0xd1, 0x54, 0x7e, 0x12, 0x79, 0x1d, 0x98,
+	0x78, 0xc7, 0xa6, 0x76, 0x30, 0x85, 0xd7, 0xf9, 0x8d, 0x0c, 0x2a, 0x7a, 0x94, 0x25, 0x84, 0xfe,
+	0xd4, 0xc9, 0x56, 0xb2, 0x1d, 0x22, 0x04, 0xd4, 0x3f, 0x15, 0x4e, 0x75, 0x08, 0x79, 0x70, 0xbc,
+	0xa6, 0x8d, 0xa3, 0x26, 0xb2, 0x61, 0xb0, 0xbe, 0xb1, 0x64, 0xe0, 0xa3, 0x4c, 0xea, 0x5b, 0x1e,
+	0x35, 0x59, 0x3f, 0x0a, 0x91, 0x8f, 0x6a, 0x72, 0x30, 0x09, 0xf1, 0x40, 0x6a, 0x0c, 0x3c, 0x8f,
+	0xda, 0x7c, 0xd6, 0x14, 0xd2, 0x06, 0x07, 0x93, 0x10, 0x0f, 0x03, 0xac, 0x1f, 0xea, 0x56, 0x4f,
+	0xdf, 0xed, 0x51, 0x31, 0x81, 0x72, 0x80, 0x6b, 0x21, 0x82, 0x44, 0x34, 0x20, 0x7b, 0xc0, 0x86,
+	0xda, 0xd4, 0xf2, 0x71, 0xd9, 0x7c, 0x06, 0x4c, 0x12, 0xe2, 0x2b, 0x7f, 0x9a, 0x53, 0xe6, 0xc2,
+	0x36, 0x2d, 0xb6, 0x64, 0x27, 0xcf, 0xc5, 0x4b, 0x72, 0x73, 0xe5, 0x26, 0xf7, 0x91, 0xf8, 0x3e,
+	0xf9, 0xe0, 0x78, 0x6d, 0x51, 0x8a, 0x8b, 0x6f, 0x9d, 0xb8, 0x0b, 0x3e, 0xc8, 0x0f, 0xda, 0x9e,
+	0xb3, 0x4b, 0x21, 0xe2, 0xd3, 0x72, 0xa9, 0x03, 0x4c, 0xc5, 0x5f, 0x29, 0x82, 0x48, 0x5c, 0x2e,
+	0x3e, 0x44, 0x18, 0x00, 0x77, 0x3c, 0xdd, 0xf6, 0x99, 0x22, 0xac, 0xb5, 0x7c, 0xea, 0xd6, 0x56,
+	0x45, 0x6b, 0x78, 0x6b, 0x48, 0x1a, 0x19, 0xd1, 0x82, 0xb2, 0xb1, 0x14, 0x4e, 0x0d, 0xd6, 0x3f,
+	0x8e, 0x66, 0xfb, 0xd4, 0xf7, 0xf5, 0x2e, 0xd5, 0x66, 0xe2, 0x1b, 0xda, 0x36, 0x07, 0x93, 0x10,
+	0x5f, 0x79, 0xb7, 0x88, 0x96, 0xc3, 0x59, 0xf2, 0xa8, 0x49, 0x6d, 0x88, 0x99, 0xcf, 0x60, 0x13,
+	0x52, 0xb3, 0xb9, 0x6c, 0xda, 0x6c, 0x2e, 0x37, 0x65, 0x36, 0x57, 0x45, 0x88, 0x06, 0x86, 0xd9,
+	0xa8, 0x35, 0xa8, 0x17, 0xb0, 0xf9, 0x99, 0xab, 0x2f, 0x80, 0x4a, 0x9b, 0x77, 0x1a, 0x4d, 0x0e,
+	0x25, 0x0a, 0x05, 0xfe, 0x24, 0x2a, 0xf1, 0xaf, 0x5b, 0xf4, 0x88, 0x0d, 0xf1, 0x5c, 0x7d, 0x1e,
+	0x96, 0x02, 0x27, 0xbf, 0x45, 0x8f, 0x48, 0x84, 0xc7, 0x0d, 0xb4, 0x0c, 0x1f, 0xb5, 0x76, 0xab,
+	0xd1, 0xb3, 0xa8, 0x1d, 0xb0, 0x36, 0x66, 0x18, 0xd3, 0xf9, 0x93, 0xe3, 0xb5, 0x65, 0x60, 0x8a,
+	0x21, 0xc9, 0x30, 0x3d, 0xfe, 0x12, 0x5a, 0x8a, 0x01, 0xa1, 0xe1, 0x59, 0x26, 0x63, 0xe5, 0xe4,
+	0x78, 0x6d, 0x29, 0x26, 0x03, 0xda, 0x1f, 0xa2, 0xc6, 0x15, 0x34, 0x63, 0xe8, 0xac, 0xed, 0x22,
+	0xe3, 0x43, 0x60, 0x0f, 0xa2, 0x6f, 0x02, 0x83, 0xd7, 0x50, 0xc1, 0xd0, 0x41, 0x74, 0x89, 0x91,
+	0x94, 0x60, 0xa7, 0xe0, 0xfd, 0xe1, 0x70, 0x18, 0x28, 0x23, 0xea, 0x04, 0x8a, 0x06, 0x4a, 0xd1,
+	0x5e, 0xa1, 0x80, 0x81, 0x32, 0xa4, 0xbe, 0xe5, 0x68, 0xa0, 0x22, 0x45, 0x23, 0x3c, 0xb4, 0x1e,
+	0x38, 0x07, 0xd4, 0xd6, 0xe6, 0xd8, 0xb4, 0xb1, 0xd6, 0xef, 0x00, 0x80, 0x70, 0x38, 0xfe, 0x2c,
+	0x5a, 0xd8, 0x0d, 0xab, 0x50, 0x0c, 0xa1, 0xcd, 0x33, 0x4a, 0x7c, 0x72, 0xbc, 0xb6, 0x50, 0x8f,
+	0x61, 0x48, 0x82, 0x12, 0x78, 0x0d, 0xea, 0x05, 0xd6, 0x1e, 0x14, 0xf3, 0x28, 0xa8, 0xb3, 0x10,
+	0xf1, 0x36, 0x62, 0x18, 0x92, 0xa0, 0x04, 0x1b, 0x1c, 0xf8, 0xd4, 0x63, 0xb9, 0xdc, 0x62, 0xdc,
+	0x06, 0xef, 0x0a, 0x38, 0x91, 0x14, 0xf8, 0x19, 0x94, 0xd5, 0x7d, 0x6d, 0x29, 0x6e, 0x7a, 0xad,
+	0xbe, 0x4b, 0x3d, 0xdf, 0xb1, 0x61, 0x1f, 0xca, 0xea, 0x3e, 0xbe, 0x8a, 0x8a, 0xba, 0xff, 0x65,
+	0xcf, 0x19, 0xb8, 0xbe, 0xb6, 0xcc, 0xa2, 0x10, 0x66, 0x0b, 0x0a, 0x19, 0x47, 0x12, 0x49, 0x86,
+	0xbf, 0x93, 0x41, 0x65, 0xdd, 0x87, 0x06, 0x37, 0xef, 0x07, 0x9e, 0xae, 0x61, 0x16, 0x04, 0x34,
+	0xa6, 0xde, 0x7f, 0xe4, 0xaa, 0xad, 0xd6, 0x22, 0x29, 0x9b, 0x76, 0xe0, 0x1d, 0xd5, 0x5f, 0x0c,
+	0x6b, 0x08, 0x4a, 0xfb, 0x92, 0xe4, 0xc1, 0x18, 0x38, 0x51, 0xb5, 0x59, 0x7d, 0x19, 0x2d, 0x25,
+	0xc5, 0xe2, 0x25, 0x94, 0x3b, 0xa0, 0x47, 0xdc, 0x87, 0x13, 0xf8, 0x13, 0xaf, 0xa0, 0xc2, 0xa1,
+	0xde, 0x1b, 0x88, 0x98, 0x92, 0xf0, 0x8f, 0xcf, 0x66, 0x6f, 0x64, 0x2a, 0xff, 0x9c, 0x41, 0xe7,
+	0x87, 0x34, 0x3d, 0x83, 0x98, 0xea, 0x6b, 0xf1, 0x98, 0xea, 0x5a, 0xfa, 0xe1, 0x1c, 0x13, 0x54,
+	0xfd, 0xb8, 0x24, 0x83, 0xaa, 0xb0, 0x3a, 0xf7, 0x21, 0x94, 0xb7, 0xdc, 0x43, 0x5f, 0x44, 0x28,
+	0x45, 0xd8, 0xd0, 0x5a, 0xed, 0x7b, 0x1d, 0xc2, 0xa0, 0xf8, 0x0a, 0x2a, 0xba, 0x83, 0xdd, 0x9e,
+	0x65, 0x6c, 0xd5, 0xd9, 0xf0, 0x14, 0x79, 0x35, 0xb6, 0x2d, 0x60, 0x44, 0x62, 0x61, 0x15, 0x5a,
+	0x36, 0xaf, 0xcc, 0x6e, 0xd5, 0x99, 0x93, 0x2b, 0xf2, 0x55, 0xd8, 0x92, 0x50, 0xa2, 0x50, 0xe0,
+	0xe7, 0xd1, 0x6c, 0xd7, 0x1d, 0xb0, 0x88, 0x97, 0x87, 0x56, 0x4f, 0x83, 0x8b, 0xff, 0x72, 0xfb,
+	0xae, 0x08, 0xe7, 0xc2, 0x3f, 0x49, 0x48, 0x06, 0x25, 0x27, 0x6a, 0xc3, 0x46, 0xbe, 0xad, 0xb3,
+	0x7c, 0xdd, 0xd8, 0xa7, 0xe6, 0xa0, 0x47, 0x99, 0xaf, 0x2b, 0x46, 0x25, 0xa7, 0xcd, 0x11, 0x34,
+	0x64, 0x24, 0x27, 0xfe, 0x1c, 0xca, 0xee, 0xeb, 0xa2, 0x92, 0xf3, 0xcc, 0xc4, 0x41, 0xbe, 0x59,
+	0xab, 0xcf, 0x9c, 0x1c, 0xaf, 0x65, 0x6f, 0xd6, 0x48, 0x76, 0x5f, 0x87, 0xc5, 0xeb, 0x1f, 0x58,
+	0xae, 0xdc, 0xcf, 0x7d, 0x6d, 0x76, 0x3d, 0x17, 0x2e, 0xde, 0x4e, 0x0c, 0x43, 0x12, 0x94, 0xf8,
+	0x2b, 0xa8, 0xb0, 0x67, 0xf5, 0xa8, 0xaf, 0x15, 0xd9, 0x04, 0x7f, 0x6c, 0x62, 0xdb, 0xaf, 0x58,
+	0x3d, 0x25, 0x50, 0x86, 0x2f, 0x9f, 0x70, 0x11, 0xf8, 0x00, 0x15, 0xa0, 0x44, 0xed, 0x6b, 0x25,
+	0x26, 0xeb, 0xb3, 0xd3, 0x1a, 0x8b, 0x30, 0x80, 0xea, 0x4d, 0x60, 0xe6, 0x4b, 0xee, 0x62, 0xd8,
+	0x00, 0x83, 0xfd, 0xfa, 0xbf, 0xad, 0x15, 0xe1, 0x0f, 0x36, 0x0b, 0xbc, 0x0d, 0xbc, 0x87, 0xca,
+	0x86, 0x6f, 0x85, 0x65, 0x43, 0x0d, 0x4d, 0x5b, 0x42, 0x18, 0xaa, 0x0a, 0xd7, 0x17, 0xd9, 0xe6,
+	0x17, 0xc1, 0x89, 0x2a, 0x18, 0xfb, 0x68, 0x49, 0x4f, 0xd4, 0xdf, 0x99, 0xab, 0x9e, 0x26, 0xc1,
+	0x18, 0x3a, 0x40, 0x60, 0xbb, 0x51, 0x12, 0x4a, 0x86, 0x1a, 0xc0, 0xdb, 0xe8, 0x9c, 0x30, 0x13,
+	0x1a, 0x78, 0x96, 0xe1, 0x77, 0xa8, 0x77, 0x48, 0x3d, 0xe6, 0xf9, 0x8b, 0x32, 0xdd, 0x38, 0xb7,
+	0x39, 0x4c, 0x42, 0x46, 0xf1, 0x41, 0x56, 0x69, 0xb9, 0x87, 0xd7, 0x9b, 0x03, 0xbd, 0xd7, 0x01,
+	0x7d, 0xd9, 0xc6, 0x50, 0x8c, 0xa2, 0xb4, 0x56, 0x5b, 0x41, 0x92, 0x38, 0x2d, 0xbe, 0x81, 0xe6,
+	0xb8, 0xcc, 0x86, 0xd5, 0xb3, 0x06, 0x7d, 0xb6, 0x31, 0x14, 0xeb, 0x2b, 0x82, 0x77, 0x6e, 0x53,
+	0xc1, 0x91, 0x18, 0x25, 0x6e, 0xa2, 0x25, 0xc3, 0xb1, 0x03, 0x1d, 0x1c, 0x10, 0xe1, 0x87, 0x7b,
+	0x62, 0x83, 0xd0, 0x04, 0xf7, 0x52, 0x23, 0x81, 0x27, 0x43, 0x1c, 0xb8, 0x03, 0xb1, 0x72, 0xd7,
+	0xd3, 0x4d, 0xaa, 0x3d, 0xcd, 0xc6, 0xfd, 0xca, 0xc4, 0x71, 0xbf, 0xcb, 0xe9, 0xd5, 0xa8, 0x9a,
+	0x01, 0x48, 0x28, 0x69, 0xf5, 0x06, 0x42, 0x91, 0xb5, 0xa5, 0xf2, 0xc4, 0x7f, 0x9c, 0x43, 0x97,
+	0x84, 0xdd, 0xb2, 0x9d, 0xa7, 0xd6, 0x6e, 0x11, 0x71, 0xa2, 0x0a, 0x0e, 0x4e, 0x56, 0x35, 0x33,
+	0xe3, 0xaa, 0x9a, 0x30, 0xa0, 0xbe, 0x65, 0x77, 0x07, 0x3d, 0x5d, 0x2d, 0xaa, 0xcb, 0x01, 0xed,
+	0x28, 0x38, 0x12, 0xa3, 0x84, 0xea, 0xb1, 0x2c, 0x9f, 0x9a, 0xc2, 0xb3, 0xc9, 0xf8, 0x50, 0xd6,
+	0x58, 0x4d, 0xa2, 0x50, 0x41, 0xa9, 0xa5, 0x0b, 0x7a, 0x0a, 0xdf, 0x26, 0x57, 0x2e, 0x53, 0x9e,
+	0x70, 0x9c, 0x5a, 0xba, 0x29, 0x4c, 0x28, 0xdd, 0xac, 0xa3, 0xfc, 0x81, 0x65, 0x9b, 0xda, 0x4c,
+	0xbc, 0x7f, 0xb7, 0x2c, 0xdb, 0x24, 0x0c, 0x03, 0x81, 0xca, 0x21, 0xf5, 0x76, 0x43, 0x2f, 0xc4,
+	0x02, 0x95, 0x7b, 0x00, 0x20, 0x1c, 0x0e, 0x0e, 0xda, 0xdf, 0x77, 0xbc, 0x80, 0x69, 0xcc, 0x1c,
+	0x4f, 0x89, 0x3b, 0xe8, 0x8e, 0x84, 0x12, 0x85, 0x02, 0xe8, 0x21, 0xd6, 0xe8, 0x3a, 0x9e, 0x45,
+	0xb9, 0x73, 0x11, 0xf4, 0x0d, 0x09, 0x25, 0x0a, 0x45, 0xe5, 0x07, 0x59, 0xf4, 0xa1, 0x53, 0xa6,
+	0xc8, 0x3f, 0x83, 0xb8, 0xfc, 0x06, 0x9a, 0x63, 0x23, 0x1b, 0x3f, 0x8c, 0x90, 0x73, 0xfc, 0x65,
+	0x05, 0x47, 0x62, 0x94, 0xd8, 0x45, 0xa5, 0xf0, 0x84, 0x1e, 0x0a, 0xa3, 0xe0, 0x48, 0x3f, 0x3f,
+	0xad, 0x23, 0x1d, 0xd5, 0xdb, 0xa8, 0x51, 0x05, 0xe1, 0x93, 0xa8, 0x91, 0xca, 0xf7, 0xb3, 0x68,
+	0xfd, 0xb4, 0xe1, 0x1a, 0x0a, 0x33, 0xb2, 0x8f, 0x3c, 0xcc, 0xd8, 0x0d, 0xc3, 0x0c, 0xde, 0xe1,
+	0x2f, 0xbc, 0x9f, 0x0e, 0xfb, 0xa3, 0x23, 0x0e, 0xf0, 0x46, 0x7b, 0xba, 0xd5, 0xa3, 0x26, 0x63,
+	0xda, 0xf4, 0x3c, 0xc7, 0xd3, 0xf2, 0x71, 0x6f, 0xf4, 0x4a, 0x02, 0x4f, 0x86, 0x38, 0x2a, 0xeb,
+	0xe8, 0xf2, 0x98, 0xb6, 0x45, 0xb5, 0x05, 0x8a, 0x27, 0x61, 0x2a, 0x75, 0x06, 0x01, 0xda, 0x76,
+	0x3c, 0x40, 0xbb, 0x32, 0xed, 0xc8, 0x8d, 0x09, 0xcb, 0x7e, 0x9a, 0x97, 0x61, 0xd9, 0x36, 0xd7,
+	0x0c, 0xaf, 0xa2, 0xac, 0xe5, 0x0a, 0x77, 0x86, 0x04, 0x53, 0xb6, 0xd5, 0x26, 0x59, 0xcb, 0x95,
+	0x45, 0xab, 0xec, 0xd8, 0xa2, 0x95, 0x9a, 0x1c, 0xe4, 0x26, 0x26, 0x07, 0x10, 0xe4, 0xe9, 0xbe,
+	0xff, 0xa6, 0xe3, 0x99, 0x22, 0xcf, 0xe4, 0x41, 0x9e, 0x80, 0x11, 0x89, 0x05, 0x9f, 0xe0, 0x7a,
+	0xd6, 0xa1, 0x48, 0x56, 0x0a, 0x51, 0xaa, 0xd5, 0x96, 0x50, 0xa2, 0x50, 0x30, 0x7a, 0xdd, 0xf7,
+	0xdb, 0xfb, 0x1e, 0x94, 0x9d, 0x67, 0x14, 0x7a, 0x09, 0x25, 0x0a, 0x05, 0x36, 0xd0, 0x4c, 0x4f,
+	0xdf, 0xa5, 0x3d, 0xee, 0xc5, 0xca, 0xd7, 0x3e, 0x37, 0xed, 0xc0, 0x8a, 0x61, 0xab, 0x6e, 0x31,
+	0x6e, 0x1e, 0xcd, 0xc8, 0x02, 0x03, 0x07, 0x12, 0x21, 0x1a, 0xd7, 0xd0, 0x0c, 0xec, 0x75, 0x41,
+	0x18, 0x7d, 0x5d, 0x54, 0x0c, 0xa3, 0x0a, 0x97, 0x7b, 0x58, 0x89, 0x03, 0x28, 0x22, 0x11, 0xec,
+	0xd3, 0x27, 0x82, 0x11, 0x7f, 0x1d, 0x15, 0x5c, 0x38, 0x85, 0x63, 0x39, 0x69, 0xf9, 0xda, 0x8b,
+	0x29, 0xd5, 0x64, 0x27, 0x78, 0x4a, 0xfd, 0x1d, 0x3e, 0x09, 0x97, 0xb8, 0xfa, 0x12, 0x2a, 0x2b,
+	0x9d, 0x48, 0xb5, 0x49, 0xfe, 0x24, 0x8b, 0xce, 0x8d, 0x68, 0x08, 0x3f, 0x17, 0xab, 0x5b, 0x5d,
+	0x4c, 0xd4, 0x4d, 0x4b, 0x8c, 0x48, 0x29, 0x62, 0x71, 0xd3, 0xcb, 0x9e, 0x6a, 0x7a, 0xb9, 0xa9,
+	0x4c, 0x2f, 0x9f, 0xca, 0xf4, 0x0a, 0x29, 0x4c, 0x6f, 0x26, 0xa5, 0xe9, 0xcd, 0x4e, 0x32, 0xbd,
+	0xca, 0xbb, 0x59, 0xb4, 0x28, 0x06, 0xaf, 0xed, 0x39, 0x2e, 0xf5, 0x82, 0x23, 0xbc, 0x85, 0x56,
+	0xfa, 0xfa, 0x7d, 0x01, 0x85, 0xa8, 0xce, 0x32, 0xe8, 0xce, 0xa0, 0x2f, 0x8a, 0x98, 0x1a, 0x64,
+	0x1b, 0xdb, 0x23, 0xf0, 0x64, 0x24, 0x17, 0xfe, 0x0c, 0x9a, 0xef, 0xeb, 0xf7, 0x77, 0x1c, 0x93,
+	0xb6, 0x1d, 0x13, 0xc4, 0xf0, 0xf5, 0xbb, 0x0c, 0xb1, 0xe0, 0xb6, 0x8a, 0x20, 0x71, 0x3a, 0xfc,
+	0xad, 0x0c, 0x9a, 0x77, 0x20, 0x12, 0x70, 0x7a, 0x26, 0xd1, 0x03, 0xcb, 0xd1, 0x72, 0xe9, 0xd2,
+	0xec, 0xb0, 0x43, 0xd5, 0xdb, 0xaa, 0x14, 0xbe, 0x4a, 0x64, 0x38, 0x1a, 0xc3, 0x91, 0x78, 0x83,
+	0xab, 0x5f, 0x42, 0x78, 0x98, 0x37, 0x95, 0x71, 0xfe, 0x4f, 0x41, 0x8e, 0x6f, 0xe8, 0xbb, 0xf1,
+	0xaf, 0xa2, 0xa2, 0xa1, 0xbb, 0xba, 0x61, 0x05, 0x20, 0x04, 0xba, 0xf4, 0xf2, 0xb4, 0x5d, 0x0a,
+	0x65, 0x54, 0x1b, 0x42, 0x00, 0xef, 0xcd, 0x7a, 0x68, 0x6b, 0x21, 0xf8, 0xc1, 0xf1, 0xda, 0x5c,
+	0x48, 0x0b, 0x8e, 0x9c, 0xc8, 0x16, 0xf1, 0x6f, 0x41, 0xed, 0xa2, 0xd7, 0x73, 0x0c, 0x3d, 0x60,
+	0x25, 0x64, 0xee, 0xcb, 0x6b, 0xa9, 0x35, 0xa8, 0x45, 0x32, 0xb8, 0x12, 0xe1, 0x41, 0x7f, 0x59,
+	0xc1, 0x0c, 0xe9, 0xa1, 0x36, 0x0d, 0x33, 0x5c, 0x12, 0xdf, 0x2c, 0xc4, 0x04, 0x45, 0xbe, 0xf8,
+	0xb0, 0x8a, 0x50, 0x93, 0xab, 0xf1, 0x11, 0x59, 0x0c, 0x0f, 0xe1, 0x43, 0x4a, 0x44, 0x8d, 0xae,
+	0x1e, 0xa0, 0xf9, 0xd8, 0x50, 0x8e, 0x98, 0xdc, 0xa6, 0x3a, 0xb9, 0x13, 0x36, 0xd4, 0x6a, 0x18,
+	0xe9, 0x54, 0xbf, 0x3a, 0xd0, 0xed, 0xc0, 0x0a, 0x8e, 0x14, 0x63, 0x58, 0xb5, 0xd1, 0x52, 0x72,
+	0xd4, 0x1e, 0x6b, 0x7b, 0x3d, 0xb4, 0x10, 0x1f, 0x9c, 0xc7, 0xd9, 0x5a, 0xe5, 0xbd, 0xf3, 0x32,
+	0x16, 0x61, 0x27, 0xc7, 0x5f, 0x44, 0x68, 0xcf, 0xb2, 0xe1, 0x36, 0x07, 0xf5, 0x7c, 0x66, 0xe8,
+	0xa5, 0xfa, 0x1a, 0xb8, 0xa2, 0x57, 0x24, 0xf4, 0xc1, 0xf1, 0xda, 0xbc, 0xfc, 0x62, 0x39, 0x88,
+	0xc2, 0x92, 0xbe, 0xde, 0x6c, 0x5a, 0xbe, 0xdb, 0xd3, 0x8f, 0x46, 0xd5, 0x9b, 0x9b, 0x11, 0x8a,
+	0xa8, 0x74, 0xf2, 0x74, 0x23, 0x3f, 0xf6, 0x74, 0x23, 0x45, 0xbe, 0xd2, 0x44, 0x65, 0x9b, 0x06,
+	0x6f, 0x3a, 0xde, 0x81, 0x38, 0xd3, 0x04, 0xf2, 0x4a, 0xa8, 0xc3, 0x4e, 0x84, 0x7a, 0x10, 0xff,
+	0x24, 0x2a, 0x1b, 0x64, 0xd0, 0xe2, 0xb3, 0x49, 0xc1, 0x8b, 0x6a, 0xb3, 0xf1, 0x73, 0xd9, 0x1d,
+	0x15, 0x49, 0xe2, 0xb4, 0x4a, 0xd9, 0xbd, 0xd1, 0x6a, 0x12, 0xad, 0x18, 0x1f, 0x86, 0x46, 0x84,
+	0x22, 0x2a, 0x1d, 0xbe, 0x8a, 0xca, 0x3e, 0xf7, 0xd9, 0x8c, 0xed, 0x1c, 0xef, 0x28, 0xb0, 0x74,
+	0x22, 0x30, 0x51, 0x69, 0xe0, 0x20, 0xca, 0xb4, 0xfd, 0xa6, 0xd3, 0xd7, 0x2d, 0x5b, 0x2b, 0xc5,
+	0xef, 0xe0, 0x34, 0x77, 0x3a, 0x1c, 0x41, 0x22, 0x1a, 0x4c, 0xd0, 0xd3, 0xbc, 0x6e, 0x56, 0xeb,
+	0xb1, 0x7a, 0x58, 0x60, 0x1d, 0x52, 0x9e, 0x96, 0x21, 0x66, 0x1c, 0xab, 0x27, 0xc7, 0x6b, 0x4f,
+	0xb7, 0x47, 0x52, 0x90, 0x31, 0x9c, 0xd8, 0x41, 0xc5, 0x3d, 0x5e, 0x5a, 0xf1, 0x45, 0xa5, 0x64,
+	0x23, 0x65, 0x25, 0x48, 0xce, 0x4f, 0x51, 0x00, 0xc0, 0x2a, 0x13, 0xe5, 0x42, 0x22, 0x1b, 0xc1,
+	0x6f, 0xc2, 0x86, 0xcc, 0xf6, 0x15, 0xc8, 0x0f, 0xe7, 0xa6, 0xbd, 0xa2, 0x18, 0xdf, 0x91, 0xea,
+	0x1f, 0x13, 0x6d, 0xa2, 0xb6, 0x94, 0xc5, 0x4e, 0xc9, 0xe2, 0x64, 0x44, 0x69, 0x0a, 0xbf, 0x8a,
+	0x4a, 0x3a, 0x3f, 0xea, 0xa5, 0xbe, 0x36, 0xbf, 0x9e, 0x4b, 0xd3, 0x55, 0x11, 0x17, 0x45, 0xeb,
+	0x47, 0x00, 0x7c, 0x12, 0xc9, 0xc4, 0xbf, 0x99, 0x41, 0x8b, 0xa6, 0x63, 0x1c, 0x88, 0xba, 0x71,
+	0xcd, 0xeb, 0xfa, 0xda, 0x42, 0xba, 0xcd, 0x01, 0xd6, 0x7d, 0xb5, 0x19, 0x97, 0xc1, 0xbd, 0xf2,
+	0x05, 0xd1, 0xf2, 0x62, 0x02, 0x4b, 0x92, 0x4d, 0xc2, 0xfe, 0xb4, 0x74, 0x30, 0xd8, 0xa5, 0x3d,
+	0x1a, 0x44, 0x7a, 0x2c, 0x32, 0x3d, 0xea, 0xa9, 0xf4, 0xb8, 0x95, 0x10, 0xc2, 0x15, 0x91, 0xf9,
+	0x57, 0x12, 0x4d, 0x86, 0x5a, 0xc5, 0xdf, 0xce, 0x20, 0xac, 0xbb, 0x16, 0x2f, 0x6c, 0x45, 0xca,
+	0x2c, 0x31, 0x65, 0x9a, 0xa9, 0x94, 0xa9, 0x0d, 0x89, 0xe1, 0xea, 0xc8, 0xe3, 0xc4, 0x5a, 0xbb,
+	0x95, 0x20, 0x20, 0x23, 0xda, 0xc6, 0x3f, 0xca, 0xa0, 0x55, 0xa8, 0x5a, 0x79, 0x4e, 0xaf, 0x07,
+	0xf3, 0x6a, 0xeb, 0x5d, 0x55, 0xb5, 0x65, 0xa6, 0xda, 0x56, 0x2a, 0xd5, 0x1a, 0x63, 0xc5, 0x71,
+	0x15, 0xc3, 0xf5, 0xb1, 0x3a, 0x9e, 0x90, 0x9c, 0xa2, 0x13, 0x1b, 0x45, 0x5f, 0xd4, 0x9e, 0x15,
+	0x55, 0xf1, 0x43, 0x8c, 0x62, 0x67, 0x48, 0x4c, 0x62, 0x14, 0x87, 0x09, 0xc8, 0x88, 0xb6, 0xf1,
+	0x21, 0x5a, 0x31, 0x92, 0x67, 0x07, 0x84, 0xee, 0x69, 0x2b, 0xa2, 0xe6, 0x37, 0x22, 0x33, 0xda,
+	0x72, 0x0c, 0xbd, 0xc7, 0xcb, 0x2f, 0x84, 0xee, 0x51, 0x8f, 0xda, 0x06, 0xe5, 0xb1, 0x70, 0x63,
+	0x84, 0x24, 0x32, 0x52, 0x3e, 0x6e, 0xa0, 0x3c, 0x1c, 0x06, 0x6a, 0xe7, 0xd7, 0x33, 0x53, 0xd5,
+	0xbf, 0x37, 0x03, 0xc3, 0xe4, 0x87, 0x13, 0xf0, 0x17, 0x61, 0xcc, 0xf8, 0x2b, 0x08, 0xc3, 0x25,
+	0x0e, 0x48, 0x24, 0x6a, 0x3e, 0xc4, 0xcb, 0xf0, 0x97, 0x76, 0x81, 0x15, 0xe8, 0xe4, 0x40, 0xdc,
+	0x1c, 0xa2, 0x20, 0x23, 0xb8, 0x70, 0x20, 0x37, 0x2c, 0x36, 0x27, 0x5a, 0xba, 0x8a, 0x08, 0x9b,
+	0x93, 0x9d, 0x88, 0x9f, 0x4f, 0xc6, 0xb9, 0xc4, 0x7e, 0xc7, 0x66, 0x41, 0x6d, 0x06, 0x7b, 0x68,
+	0xd1, 0x37, 0xf4, 0x9e, 0x65, 0x77, 0x43, 0x3f, 0xa4, 0x5d, 0x7c, 0x38, 0x87, 0x26, 0xdd, 0x4a,
+	0x27, 0x2e, 0x8f, 0x24, 0x1b, 0xc0, 0xaf, 0xa3, 0xf9, 0x5d, 0xe5, 0xda, 0xbc, 0xaf, 0xad, 0x4e,
+	0x79, 0x71, 0x4e, 0xbd, 0x6c, 0x1f, 0xed, 0xc1, 0x2a, 0xd4, 0x27, 0x71, 0xd1, 0x50, 0x3a, 0xd5,
+	0x5d, 0x59, 0x8e, 0xbb, 0xc4, 0x0f, 0x37, 0x05, 0x27, 0xaa, 0x49, 0x0c, 0x51, 0xa8, 0x56, 0xeb,
+	0x68, 0x65, 0x94, 0xe3, 0x4c, 0x93, 0x6c, 0xac, 0x36, 0xd0, 0xf9, 0x91, 0x4e, 0x2f, 0x95, 0x90,
+	0x4d, 0x74, 0x61, 0x8c, 0xb3, 0x4a, 0x25, 0x66, 0x1b, 0xad, 0x4d, 0x70, 0x2c, 0x69, 0xb5, 0x1a,
+	0xb3, 0xf8, 0x53, 0x89, 0x79, 0x19, 0x2d, 0x25, 0xed, 0x35, 0x55, 0x3a, 0xf7, 0x97, 0x65, 0x34,
+	0x1f, 0xbb, 0x38, 0x0b, 0x67, 0xf9, 0x3d, 0x98, 0x37, 0x53, 0x1c, 0x25, 0xb2, 0xb3, 0xfc, 0x2d,
+	0x06, 0x21, 0x02, 0xa3, 0x46, 0x90, 0xd9, 0x09, 0x11, 0xe4, 0x0b, 0xf1, 0xeb, 0xe0, 0x1f, 0x4e,
+	0x5e, 0x07, 0x0f, 0x2f, 0xe3, 0xc6, 0x2e, 0x2f, 0x52, 0x84, 0x8c, 0xe8, 0x3c, 0x2e, 0x9f, 0xee,
+	0x46, 0x9a, 0x3c, 0x9f, 0x8b, 0x4c, 0x54, 0x39, 0xc2, 0x53, 0x04, 0xab, 0x57, 0x54, 0x0a, 0xa7,
+	0x5f, 0x51, 0x51, 0x6e, 0xbd, 0xcc, 0x9c, 0x7a, 0xeb, 0xe5, 0x35, 0x35, 0xa8, 0x99, 0x4d, 0xe7,
+	0x03, 0xc4, 0xc5, 0x37, 0xe5, 0xf6, 0x53, 0x28, 0x49, 0x8d, 0x6a, 0xde, 0x80, 0x6b, 0x62, 0x3c,
+	0x6b, 0xd1, 0x4a, 0xe9, 0xa2, 0xb5, 0x30, 0x67, 0x94, 0x99, 0x6d, 0x31, 0x84, 0x28, 0xb1, 0x5a,
+	0x08, 0x22, 0xb2, 0x19, 0x3e, 0x1d, 0xe2, 0x32, 0x18, 0x8f, 0x6d, 0x53, 0x4d, 0x87, 0xe0, 0x54,
+	0xa7, 0x23, 0x14, 0x46, 0x14, 0xc1, 0x10, 0xe9, 0xab, 0x21, 0x7b, 0x39, 0x1e, 0xe9, 0x8f, 0x0d,
+	0xdb, 0x9b, 0x68, 0xc9, 0x76, 0x4c, 0xf6, 0xf7, 0xb6, 0xee, 0x1f, 0x74, 0xac, 0xb7, 0x28, 0x0b,
+	0x63, 0x0b, 0x51, 0x68, 0xb4, 0x93, 0xc0, 0x93, 0x21, 0x0e, 0x38, 0xe9, 0x31, 0x6d, 0xbf, 0xd5,
+	0x16, 0xd7, 0x3e, 0x64, 0x51, 0xaf, 0xb9, 0xd3, 0x69, 0xb5, 0x09, 0xc7, 0x41, 0x52, 0xe1, 0xd1,
+	0xae, 0xe5, 0x07, 0xde, 0x51, 0xab, 0xcd, 0x83, 0x49, 0x91, 0x54, 0x90, 0x08, 0x4c, 0x54, 0x1a,
+	0xf6, 0xc0, 0x82, 0x82, 0xcd, 0xe9, 0xde, 0x91, 0xd2, 0x05, 0x71, 0x94, 0x17, 0x3d, 0xb0, 0x18,
+	0x41, 0x43, 0x46, 0x72, 0x26, 0x13, 0xa2, 0xa5, 0x29, 0x13, 0x22, 0x55, 0x11, 0x85, 0x48, 0x5b,
+	0x1e, 0xa3, 0x88, 0x2a, 0x68, 0x24, 0x27, 0x48, 0x4c, 0x0e, 0x63, 0xab, 0x7d, 0xf8, 0xa2, 0x86,
+	0xd9, 0xe0, 0x4b, 0x89, 0x3b, 0x23, 0x68, 0xc8, 0x48, 0xce, 0x31, 0x12, 0xaf, 0x6b, 0xe7, 0x26,
+	0x4a, 0xbc, 0x3e, 0x52, 0xe2, 0x75, 0xdc, 0x44, 0x08, 0xa2, 0x60, 0xfe, 0x44, 0x85, 0x85, 0x43,
+	0xa5, 0xfa, 0x47, 0x43, 0x3b, 0xbc, 0x25, 0x31, 0x90, 0x21, 0x45, 0x5f, 0x2c, 0x83, 0x55, 0xf8,
+	0x12, 0xfb, 0xdf, 0xf9, 0x69, 0xf6, 0x3f, 0xdc, 0x46, 0x0b, 0xd2, 0xb6, 0x99, 0x73, 0x63, 0x07,
+	0xb0, 0xa5, 0xfa, 0x15, 0xc1, 0xb7, 0xd0, 0x88, 0x61, 0x1f, 0x0c, 0x41, 0x48, 0x82, 0xbf, 0xf2,
+	0xc3, 0x1c, 0x2a, 0x35, 0x1c, 0x7b, 0xcf, 0xea, 0x6e, 0xeb, 0x67, 0xf1, 0x84, 0xf1, 0x1e, 0xca,
+	0x8b, 0x13, 0xab, 0xdc, 0x74, 0xc5, 0xf1, 0x50, 0xb7, 0x6a, 0x53, 0x0f, 0xc4, 0xed, 0x1f, 0x59,
+	0x7f, 0x00, 0x10, 0x61, 0xf2, 0xb0, 0x8d, 0xd0, 0xae, 0x65, 0xeb, 0xde, 0x11, 0xc0, 0xb4, 0xdc,
+	0xb4, 0xd7, 0x1d, 0xa4, 0xf4, 0xba, 0x64, 0xe6, 0x6d, 0xc8, 0x5e, 0x44, 0x08, 0xa2, 0xb4, 0xb0,
+	0xfa, 0x19, 0x54, 0x92, 0xc4, 0xa9, 0x36, 0xd7, 0x2f, 0xa0, 0xc5, 0x44, 0x5b, 0x93, 0xd8, 0xe7,
+	0xd4, 0xbd, 0xf5, 0xef, 0x32, 0x68, 0x5e, 0x6a, 0x7d, 0x06, 0xa7, 0x59, 0xb7, 0xe3, 0xa7, 0x59,
+	0x9f, 0x98, 0x7e, 0x48, 0xc7, 0x9c, 0x67, 0xb1, 0x17, 0x44, 0x9e, 0x63, 0xdf, 0x6c, 0xd7, 0x9e,
+	0xc4, 0x17, 0x44, 0x5c, 0xb3, 0x47, 0xf9, 0x82, 0x48, 0x48, 0x3c, 0xfd, 0x71, 0x0c, 0x3b, 0xa2,
+	0xe4, 0x94, 0x4f, 0xe4, 0x11, 0x25, 0x57, 0x6d, 0xcc, 0x94, 0xee, 0xa3, 0x73, 0x82, 0xe0, 0x71,
+	0x3f, 0x3f, 0xfb, 0x6e, 0x34, 0x4c, 0x4f, 0xe4, 0xd3, 0xc9, 0x77, 0xb3, 0x68, 0x3e, 0x36, 0xe1,
+	0x69, 0x9e, 0xe0, 0x5c, 0x8d, 0x3f, 0xc1, 0x49, 0xf7, 0xc8, 0x31, 0x97, 0xe2, 0x91, 0x63, 0xfe,
+	0x91, 0x3c, 0x72, 0x2c, 0xfc, 0x1c, 0x1e, 0x39, 0xfe, 0x20, 0x83, 0x58, 0x92, 0x8f, 0x6f, 0xa1,
+	0x02, 0x94, 0xec, 0x7b, 0x62, 0x71, 0x4c, 0x76, 0x4b, 0xac, 0x32, 0x01, 0xac, 0xfc, 0xf6, 0x0b,
+	0xfb, 0x24, 0x5c, 0x06, 0xfe, 0xda, 0xd0, 0x8b, 0xf4, 0xe7, 0xa6, 0x7e, 0x91, 0xce, 0x44, 0x8e,
+	0x7b, 0x85, 0xfe, 0x0b, 0x48, 0x1b, 0xf7, 0x72, 0xfd, 0xfd, 0x1d, 0xe2, 0x57, 0xfe, 0x26, 0x83,
+	0xe6, 0x54, 0x15, 0xd8, 0x0d, 0x6f, 0xdb, 0x74, 0x1d, 0x76, 0x76, 0xcd, 0x8f, 0x11, 0xf8, 0x0d,
+	0xef, 0x10, 0x48, 0x22, 0x3c, 0x98, 0x8d, 0xa1, 0xc3, 0x45, 0x41, 0x2d, 0x1b, 0x37, 0x9b, 0x46,
+	0x0d, 0xa0, 0x44, 0x60, 0x61, 0x79, 0x19, 0xd4, 0x0b, 0x18, 0x65, 0xe2, 0xaa, 0x40, 0x43, 0xc0,
+	0x89, 0xa4, 0x00, 0x53, 0x3f, 0xa0, 0x47, 0x8c, 0x38, 0x1f, 0x37, 0xf5, 0x5b, 0x1c, 0x4c, 0x42,
+	0x7c, 0xa5, 0x89, 0xf2, 0x8c, 0xe5, 0xc3, 0x28, 0xe7, 0x7b, 0x86, 0x18, 0x05, 0xf9, 0xe0, 0xbe,
+	0xe3, 0x19, 0x04, 0xe0, 0x80, 0x36, 0xe5, 0x13, 0x1d, 0x89, 0x6e, 0xfa, 0x01, 0x01, 0x78, 0xe5,
+	0xfb, 0x19, 0x94, 0xbd, 0x59, 0x83, 0xb7, 0xfd, 0xc1, 0x01, 0x15, 0x96, 0xf0, 0xec, 0xc4, 0x99,
+	0xbb, 0x73, 0x6b, 0xf3, 0x66, 0x4d, 0x5c, 0xd6, 0x86, 0x3f, 0x09, 0x70, 0xe3, 0x57, 0x11, 0x0a,
+	0xf6, 0x2d, 0xcf, 0x6c, 0xeb, 0x5e, 0x70, 0x34, 0xb5, 0x15, 0xdc, 0x91, 0x2c, 0x37, 0x6b, 0xf5,
+	0x25, 0xb8, 0xd2, 0xa3, 0x42, 0x88, 0x22, 0xb2, 0xf2, 0x2f, 0x59, 0x54, 0x92, 0x46, 0xc8, 0x5e,
+	0xbd, 0xe8, 0x81, 0xde, 0xb4, 0xbc, 0xa4, 0x5b, 0x68, 0x72, 0x30, 0x09, 0xf1, 0xf8, 0x75, 0x54,
+	0xa2, 0xb2, 0x1e, 0xc8, 0x1d, 0xf6, 0x4b, 0xd3, 0x9b, 0x7b, 0x35, 0x51, 0x04, 0x94, 0x1e, 0x58,
+	0xc2, 0x49, 0x24, 0x9e, 0xdd, 0x5b, 0x65, 0x35, 0x0d, 0x98, 0xde, 0x4e, 0x6d, 0x87, 0x5f, 0xff,
+	0x09, 0xef, 0xad, 0xc6, 0x30, 0x24, 0x41, 0x89, 0x5f, 0x44, 0x73, 0x2e, 0x55, 0x38, 0xf3, 0x8c,
+	0x93, 0x0d, 0x4a, 0x5b, 0x81, 0x93, 0x18, 0xd5, 0xea, 0xe7, 0xd1, 0xc2, 0xc3, 0x57, 0x2a, 0x58,
+	0x30, 0x11, 0xde, 0x8a, 0x79, 0xf2, 0x82, 0x09, 0xa1, 0xd9, 0x23, 0x0c, 0x26, 0x42, 0x89, 0xa7,
+	0x07, 0x13, 0x3e, 0x5a, 0x10, 0x84, 0xe1, 0xeb, 0xb8, 0xeb, 0xb1, 0x5b, 0x1e, 0x95, 0xc4, 0x2d,
+	0x0f, 0x1c, 0xa7, 0x8e, 0x9f, 0xea, 0x89, 0x22, 0x41, 0xb2, 0x26, 0x23, 0x68, 0x49, 0x88, 0x67,
+	0xaf, 0xa2, 0x84, 0x9c, 0x0f, 0x5e, 0x45, 0x3d, 0xb1, 0xaf, 0xa2, 0x20, 0xce, 0x14, 0xb3, 0xf4,
+	0x24, 0xc6, 0x99, 0x61, 0xc5, 0x7a, 0x74, 0x9c, 0xf9, 0xaf, 0x05, 0xa9, 0xfc, 0xcf, 0xe9, 0xec,
+	0xfc, 0x61, 0xde, 0x6a, 0x4d, 0x3e, 0x3b, 0xe7, 0xa1, 0x40, 0xe1, 0xd4, 0x50, 0x60, 0x66, 0xaa,
+	0x4b, 0x55, 0xb3, 0xa9, 0x2e, 0x55, 0x15, 0x53, 0x5c, 0xaa, 0x2a, 0xa5, 0xbc, 0x54, 0x85, 0x26,
+	0xde, 0xe7, 0x7b, 0x4d, 0xde, 0xe7, 0x2b, 0xaf, 0xe7, 0xa6, 0xfa, 0x9d, 0x21, 0x65, 0xee, 0x53,
+	0x5e, 0xe6, 0x9b, 0x7b, 0xd8, 0xcb, 0x7c, 0xcf, 0xa2, 0x19, 0x57, 0x1f, 0xf8, 0xd4, 0x14, 0x17,
+	0xf4, 0x25, 0x5d, 0x9b, 0x41, 0x89, 0xc0, 0xbe, 0x9f, 0x9b, 0x79, 0xff, 0x57, 0x40, 0xf3, 0x31,
+	0xbf, 0x3e, 0x55, 0xb5, 0xfc, 0x85, 0x78, 0xb2, 0x30, 0x5c, 0x02, 0x17, 0x22, 0x4f, 0x29, 0x81,
+	0xe7, 0xa6, 0xac, 0xb9, 0x26, 0xbd, 0x7a, 0x9a, 0x12, 0x78, 0x7e, 0xea, 0x12, 0x78, 0x61, 0xfa,
+	0x12, 0xf8, 0xcc, 0x94, 0x25, 0xf0, 0xf8, 0xb6, 0x36, 0xa1, 0x04, 0x6e, 0xa1, 0xb2, 0x70, 0x77,
+	0x2d, 0x7b, 0xcf, 0x61, 0x2b, 0x69, 0x9a, 0xd7, 0x55, 0xe1, 0xcc, 0x1d, 0xf9, 0x01, 0xed, 0x03,
+	0x67, 0xe4, 0x11, 0xb6, 0x23, 0x71, 0x44, 0x95, 0x0d, 0x2b, 0x16, 0xea, 0x8a, 0xcc, 0x8b, 0x14,
+	0xe3, 0x2b, 0x76, 0x47, 0xc0, 0x89, 0xa4, 0xc0, 0x2f, 0xa3, 0x05, 0x0f, 0x2a, 0xa5, 0x86, 0xd5,
+	0xa3, 0x3c, 0x7f, 0x2b, 0x31, 0x5f, 0xf0, 0x74, 0x58, 0xf3, 0x23, 0x31, 0x2c, 0x49, 0x50, 0x63,
+	0x07, 0x2d, 0xf3, 0x94, 0x4a, 0x40, 0xd9, 0xe6, 0x85, 0xd2, 0x6f, 0x95, 0xf0, 0xdc, 0x6f, 0x2b,
+	0x29, 0x88, 0x0c, 0xcb, 0x86, 0xee, 0xb9, 0x8e, 0xc9, 0x55, 0x2d, 0x33, 0x55, 0x65, 0xf7, 0xda,
+	0x02, 0x4e, 0x24, 0x45, 0xe5, 0xbf, 0xf3, 0x68, 0x79, 0x68, 0x10, 0xa1, 0x76, 0x10, 0x8e, 0x58,
+	0x33, 0x59, 0x3b, 0x08, 0xc7, 0xb5, 0x49, 0x22, 0x1a, 0xc8, 0x70, 0x7d, 0xc6, 0x7e, 0xf7, 0xae,
+	0x74, 0xe6, 0xd2, 0x4e, 0x3b, 0x12, 0x43, 0x14, 0x2a, 0x30, 0x3e, 0x38, 0x92, 0x6c, 0x35, 0x93,
+	0xd9, 0x73, 0x9d, 0x41, 0x89, 0xc0, 0xc2, 0x55, 0xa3, 0x03, 0xea, 0xd9, 0xb4, 0x37, 0xe6, 0x27,
+	0x20, 0x6e, 0xa9, 0x48, 0x12, 0xa7, 0x85, 0xc5, 0xe0, 0xf8, 0xad, 0xfe, 0x88, 0xf3, 0xa0, 0xdb,
+	0x1d, 0x06, 0x26, 0x21, 0x1e, 0x7f, 0x1d, 0x5d, 0x48, 0xbe, 0xb5, 0x09, 0x5b, 0xe4, 0xfb, 0xfa,
+	0x9a, 0x60, 0xbd, 0xd0, 0x18, 0x4d, 0x46, 0xc6, 0xf1, 0x83, 0x11, 0x89, 0x8b, 0x1b, 0xa1, 0x44,
+	0xbe, 0x55, 0x48, 0x23, 0xba, 0x15, 0xc3, 0x92, 0x04, 0x35, 0x9c, 0x87, 0x00, 0x84, 0xd5, 0x77,
+	0x42, 0x09, 0xc5, 0xf8, 0x55, 0xfd, 0x5b, 0x09, 0x3c, 0x19, 0xe2, 0xc0, 0x35, 0xb4, 0xe8, 0xb0,
+	0x57, 0x5c, 0x96, 0xdd, 0xe5, 0x73, 0x22, 0xae, 0x44, 0xc9, 0x13, 0xea, 0xdb, 0x71, 0x34, 0x49,
+	0xd2, 0xc3, 0x33, 0x0e, 0xdd, 0x33, 0xf6, 0xad, 0x80, 0x1a, 0xc1, 0xc0, 0xe3, 0x86, 0xac, 0x3c,
+	0xe3, 0xa8, 0x29, 0x38, 0x12, 0xa3, 0xac, 0xfc, 0x30, 0x83, 0x96, 0xdb, 0xa0, 0x88, 0x1f, 0xc0,
+	0xc1, 0x91, 0x6e, 0x1c, 0x6c, 0xda, 0x26, 0xde, 0x46, 0x39, 0xa3, 0xe7, 0x6b, 0x99, 0x29, 0x97,
+	0xbb, 0xf8, 0xcd, 0x2a, 0xc1, 0xdd, 0xd8, 0xea, 0xd4, 0x67, 0x21, 0x25, 0x6d, 0x6c, 0x75, 0x08,
+	0xc8, 0xc1, 0x2d, 0x94, 0xa5, 0xfe, 0xd4, 0x3f, 0xca, 0x13, 0x97, 0xb6, 0xd9, 0xe1, 0x6f, 0x08,
+	0x37, 0x3b, 0x24, 0x4b, 0xfd, 0xca, 0x5f, 0x65, 0xd1, 0x62, 0xa4, 0xef, 0xe6, 0x21, 0xb5, 0x83,
+	0xb3, 0xa9, 0xcf, 0x2b, 0x39, 0xce, 0xe4, 0xfa, 0x7c, 0x42, 0xc3, 0xb1, 0xb9, 0xce, 0x37, 0x13,
+	0xb9, 0xce, 0xf5, 0xd4, 0x92, 0x4f, 0xcf, 0x79, 0xfe, 0x31, 0x83, 0xce, 0x25, 0x38, 0xce, 0x20,
+	0xc0, 0xbd, 0x1b, 0x0f, 0x70, 0x9f, 0x4f, 0xdb, 0xa9, 0x31, 0x81, 0xee, 0xf7, 0xb2, 0x43, 0x9d,
+	0x39, 0xbb, 0x72, 0xe7, 0xaf, 0xa0, 0x65, 0x37, 0xb9, 0x4c, 0xa6, 0xfe, 0x3d, 0xc0, 0xa1, 0x05,
+	0x26, 0x9f, 0x14, 0x0c, 0xaf, 0x3d, 0x32, 0xdc, 0x8e, 0x5a, 0x2e, 0xcd, 0x4f, 0xa8, 0xb5, 0xfe,
+	0x57, 0x16, 0x9d, 0x1f, 0x69, 0x23, 0x1f, 0xd4, 0x5c, 0x1f, 0x69, 0xcd, 0xf5, 0x79, 0x34, 0x17,
+	0x2b, 0xeb, 0x87, 0x3f, 0x7a, 0x93, 0x19, 0xfb, 0xa3, 0x37, 0x7f, 0x9b, 0x41, 0xc5, 0xf0, 0xec,
+	0xfa, 0x0c, 0x5c, 0xd6, 0xed, 0x98, 0xcb, 0x9a, 0x5c, 0xb4, 0x0b, 0x55, 0x1b, 0xfb, 0xbb, 0xa8,
+	0x50, 0x5c, 0x0d, 0x89, 0xce, 0xc0, 0x89, 0xec, 0xc4, 0x9d, 0xc8, 0xc7, 0xa7, 0xee, 0xc0, 0x18,
+	0xef, 0xf1, 0xdd, 0x6c, 0xa4, 0xfe, 0xc3, 0xb9, 0x0d, 0xf5, 0x8a, 0x78, 0x76, 0xca, 0x2b, 0xe2,
+	0x0f, 0x99, 0x1d, 0x7f, 0x18, 0xe5, 0x06, 0x5e, 0x4f, 0xcb, 0xc7, 0x4b, 0xbc, 0x77, 0xc9, 0x16,
+	0x01, 0x38, 0xa4, 0xab, 0x03, 0x9f, 0x93, 0x8a, 0xf0, 0x69, 0x2e, 0x4c, 0x6c, 0x77, 0x64, 0x62,
+	0xbb, 0x93, 0x4c, 0x6c, 0x67, 0x22, 0xca, 0xe1, 0xc4, 0xb6, 0xf2, 0xbf, 0x39, 0xb4, 0x22, 0x2f,
+	0xa4, 0xd0, 0x37, 0x06, 0x96, 0x47, 0xfb, 0xec, 0xae, 0xc8, 0x11, 0x9a, 0xe9, 0x59, 0x7d, 0x4b,
+	0x14, 0xd0, 0xa7, 0xb9, 0xd1, 0x3b, 0x4a, 0x4c, 0x75, 0x8b, 0xc9, 0xe0, 0xa9, 0xe9, 0x65, 0x99,
+	0x9a, 0x32, 0xe0, 0xd0, 0x23, 0x0b, 0xd1, 0x20, 0xfe, 0x35, 0xf6, 0x43, 0x4d, 0x6f, 0x0c, 0xa8,
+	0x1f, 0x84, 0x76, 0xd0, 0x78, 0xb8, 0xd6, 0x89, 0x90, 0x92, 0x78, 0xf3, 0x12, 0x82, 0x87, 0xdf,
+	0xbc, 0x84, 0xcd, 0xae, 0x5a, 0xa8, 0xac, 0xa8, 0xfe, 0x58, 0xdf, 0x5c, 0x1c, 0xa0, 0xf9, 0x98,
+	0x9e, 0x8f, 0xf5, 0xc9, 0x45, 0x0f, 0x2d, 0x0f, 0x85, 0x6d, 0xb0, 0x26, 0x7a, 0x4e, 0xb7, 0x43,
+	0x47, 0xac, 0x89, 0x2d, 0x01, 0x27, 0x92, 0x02, 0x76, 0x94, 0xc0, 0x71, 0x2d, 0x43, 0xa6, 0x16,
+	0x72, 0x47, 0xb9, 0xc3, 0xc1, 0x24, 0xc4, 0x57, 0x7e, 0x94, 0x45, 0x4b, 0xc9, 0xb8, 0xee, 0x7d,
+	0xbe, 0xd8, 0x7c, 0x16, 0xcd, 0xb0, 0x5f, 0xe0, 0xa6, 0xc9, 0x1d, 0xa7, 0xc3, 0xa0, 0x44, 0x60,
+	0x21, 0x69, 0xb2, 0x6c, 0x93, 0xde, 0xdf, 0x89, 0xde, 0xd7, 0xc9, 0xa4, 0xa9, 0x15, 0x22, 0x48,
+	0x44, 0x03, 0x4d, 0xc3, 0xfa, 0x09, 0x57, 0x56, 0xd8, 0x34, 0xac, 0x2e, 0xc2, 0x30, 0x2c, 0x97,
+	0x8b, 0xaf, 0xaa, 0x28, 0x97, 0x1b, 0x2e, 0x19, 0x7d, 0x1a, 0xae, 0x32, 0xb1, 0x63, 0x81, 0xa6,
+	0x7e, 0xe4, 0xb3, 0x14, 0xa3, 0x10, 0xf9, 0x00, 0x12, 0xa1, 0x88, 0x4a, 0x57, 0x69, 0x22, 0x7e,
+	0x12, 0x03, 0xce, 0xe0, 0x50, 0x8e, 0x93, 0x74, 0x06, 0xf7, 0x5a, 0x6d, 0x02, 0x70, 0xf8, 0x39,
+	0x92, 0x43, 0xcf, 0x32, 0xc5, 0x48, 0xb1, 0x1b, 0xbf, 0xf7, 0x48, 0xab, 0x49, 0x18, 0xb4, 0xf2,
+	0x17, 0x59, 0xb4, 0x70, 0x47, 0x77, 0xdd, 0xe8, 0x42, 0xe5, 0x19, 0xec, 0x3d, 0x77, 0x63, 0x7b,
+	0xcf, 0xe4, 0x1f, 0xbb, 0x88, 0x2b, 0x38, 0x36, 0x5a, 0xfe, 0xe5, 0x44, 0xb4, 0xfc, 0xe9, 0xb4,
+	0x82, 0x4f, 0x0f, 0x96, 0xdf, 0xce, 0x20, 0x1c, 0x67, 0x38, 0x83, 0x6d, 0xee, 0x4e, 0x7c, 0x9b,
+	0xdb, 0x48, 0xd9, 0xa5, 0x31, 0x9b, 0xdd, 0x1f, 0x64, 0xd0, 0x6a, 0x9c, 0xf0, 0x31, 0xdf, 0x41,
+	0x80, 0xd5, 0xa8, 0x1b, 0x81, 0x35, 0x1c, 0xff, 0xd5, 0x18, 0x94, 0x08, 0x6c, 0xe5, 0xcf, 0x86,
+	0x06, 0xf9, 0x89, 0xbc, 0xb2, 0xf0, 0x9f, 0x59, 0xb4, 0x32, 0xca, 0x78, 0x3e, 0x88, 0xa2, 0x1f,
+	0x69, 0x14, 0x4d, 0x50, 0xec, 0x68, 0x78, 0x92, 0xab, 0x7b, 0x06, 0x15, 0x0e, 0x95, 0x5d, 0x41,
+	0xda, 0xfe, 0x3d, 0xb6, 0x2d, 0x70, 0x5c, 0xe5, 0x0f, 0x33, 0x28, 0xfc, 0x1d, 0x15, 0xf8, 0xfd,
+	0xcb, 0xbe, 0x63, 0x0e, 0xfd, 0xfe, 0xe5, 0xb6, 0x63, 0xb2, 0x67, 0x74, 0x82, 0x0c, 0x3e, 0x09,
+	0x23, 0xc4, 0xdf, 0x44, 0x45, 0x3f, 0xf0, 0xf4, 0x80, 0x76, 0x8f, 0xa6, 0xfe, 0x0d, 0x79, 0x21,
+	0xa5, 0x23, 0xf8, 0x22, 0xcb, 0x0d, 0x21, 0x44, 0xca, 0xac, 0xfc, 0x43, 0x06, 0x2d, 0x26, 0xe8,
+	0xf1, 0x6b, 0x08, 0xf5, 0xf5, 0xfb, 0x77, 0x6d, 0x8f, 0xea, 0xe6, 0xd1, 0x44, 0x8f, 0x0c, 0xff,
+	0x45, 0xa2, 0xca, 0xff, 0x8b, 0x44, 0xb5, 0x65, 0x07, 0xb7, 0xbd, 0x4e, 0xe0, 0x59, 0x76, 0x97,
+	0x1f, 0x2a, 0x6c, 0x4b, 0x39, 0x44, 0x91, 0x09, 0xaf, 0xe7, 0x4c, 0x4f, 0xb7, 0x6c, 0xa8, 0xa3,
+	0xd6, 0xe9, 0x9e, 0xe3, 0x51, 0xa1, 0x83, 0xf8, 0x85, 0x2a, 0xf6, 0x7a, 0xae, 0x39, 0x92, 0x82,
+	0x8c, 0xe1, 0xac, 0x5f, 0x79, 0xfb, 0xbd, 0xcb, 0x4f, 0xfd, 0xec, 0xbd, 0xcb, 0x4f, 0xbd, 0xf3,
+	0xde, 0xe5, 0xa7, 0xbe, 0x75, 0x72, 0x39, 0xf3, 0xf6, 0xc9, 0xe5, 0xcc, 0xcf, 0x4e, 0x2e, 0x67,
+	0xde, 0x39, 0xb9, 0x9c, 0xf9, 0xf7, 0x93, 0xcb, 0x99, 0x6f, 0xff, 0xc7, 0xe5, 0xa7, 0xbe, 0x91,
+	0x3d, 0xbc, 0xfa, 0xff, 0x03, 0x00, 0xbf, 0x4d, 0x6f, 0x01, 0x8b, 0x64, 0x00, 0x00,
 }
 
 func (m *AddonSpec) Marshal() (dAtA []byte, err error) {
@@ -6055,6 +6061,14 @@ func (m *MachineSpec) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	i--
+	if m.Paused {
+		dAtA[i] = 1
+	} else {
+		dAtA[i] = 0
+	}
+	i--
+	dAtA[i] = 0x68
 	if len(m.Taints) > 0 {
 		for iNdEx := len(m.Taints) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -6174,6 +6188,29 @@ func (m *MachineStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	i = encodeVarintGenerated(dAtA, i, uint64(m.PodCount))
+	i--
+	dAtA[i] = 0x58
+	if m.LastReconcileTime != nil {
+		{
+			size, err := m.LastReconcileTime.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintGenerated(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x52
+	}
+	i = encodeVarintGenerated(dAtA, i, uint64(m.ReconcileCount))
+	i--
+	dAtA[i] = 0x48
+	i -= len(m.NodeName)
+	copy(dAtA[i:], m.NodeName)
+	i = encodeVarintGenerated(dAtA, i, uint64(len(m.NodeName)))
+	i--
+	dAtA[i] = 0x42
 	{
 		size, err := m.MachineInfo.MarshalToSizedBuffer(dAtA[:i])
 		if err != nil {
@@ -8561,6 +8598,7 @@ func (m *MachineSpec) Size() (n int) {
 			n += 1 + l + sovGenerated(uint64(l))
 		}
 	}
+	n += 2
 	return n
 }
 
@@ -8593,6 +8631,14 @@ func (m *MachineStatus) Size() (n int) {
 	}
 	l = m.MachineInfo.Size()
 	n += 1 + l + sovGenerated(uint64(l))
+	l = len(m.NodeName)
+	n += 1 + l + sovGenerated(uint64(l))
+	n += 1 + sovGenerated(uint64(m.ReconcileCount))
+	if m.LastReconcileTime != nil {
+		l = m.LastReconcileTime.Size()
+		n += 1 + l + sovGenerated(uint64(l))
+	}
+	n += 1 + sovGenerated(uint64(m.PodCount))
 	return n
 }
 
@@ -9980,6 +10026,7 @@ func (this *MachineSpec) String() string {
 		`PassPhrase:` + valueToStringGenerated(this.PassPhrase) + `,`,
 		`Labels:` + mapStringForLabels + `,`,
 		`Taints:` + repeatedStringForTaints + `,`,
+		`Paused:` + fmt.Sprintf("%v", this.Paused) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -10006,6 +10053,10 @@ func (this *MachineStatus) String() string {
 		`Reason:` + fmt.Sprintf("%v", this.Reason) + `,`,
 		`Addresses:` + repeatedStringForAddresses + `,`,
 		`MachineInfo:` + strings.Replace(strings.Replace(this.MachineInfo.String(), "MachineSystemInfo", "MachineSystemInfo", 1), `&`, ``, 1) + `,`,
+		`NodeName:` + fmt.Sprintf("%v", this.NodeName) + `,`,
+		`ReconcileCount:` + fmt.Sprintf("%v", this.ReconcileCount) + `,`,
+		`LastReconcileTime:` + strings.Replace(fmt.Sprintf("%v", this.LastReconcileTime), "Time", "v1.Time", 1) + `,`,
+		`PodCount:` + fmt.Sprintf("%v", this.PodCount) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -22408,6 +22459,26 @@ func (m *MachineSpec) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 13:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Paused", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Paused = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipGenerated(dAtA[iNdEx:])
@@ -22676,6 +22747,112 @@ func (m *MachineStatus) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NodeName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NodeName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReconcileCount", wireType)
+			}
+			m.ReconcileCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ReconcileCount |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastReconcileTime", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.LastReconcileTime == nil {
+				m.LastReconcileTime = &v1.Time{}
+			}
+			if err := m.LastReconcileTime.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PodCount", wireType)
+			}
+			m.PodCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PodCount |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipGenerated(dAtA[iNdEx:])
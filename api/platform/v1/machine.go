@@ -85,3 +85,9 @@ func (in *Machine) SetCondition(newCondition MachineCondition) {
 		in.Status.Message = ""
 	}
 }
+
+// IsReady returns true if the MachineConditionReady condition of machine is True.
+func (in *Machine) IsReady() bool {
+	condition := in.GetCondition(MachineConditionReady)
+	return condition != nil && condition.Status == ConditionTrue
+}
@@ -141,6 +141,71 @@ const (
 	AnywhereValidateAnno = "tkestack.io/anywhere-validate"
 	// LocationBasedImagePrefixAnno is exist, the cluster will use it as k8s images prefix
 	LocationBasedImagePrefixAnno = "tkestack.io/location-based-image-prefix"
+	// ClusterProxyURLAnno contains the URL of the HTTP or SOCKS5 proxy through
+	// which the cluster API and machines of an isolated network are reached
+	ClusterProxyURLAnno = "tkestack.io/proxy-url"
+	// MachineForceResyncAnno is exist, the machine provider will do a complete resync on next update
+	MachineForceResyncAnno = "machine.tkestack.io/force-resync"
+	// MachineInstallLogAnno contains the tail of the install log of last failed machine provisioning
+	MachineInstallLogAnno = "machine.tkestack.io/install-log"
+	// MachineKeepNodeAnno is exist, the node is left in the cluster when the machine is deleted
+	MachineKeepNodeAnno = "machine.tkestack.io/keep-node"
+	// MachineRebootRequestedAnno is exist, the machine controller drains and reboots the node of machine
+	MachineRebootRequestedAnno = "machine.tkestack.io/reboot-requested"
+	// MachineRebootStateAnno describe the current step of a requested reboot
+	MachineRebootStateAnno = "machine.tkestack.io/reboot-state"
+	// MachineRebootBootIDAnno contains the boot id of node before a requested reboot
+	MachineRebootBootIDAnno = "machine.tkestack.io/reboot-boot-id"
+	// MachineDrainGraceSecondsAnno overrides the grace period in seconds to evict pods when the node of machine is drained
+	MachineDrainGraceSecondsAnno = "machine.tkestack.io/drain-grace-seconds"
+	// MachineProviderVersionAnno contains the version of machine provider which provisioned the machine
+	MachineProviderVersionAnno = "machine.tkestack.io/provider-version"
+	// MachineProvisionedTimeAnno contains the time in RFC3339 the machine was last provisioned
+	MachineProvisionedTimeAnno = "machine.tkestack.io/provisioned-time"
+	// MachineLastHealthErrorAnno contains the message of the last failed health check of machine, it's removed once the machine is healthy
+	MachineLastHealthErrorAnno = "machine.tkestack.io/last-health-error"
+	// MachineKubeletConfigHashAnno contains the hash of the machine spec fields mapping to the kubelet configuration last applied
+	MachineKubeletConfigHashAnno = "machine.tkestack.io/kubelet-config-hash"
+	// MachineClusterAnno contains the name of the cluster which machine is provisioned into
+	MachineClusterAnno = "machine.tkestack.io/cluster"
+	// MachineMigrateToAnno confirms the change of the cluster name of machine, its value must be the new cluster name
+	MachineMigrateToAnno = "machine.tkestack.io/migrate-to"
+	// MachineCoordinationExemptAnno is true, the machine is processed independently of the other machines of its pool
+	MachineCoordinationExemptAnno = "machine.tkestack.io/coordination-exempt"
+	// MachineBestEffortAnno is true for machines expected to vanish, e.g. spot
+	// instances, whose missing node doesn't fail them
+	MachineBestEffortAnno = "machine.tkestack.io/best-effort"
+	// MachinePoolLabel is the label of the pool which machine belongs to, it's
+	// applied to the node of machine
+	MachinePoolLabel = "machine.tkestack.io/pool"
+	// MachineNameLabel is the label of node containing the name of machine it belongs to
+	MachineNameLabel = "machine.tkestack.io/name"
+	// MachineFailedTaintKey is the taint applied to the node of failed machine by the failed pod policy
+	MachineFailedTaintKey = "machine.tkestack.io/failed"
+	// MachineFailedCordonAnno is on the node cordoned by the failed pod policy, so that only those nodes are uncordoned on recovery
+	MachineFailedCordonAnno = "machine.tkestack.io/failed-cordon"
+)
+
+const (
+	// MachineConditionReady is the condition maintained by the machine
+	// controller for other controllers to gate on, e.g. cluster readiness.
+	// It's True if the machine is Running and healthy, Unknown if the health
+	// of machine is unknown, and False otherwise with one of the
+	// MachineReadyReason reasons. Its LastTransitionTime only changes with
+	// its status.
+	MachineConditionReady = "Ready"
+	// MachineReadyReasonHealthy means the machine is running and healthy
+	MachineReadyReasonHealthy = "Healthy"
+	// MachineReadyReasonUnhealthy means the health check of machine failed
+	MachineReadyReasonUnhealthy = "Unhealthy"
+	// MachineReadyReasonHealthUnknown means the health of machine is unknown
+	MachineReadyReasonHealthUnknown = "HealthUnknown"
+	// MachineReadyReasonProvisioning means the machine is being provisioned
+	MachineReadyReasonProvisioning = "Provisioning"
+	// MachineReadyReasonUpgrading means the machine is being upgraded
+	MachineReadyReasonUpgrading = "Upgrading"
+	// MachineReadyReasonTerminating means the machine is being deleted
+	MachineReadyReasonTerminating = "Terminating"
 )
 
 // KubeVendorType describe the kubernetes provider of the cluster
@@ -1302,6 +1367,9 @@ type MachineSpec struct {
 	// If specified, the node's taints.
 	// +optional
 	Taints []corev1.Taint `json:"taints,omitempty" protobuf:"bytes,12,opt,name=taints"`
+	// Paused holds the provisioning of the initializing machine until it's unset.
+	// +optional
+	Paused bool `json:"paused,omitempty" protobuf:"varint,13,opt,name=paused"`
 }
 
 // MachineStatus represents information about the status of an machine.
@@ -1328,6 +1396,18 @@ type MachineStatus struct {
 	// Set of ids/uuids to uniquely identify the node.
 	// +optional
 	MachineInfo MachineSystemInfo `json:"machineInfo,omitempty" protobuf:"bytes,7,opt,name=machineInfo"`
+	// The name of the node resolved by machine IP.
+	// +optional
+	NodeName string `json:"nodeName,omitempty" protobuf:"bytes,8,opt,name=nodeName"`
+	// The number of meaningful reconciles of the machine.
+	// +optional
+	ReconcileCount int32 `json:"reconcileCount,omitempty" protobuf:"varint,9,opt,name=reconcileCount"`
+	// The last time the reconcile count was recorded.
+	// +optional
+	LastReconcileTime *metav1.Time `json:"lastReconcileTime,omitempty" protobuf:"bytes,10,opt,name=lastReconcileTime"`
+	// The number of pods scheduled to the node of the machine which haven't terminated.
+	// +optional
+	PodCount int32 `json:"podCount,omitempty" protobuf:"varint,11,opt,name=podCount"`
 }
 
 // MachineSystemInfo is a set of ids/uuids to uniquely identify the node.
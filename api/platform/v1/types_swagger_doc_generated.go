@@ -502,6 +502,7 @@ var map_MachineSpec = map[string]string{
 	"":           "MachineSpec is a description of machine.",
 	"finalizers": "Finalizers is an opaque list of values that must be empty to permanently remove object from storage.",
 	"taints":     "If specified, the node's taints.",
+	"paused":     "Paused holds the provisioning of the initializing machine until it's unset.",
 }
 
 func (MachineSpec) SwaggerDoc() map[string]string {
@@ -509,11 +510,15 @@ func (MachineSpec) SwaggerDoc() map[string]string {
 }
 
 var map_MachineStatus = map[string]string{
-	"":            "MachineStatus represents information about the status of an machine.",
-	"message":     "A human readable message indicating details about why the machine is in this condition.",
-	"reason":      "A brief CamelCase message indicating details about why the machine is in this state.",
-	"addresses":   "List of addresses reachable to the machine.",
-	"machineInfo": "Set of ids/uuids to uniquely identify the node.",
+	"":                  "MachineStatus represents information about the status of an machine.",
+	"message":           "A human readable message indicating details about why the machine is in this condition.",
+	"reason":            "A brief CamelCase message indicating details about why the machine is in this state.",
+	"addresses":         "List of addresses reachable to the machine.",
+	"machineInfo":       "Set of ids/uuids to uniquely identify the node.",
+	"nodeName":          "The name of the node resolved by machine IP.",
+	"reconcileCount":    "The number of meaningful reconciles of the machine.",
+	"lastReconcileTime": "The last time the reconcile count was recorded.",
+	"podCount":          "The number of pods scheduled to the node of the machine which haven't terminated.",
 }
 
 func (MachineStatus) SwaggerDoc() map[string]string {
@@ -2441,6 +2441,7 @@ func autoConvert_v1_MachineSpec_To_platform_MachineSpec(in *MachineSpec, out *pl
 	out.PassPhrase = *(*[]byte)(unsafe.Pointer(&in.PassPhrase))
 	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
 	out.Taints = *(*[]corev1.Taint)(unsafe.Pointer(&in.Taints))
+	out.Paused = in.Paused
 	return nil
 }
 
@@ -2462,6 +2463,7 @@ func autoConvert_platform_MachineSpec_To_v1_MachineSpec(in *platform.MachineSpec
 	out.PassPhrase = *(*[]byte)(unsafe.Pointer(&in.PassPhrase))
 	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
 	out.Taints = *(*[]corev1.Taint)(unsafe.Pointer(&in.Taints))
+	out.Paused = in.Paused
 	return nil
 }
 
@@ -2480,6 +2482,10 @@ func autoConvert_v1_MachineStatus_To_platform_MachineStatus(in *MachineStatus, o
 	if err := Convert_v1_MachineSystemInfo_To_platform_MachineSystemInfo(&in.MachineInfo, &out.MachineInfo, s); err != nil {
 		return err
 	}
+	out.NodeName = in.NodeName
+	out.ReconcileCount = in.ReconcileCount
+	out.LastReconcileTime = (*metav1.Time)(unsafe.Pointer(in.LastReconcileTime))
+	out.PodCount = in.PodCount
 	return nil
 }
 
@@ -2498,6 +2504,10 @@ func autoConvert_platform_MachineStatus_To_v1_MachineStatus(in *platform.Machine
 	if err := Convert_platform_MachineSystemInfo_To_v1_MachineSystemInfo(&in.MachineInfo, &out.MachineInfo, s); err != nil {
 		return err
 	}
+	out.NodeName = in.NodeName
+	out.ReconcileCount = in.ReconcileCount
+	out.LastReconcileTime = (*metav1.Time)(unsafe.Pointer(in.LastReconcileTime))
+	out.PodCount = in.PodCount
 	return nil
 }
 
@@ -1728,6 +1728,10 @@ func (in *MachineStatus) DeepCopyInto(out *MachineStatus) {
 		copy(*out, *in)
 	}
 	out.MachineInfo = in.MachineInfo
+	if in.LastReconcileTime != nil {
+		in, out := &in.LastReconcileTime, &out.LastReconcileTime
+		*out = (*in).DeepCopy()
+	}
 	return
 }
 
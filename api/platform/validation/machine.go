@@ -20,11 +20,13 @@ package validation
 
 import (
 	"context"
+	"strconv"
 
 	apimachineryvalidation "k8s.io/apimachinery/pkg/api/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	platforminternalclient "tkestack.io/tke/api/client/clientset/internalversion/typed/platform/internalversion"
 	"tkestack.io/tke/api/platform"
+	platformv1 "tkestack.io/tke/api/platform/v1"
 	machineprovider "tkestack.io/tke/pkg/platform/provider/machine"
 	utilvalidation "tkestack.io/tke/pkg/util/validation"
 )
@@ -33,6 +35,7 @@ import (
 func ValidateMachine(ctx context.Context, machine *platform.Machine, platformClient platforminternalclient.PlatformInterface) field.ErrorList {
 	allErrs := apimachineryvalidation.ValidateObjectMeta(&machine.ObjectMeta, false, apimachineryvalidation.NameIsDNSLabel, field.NewPath("metadata"))
 	allErrs = append(allErrs, ValidateMachineSpec(ctx, &machine.Spec, field.NewPath("spec"), platformClient)...)
+	allErrs = append(allErrs, ValidateMachineAnnotations(machine.Annotations, field.NewPath("metadata", "annotations"))...)
 	p, err := machineprovider.GetProvider(machine.Spec.Type)
 	if err != nil {
 		return append(allErrs, field.NotFound(field.NewPath("spec").Child("type"), machine.Spec.Type))
@@ -47,8 +50,17 @@ func ValidateMachineUpdate(ctx context.Context, machine *platform.Machine, oldMa
 	fldPath := field.NewPath("spec")
 	allErrs := apimachineryvalidation.ValidateObjectMetaUpdate(&machine.ObjectMeta, &oldMachine.ObjectMeta, field.NewPath("metadata"))
 	allErrs = append(allErrs, apimachineryvalidation.ValidateImmutableField(machine.Spec.Type, oldMachine.Spec.Type, fldPath.Child("type"))...)
-	allErrs = append(allErrs, apimachineryvalidation.ValidateImmutableField(machine.Spec.ClusterName, oldMachine.Spec.ClusterName, fldPath.Child("clusterName"))...)
+	// the machine is migrated to another cluster only if it's confirmed
+	if machine.Annotations[platformv1.MachineMigrateToAnno] != machine.Spec.ClusterName {
+		allErrs = append(allErrs, apimachineryvalidation.ValidateImmutableField(machine.Spec.ClusterName, oldMachine.Spec.ClusterName, fldPath.Child("clusterName"))...)
+	}
+	// only the provisioning of an initializing machine can be paused
+	if machine.Spec.Paused && !oldMachine.Spec.Paused &&
+		oldMachine.Status.Phase != "" && oldMachine.Status.Phase != platform.MachineInitializing {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("paused"), "can only pause an initializing machine"))
+	}
 	allErrs = append(allErrs, ValidateMachineSpec(ctx, &machine.Spec, field.NewPath("spec"), platformClient)...)
+	allErrs = append(allErrs, ValidateMachineAnnotations(machine.Annotations, field.NewPath("metadata", "annotations"))...)
 	p, err := machineprovider.GetProvider(machine.Spec.Type)
 	if err != nil {
 		return append(allErrs, field.NotFound(field.NewPath("spec").Child("type"), machine.Spec.Type))
@@ -58,6 +70,21 @@ func ValidateMachineUpdate(ctx context.Context, machine *platform.Machine, oldMa
 	return allErrs
 }
 
+// ValidateMachineAnnotations validates the annotations of machine controlling
+// the machine controller.
+func ValidateMachineAnnotations(annotations map[string]string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if value, ok := annotations[platformv1.MachineDrainGraceSecondsAnno]; ok {
+		seconds, err := strconv.Atoi(value)
+		if err != nil || seconds < 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Key(platformv1.MachineDrainGraceSecondsAnno), value, "must be a non-negative integer"))
+		}
+	}
+
+	return allErrs
+}
+
 // ValidateMachineSpec validates a given machine spec.
 func ValidateMachineSpec(ctx context.Context, spec *platform.MachineSpec, fldPath *field.Path, platformClient platforminternalclient.PlatformInterface) field.ErrorList {
 	allErrs := field.ErrorList{}
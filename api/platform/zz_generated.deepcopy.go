@@ -1727,6 +1727,10 @@ func (in *MachineStatus) DeepCopyInto(out *MachineStatus) {
 		copy(*out, *in)
 	}
 	out.MachineInfo = in.MachineInfo
+	if in.LastReconcileTime != nil {
+		in, out := &in.LastReconcileTime, &out.LastReconcileTime
+		*out = (*in).DeepCopy()
+	}
 	return
 }
 
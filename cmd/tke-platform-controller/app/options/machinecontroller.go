@@ -19,8 +19,15 @@
 package options
 
 import (
+	"fmt"
+	"strings"
+	"time"
+
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
 
 	machineconfig "tkestack.io/tke/pkg/platform/controller/machine/config"
 )
@@ -30,6 +37,43 @@ const (
 	flagConcurrentMachineSyncs  = "concurrent-machine-syncs"
 	flagMachineRateLimiterLimit = "machine-rate-limiter-limit"
 	flagMachineRateLimiterBurst = "machine-rate-limiter-burst"
+	flagMachineProviderTimeout  = "machine-provider-timeout"
+	flagMachineDedicatedPool    = "machine-dedicated-pool-selector"
+	flagConcurrentDedicatedSync = "concurrent-dedicated-machine-syncs"
+	flagMachineHealthWebhookURL = "machine-health-webhook-url"
+	flagMachineHealthWebhookTTL = "machine-health-webhook-timeout"
+	flagMachineHealthWebhookTry = "machine-health-webhook-retries"
+	flagMachinePhaseResync      = "machine-phase-resync-periods"
+	flagMachineSkipResync       = "machine-skip-redundant-resync"
+	flagMachineMaxHealthChecks  = "machine-max-concurrent-health-checks"
+	flagMachineJoinGracePeriod  = "machine-join-grace-period"
+	flagMachineStartupStagger   = "machine-startup-stagger-window"
+	flagMachineHealthAuditCM    = "machine-health-audit-configmap"
+	flagMachineHealthAuditMax   = "machine-health-audit-max-records"
+	flagMachineMaxInFlightWrite = "machine-max-inflight-writes"
+	flagMachineNodeHeartbeat    = "machine-node-heartbeat-threshold"
+	flagMachineNodeHeartbeatAnn = "machine-node-heartbeat-annotation"
+	flagMachineFailedPodPolicy  = "machine-failed-pod-policy"
+	flagMachineRecoveryStreak   = "machine-recovery-success-threshold"
+	flagMachineKubeletHealthz   = "machine-kubelet-healthz-path"
+	flagMachineSustainedPress   = "machine-sustained-pressure-probes"
+	flagMachineHealthPatch      = "machine-health-condition-patch"
+	flagMachineUpdateReadyNode  = "machine-update-requires-ready-node"
+	flagMachineMaxAge           = "machine-max-age"
+	flagMachineNodePodCIDR      = "machine-node-require-pod-cidr"
+	flagMachineNodeNetwork      = "machine-node-require-network-available"
+	flagMachineNodeAnnotations  = "machine-node-annotation-prefixes"
+	flagMachineProbeTimeWrite   = "machine-probe-time-write-interval"
+	flagMachineNodeGetTimeout   = "machine-node-get-timeout"
+	flagMachineCreateBackoff    = "machine-create-failure-backoff"
+	flagMachineUpdateBackoff    = "machine-update-failure-backoff"
+	flagMachineDeleteBackoff    = "machine-delete-failure-backoff"
+	flagMachineNodeHealthCond   = "machine-node-health-condition"
+	flagMachineProviderRetries  = "machine-provider-error-retries"
+	flagMachineConflictRetries  = "machine-conflict-error-retries"
+	flagMachineNetworkRetries   = "machine-network-error-retries"
+	flagMachineSpecDebounce     = "machine-spec-update-debounce"
+	flagMachineLegacyFinalizers = "machine-legacy-finalizers"
 )
 
 const (
@@ -37,11 +81,68 @@ const (
 	configConcurrentMachineSyncs  = "controller.concurrent_machine_syncs"
 	configMachineRateLimiterLimit = "controller.machine_rate_limiter_limit"
 	configMachineRateLimiterBurst = "controller.machine_rate_limiter_burst"
+	configMachineProviderTimeout  = "controller.machine_provider_timeout"
+	configMachineDedicatedPool    = "controller.machine_dedicated_pool_selector"
+	configConcurrentDedicatedSync = "controller.concurrent_dedicated_machine_syncs"
+	configMachineHealthWebhookURL = "controller.machine_health_webhook_url"
+	configMachineHealthWebhookTTL = "controller.machine_health_webhook_timeout"
+	configMachineHealthWebhookTry = "controller.machine_health_webhook_retries"
+	configMachinePhaseResync      = "controller.machine_phase_resync_periods"
+	configMachineSkipResync       = "controller.machine_skip_redundant_resync"
+	configMachineMaxHealthChecks  = "controller.machine_max_concurrent_health_checks"
+	configMachineJoinGracePeriod  = "controller.machine_join_grace_period"
+	configMachineStartupStagger   = "controller.machine_startup_stagger_window"
+	configMachineHealthAuditCM    = "controller.machine_health_audit_configmap"
+	configMachineHealthAuditMax   = "controller.machine_health_audit_max_records"
+	configMachineMaxInFlightWrite = "controller.machine_max_inflight_writes"
+	configMachineNodeHeartbeat    = "controller.machine_node_heartbeat_threshold"
+	configMachineNodeHeartbeatAnn = "controller.machine_node_heartbeat_annotation"
+	configMachineFailedPodPolicy  = "controller.machine_failed_pod_policy"
+	configMachineRecoveryStreak   = "controller.machine_recovery_success_threshold"
+	configMachineKubeletHealthz   = "controller.machine_kubelet_healthz_path"
+	configMachineSustainedPress   = "controller.machine_sustained_pressure_probes"
+	configMachineHealthPatch      = "controller.machine_health_condition_patch"
+	configMachineUpdateReadyNode  = "controller.machine_update_requires_ready_node"
+	configMachineMaxAge           = "controller.machine_max_age"
+	configMachineNodePodCIDR      = "controller.machine_node_require_pod_cidr"
+	configMachineNodeNetwork      = "controller.machine_node_require_network_available"
+	configMachineNodeAnnotations  = "controller.machine_node_annotation_prefixes"
+	configMachineProbeTimeWrite   = "controller.machine_probe_time_write_interval"
+	configMachineNodeGetTimeout   = "controller.machine_node_get_timeout"
+	configMachineCreateBackoff    = "controller.machine_create_failure_backoff"
+	configMachineUpdateBackoff    = "controller.machine_update_failure_backoff"
+	configMachineDeleteBackoff    = "controller.machine_delete_failure_backoff"
+	configMachineNodeHealthCond   = "controller.machine_node_health_condition"
+	configMachineProviderRetries  = "controller.machine_provider_error_retries"
+	configMachineConflictRetries  = "controller.machine_conflict_error_retries"
+	configMachineNetworkRetries   = "controller.machine_network_error_retries"
+	configMachineSpecDebounce     = "controller.machine_spec_update_debounce"
+	configMachineLegacyFinalizers = "controller.machine_legacy_finalizers"
+)
+
+const (
+	defaultMachineHealthWebhookTimeout = 10 * time.Second
+	defaultMachineHealthWebhookRetries = 3
+	defaultMachineJoinGracePeriod      = 2 * time.Minute
+	defaultMachineHealthAuditRecords   = 100
+	// defaultMachineRecoveryStreak recovers failed machines on first success.
+	defaultMachineRecoveryStreak = 1
+	// defaultMachineSustainedPressure reports pressure lasting for three probes.
+	defaultMachineSustainedPressure = 3
+	// defaultMachineStartupStagger spreads the first health checks of existing
+	// machines on start over one sync period, which is the interval they are
+	// checked at anyway, instead of checking all of them at once.
+	defaultMachineStartupStagger = defaultSyncPeriod
+	// defaultMachineNodeGetTimeout bounds getting the node by health check
+	// well below the resync of machines.
+	defaultMachineNodeGetTimeout = 30 * time.Second
 )
 
 // MachineControllerOptions holds the MachineController options.
 type MachineControllerOptions struct {
 	*machineconfig.MachineControllerConfiguration
+	// PhaseResyncPeriods is the raw value of MachineControllerConfiguration.PhaseResyncPeriods.
+	PhaseResyncPeriods map[string]string
 }
 
 // NewMachineControllerOptions creates a new Options with a default config.
@@ -52,7 +153,20 @@ func NewMachineControllerOptions() *MachineControllerOptions {
 			ConcurrentMachineSyncs: defaultConcurrentSyncs,
 			BucketRateLimiterLimit: defaultBucketRateLimiterLimit,
 			BucketRateLimiterBurst: defaultBucketRateLimiterBurst,
+
+			ConcurrentDedicatedMachineSyncs: defaultConcurrentSyncs,
+			HealthWebhookTimeout:            defaultMachineHealthWebhookTimeout,
+			HealthWebhookRetries:            defaultMachineHealthWebhookRetries,
+			JoinGracePeriod:                 defaultMachineJoinGracePeriod,
+			HealthAuditMaxRecords:           defaultMachineHealthAuditRecords,
+			FailedMachinePodPolicy:          machineconfig.FailedMachinePodPolicyNone,
+			RecoverySuccessThreshold:        defaultMachineRecoveryStreak,
+			SustainedPressureProbes:         defaultMachineSustainedPressure,
+			HealthConditionPatch:            machineconfig.HealthConditionPatchMerge,
+			NodeGetTimeout:                  defaultMachineNodeGetTimeout,
+			StartupStaggerWindow:            defaultMachineStartupStagger,
 		},
+		map[string]string{},
 	}
 }
 
@@ -70,6 +184,80 @@ func (o *MachineControllerOptions) AddFlags(fs *pflag.FlagSet) {
 	_ = viper.BindPFlag(configMachineRateLimiterLimit, fs.Lookup(flagMachineRateLimiterLimit))
 	fs.IntVar(&o.BucketRateLimiterBurst, flagMachineRateLimiterBurst, o.BucketRateLimiterBurst, "The number of bursts of at most b tokens.")
 	_ = viper.BindPFlag(configMachineRateLimiterBurst, fs.Lookup(flagMachineRateLimiterBurst))
+	fs.DurationVar(&o.ProviderTimeout, flagMachineProviderTimeout, o.ProviderTimeout, "The timeout of a single machine provider operation. Zero means no timeout.")
+	_ = viper.BindPFlag(configMachineProviderTimeout, fs.Lookup(flagMachineProviderTimeout))
+	fs.StringVar(&o.DedicatedPoolSelector, flagMachineDedicatedPool, o.DedicatedPoolSelector, "The annotation selector of machines processed by dedicated workers, separately from the general workers.")
+	_ = viper.BindPFlag(configMachineDedicatedPool, fs.Lookup(flagMachineDedicatedPool))
+	fs.IntVar(&o.ConcurrentDedicatedMachineSyncs, flagConcurrentDedicatedSync, o.ConcurrentDedicatedMachineSyncs, "The number of dedicated workers for machines matching the dedicated pool selector.")
+	_ = viper.BindPFlag(configConcurrentDedicatedSync, fs.Lookup(flagConcurrentDedicatedSync))
+	fs.StringVar(&o.HealthWebhookURL, flagMachineHealthWebhookURL, o.HealthWebhookURL, "The webhook URL notified when machines fail or recover.")
+	_ = viper.BindPFlag(configMachineHealthWebhookURL, fs.Lookup(flagMachineHealthWebhookURL))
+	fs.DurationVar(&o.HealthWebhookTimeout, flagMachineHealthWebhookTTL, o.HealthWebhookTimeout, "The timeout of a single machine health webhook request.")
+	_ = viper.BindPFlag(configMachineHealthWebhookTTL, fs.Lookup(flagMachineHealthWebhookTTL))
+	fs.IntVar(&o.HealthWebhookRetries, flagMachineHealthWebhookTry, o.HealthWebhookRetries, "The number of retries of a failed machine health webhook request.")
+	_ = viper.BindPFlag(configMachineHealthWebhookTry, fs.Lookup(flagMachineHealthWebhookTry))
+	fs.StringToStringVar(&o.PhaseResyncPeriods, flagMachinePhaseResync, o.PhaseResyncPeriods, "The periods to resync machines after reconcile keyed by machine phase, e.g. Failed=30s,Initializing=10s.")
+	_ = viper.BindPFlag(configMachinePhaseResync, fs.Lookup(flagMachinePhaseResync))
+	fs.BoolVar(&o.SkipRedundantResync, flagMachineSkipResync, o.SkipRedundantResync, "Skip informer resyncs of machines which have been reconciled at the same resource version and have no health check due. Off by default since the skipped resyncs also repair drift outside the machine, e.g. of its node, which is then only repaired by the health check; turn it on for large fleets where resyncs overload the provider.")
+	_ = viper.BindPFlag(configMachineSkipResync, fs.Lookup(flagMachineSkipResync))
+	fs.IntVar(&o.MaxConcurrentHealthChecks, flagMachineMaxHealthChecks, o.MaxConcurrentHealthChecks, "The max number of machine health checks in flight. Zero means no limit.")
+	_ = viper.BindPFlag(configMachineMaxHealthChecks, fs.Lookup(flagMachineMaxHealthChecks))
+	fs.DurationVar(&o.JoinGracePeriod, flagMachineJoinGracePeriod, o.JoinGracePeriod, "The period after a machine joined the cluster during which a missing node is treated as unknown health rather than failed. Zero means no grace period.")
+	_ = viper.BindPFlag(configMachineJoinGracePeriod, fs.Lookup(flagMachineJoinGracePeriod))
+	fs.DurationVar(&o.StartupStaggerWindow, flagMachineStartupStagger, o.StartupStaggerWindow, "The window over which the first syncs of existing machines are spread on start, by default the machine sync period. Zero means no stagger.")
+	_ = viper.BindPFlag(configMachineStartupStagger, fs.Lookup(flagMachineStartupStagger))
+	fs.StringVar(&o.HealthAuditConfigMap, flagMachineHealthAuditCM, o.HealthAuditConfigMap, "The namespace/name of the ConfigMap in the global cluster which machine failures and recoveries are appended to for audit.")
+	_ = viper.BindPFlag(configMachineHealthAuditCM, fs.Lookup(flagMachineHealthAuditCM))
+	fs.IntVar(&o.HealthAuditMaxRecords, flagMachineHealthAuditMax, o.HealthAuditMaxRecords, "The number of records in the machine health audit ConfigMap before they are rotated.")
+	_ = viper.BindPFlag(configMachineHealthAuditMax, fs.Lookup(flagMachineHealthAuditMax))
+	fs.IntVar(&o.MaxInFlightWrites, flagMachineMaxInFlightWrite, o.MaxInFlightWrites, "The max number of concurrent machine writes to the platform API across all workers. Zero means no limit.")
+	_ = viper.BindPFlag(configMachineMaxInFlightWrite, fs.Lookup(flagMachineMaxInFlightWrite))
+	fs.DurationVar(&o.NodeHeartbeatThreshold, flagMachineNodeHeartbeat, o.NodeHeartbeatThreshold, "The max age of the node Ready heartbeat for a machine to be healthy, it should be longer than the node status report frequency of kubelet. Zero means the heartbeat isn't checked, which is the default since that frequency differs between clusters and a too short threshold fails healthy machines.")
+	_ = viper.BindPFlag(configMachineNodeHeartbeat, fs.Lookup(flagMachineNodeHeartbeat))
+	fs.StringVar(&o.NodeHeartbeatAnnotation, flagMachineNodeHeartbeatAnn, o.NodeHeartbeatAnnotation, "The node annotation written by a heartbeat agent with a RFC3339 timestamp. If set, a machine is healthy only if the annotation of its node is not older than the node heartbeat threshold.")
+	_ = viper.BindPFlag(configMachineNodeHeartbeatAnn, fs.Lookup(flagMachineNodeHeartbeatAnn))
+	fs.StringVar(&o.FailedMachinePodPolicy, flagMachineFailedPodPolicy, o.FailedMachinePodPolicy, "The policy to the pods on the node of failed machines. One of 'none', 'taint' to stop scheduling pods to the node, 'evict' to also evict its pods, and 'cordon' to stop scheduling pods by cordoning the node rather than tainting it.")
+	_ = viper.BindPFlag(configMachineFailedPodPolicy, fs.Lookup(flagMachineFailedPodPolicy))
+	fs.IntVar(&o.RecoverySuccessThreshold, flagMachineRecoveryStreak, o.RecoverySuccessThreshold, "The number of consecutive successful health checks for a failed machine to be Running again.")
+	_ = viper.BindPFlag(configMachineRecoveryStreak, fs.Lookup(flagMachineRecoveryStreak))
+	fs.StringVar(&o.KubeletHealthzPath, flagMachineKubeletHealthz, o.KubeletHealthzPath, "The path of kubelet healthz probed on port 10250 of machines besides the node status, e.g. /healthz. Empty means the kubelet isn't probed.")
+	_ = viper.BindPFlag(configMachineKubeletHealthz, fs.Lookup(flagMachineKubeletHealthz))
+	fs.IntVar(&o.SustainedPressureProbes, flagMachineSustainedPress, o.SustainedPressureProbes, "The number of consecutive probes in which the node of machine is under resource pressure to report sustained pressure. Zero means the pressure isn't tracked.")
+	_ = viper.BindPFlag(configMachineSustainedPress, fs.Lookup(flagMachineSustainedPress))
+	fs.StringVar(&o.HealthConditionPatch, flagMachineHealthPatch, o.HealthConditionPatch, "How the machine health check condition is written. One of 'merge' to write it along with other changes by strategic merge patch, and 'apply' to write it by server-side apply, so that the controller owns just that condition.")
+	_ = viper.BindPFlag(configMachineHealthPatch, fs.Lookup(flagMachineHealthPatch))
+	fs.BoolVar(&o.UpdateRequiresReadyNode, flagMachineUpdateReadyNode, o.UpdateRequiresReadyNode, "Skip updating machines by provider while their nodes are not ready or not found, the machines are retried with backoff.")
+	_ = viper.BindPFlag(configMachineUpdateReadyNode, fs.Lookup(flagMachineUpdateReadyNode))
+	fs.DurationVar(&o.MaxMachineAge, flagMachineMaxAge, o.MaxMachineAge, "The max age of running machines since provisioned, older machines are provisioned again one at a time per pool. Zero means no limit.")
+	_ = viper.BindPFlag(configMachineMaxAge, fs.Lookup(flagMachineMaxAge))
+	fs.BoolVar(&o.NodeRequirePodCIDR, flagMachineNodePodCIDR, o.NodeRequirePodCIDR, "Require the node of machine to have PodCIDR assigned to be healthy.")
+	_ = viper.BindPFlag(configMachineNodePodCIDR, fs.Lookup(flagMachineNodePodCIDR))
+	fs.BoolVar(&o.NodeRequireNetworkAvailable, flagMachineNodeNetwork, o.NodeRequireNetworkAvailable, "Require the NetworkUnavailable condition of the node of machine, if any, to be False to be healthy.")
+	_ = viper.BindPFlag(configMachineNodeNetwork, fs.Lookup(flagMachineNodeNetwork))
+	fs.StringSliceVar(&o.NodeAnnotationPrefixes, flagMachineNodeAnnotations, o.NodeAnnotationPrefixes, "The prefixes of machine annotations mirrored onto the node of machine, e.g. billing.example.com/. The node annotations with the prefixes are removed if missing from the machine, so the prefixes should be dedicated to them.")
+	_ = viper.BindPFlag(configMachineNodeAnnotations, fs.Lookup(flagMachineNodeAnnotations))
+	fs.DurationVar(&o.ProbeTimeWriteInterval, flagMachineProbeTimeWrite, o.ProbeTimeWriteInterval, "The min interval to write the probe time of machine conditions when nothing else of machines changes by health checks. Zero means it's written on every probe.")
+	_ = viper.BindPFlag(configMachineProbeTimeWrite, fs.Lookup(flagMachineProbeTimeWrite))
+	fs.DurationVar(&o.NodeGetTimeout, flagMachineNodeGetTimeout, o.NodeGetTimeout, "The timeout of getting the node of machine by health check, distinct from the provider timeout. Zero means no timeout.")
+	_ = viper.BindPFlag(configMachineNodeGetTimeout, fs.Lookup(flagMachineNodeGetTimeout))
+	fs.DurationVar(&o.CreateFailureBackoff, flagMachineCreateBackoff, o.CreateFailureBackoff, "The base delay of the exponential backoff to requeue machines which failed to be created. Zero means the default of 5ms.")
+	_ = viper.BindPFlag(configMachineCreateBackoff, fs.Lookup(flagMachineCreateBackoff))
+	fs.DurationVar(&o.UpdateFailureBackoff, flagMachineUpdateBackoff, o.UpdateFailureBackoff, "The base delay of the exponential backoff to requeue machines which failed to be updated. Zero means the default of 5ms.")
+	_ = viper.BindPFlag(configMachineUpdateBackoff, fs.Lookup(flagMachineUpdateBackoff))
+	fs.DurationVar(&o.DeleteFailureBackoff, flagMachineDeleteBackoff, o.DeleteFailureBackoff, "The base delay of the exponential backoff to requeue machines which failed to be deleted. Zero means the default of 5ms.")
+	_ = viper.BindPFlag(configMachineDeleteBackoff, fs.Lookup(flagMachineDeleteBackoff))
+	fs.StringVar(&o.NodeHealthCondition, flagMachineNodeHealthCond, o.NodeHealthCondition, "The type of node condition mirroring the HealthCheck condition of machine, e.g. MachineHealthy, for operators who monitor nodes rather than machines. Empty means no node condition is written.")
+	_ = viper.BindPFlag(configMachineNodeHealthCond, fs.Lookup(flagMachineNodeHealthCond))
+	fs.IntVar(&o.ProviderErrorRetries, flagMachineProviderRetries, o.ProviderErrorRetries, "The max number of retries of machines which failed by machine provider errors until they are synced. Zero means no limit.")
+	_ = viper.BindPFlag(configMachineProviderRetries, fs.Lookup(flagMachineProviderRetries))
+	fs.IntVar(&o.ConflictErrorRetries, flagMachineConflictRetries, o.ConflictErrorRetries, "The max number of retries of machines which failed by API conflicts until they are synced. Zero means no limit.")
+	_ = viper.BindPFlag(configMachineConflictRetries, fs.Lookup(flagMachineConflictRetries))
+	fs.IntVar(&o.NetworkErrorRetries, flagMachineNetworkRetries, o.NetworkErrorRetries, "The max number of retries of machines which failed by network errors until they are synced. Zero means no limit.")
+	_ = viper.BindPFlag(configMachineNetworkRetries, fs.Lookup(flagMachineNetworkRetries))
+	fs.DurationVar(&o.SpecUpdateDebounce, flagMachineSpecDebounce, o.SpecUpdateDebounce, "The window a machine is reconciled after its last spec change, so rapid spec changes are reconciled once by the latest spec. Zero disables debouncing.")
+	_ = viper.BindPFlag(configMachineSpecDebounce, fs.Lookup(flagMachineSpecDebounce))
+	fs.StringSliceVar(&o.LegacyFinalizers, flagMachineLegacyFinalizers, o.LegacyFinalizers, "The finalizer tokens of machines created by earlier deployments, which are replaced by the current token so that those machines are cleaned up on deletion.")
+	_ = viper.BindPFlag(configMachineLegacyFinalizers, fs.Lookup(flagMachineLegacyFinalizers))
 }
 
 // ApplyTo fills up MachineController config with options.
@@ -82,6 +270,47 @@ func (o *MachineControllerOptions) ApplyTo(cfg *machineconfig.MachineControllerC
 	cfg.ConcurrentMachineSyncs = o.ConcurrentMachineSyncs
 	cfg.BucketRateLimiterLimit = o.BucketRateLimiterLimit
 	cfg.BucketRateLimiterBurst = o.BucketRateLimiterBurst
+	cfg.ProviderTimeout = o.ProviderTimeout
+	cfg.DedicatedPoolSelector = o.DedicatedPoolSelector
+	cfg.ConcurrentDedicatedMachineSyncs = o.ConcurrentDedicatedMachineSyncs
+	cfg.HealthWebhookURL = o.HealthWebhookURL
+	cfg.HealthWebhookTimeout = o.HealthWebhookTimeout
+	cfg.HealthWebhookRetries = o.HealthWebhookRetries
+	periods, err := parsePhaseResyncPeriods(o.PhaseResyncPeriods)
+	if err != nil {
+		return err
+	}
+	cfg.PhaseResyncPeriods = periods
+	cfg.SkipRedundantResync = o.SkipRedundantResync
+	cfg.MaxConcurrentHealthChecks = o.MaxConcurrentHealthChecks
+	cfg.JoinGracePeriod = o.JoinGracePeriod
+	cfg.StartupStaggerWindow = o.StartupStaggerWindow
+	cfg.HealthAuditConfigMap = o.HealthAuditConfigMap
+	cfg.HealthAuditMaxRecords = o.HealthAuditMaxRecords
+	cfg.MaxInFlightWrites = o.MaxInFlightWrites
+	cfg.NodeHeartbeatThreshold = o.NodeHeartbeatThreshold
+	cfg.NodeHeartbeatAnnotation = o.NodeHeartbeatAnnotation
+	cfg.FailedMachinePodPolicy = o.FailedMachinePodPolicy
+	cfg.RecoverySuccessThreshold = o.RecoverySuccessThreshold
+	cfg.KubeletHealthzPath = o.KubeletHealthzPath
+	cfg.SustainedPressureProbes = o.SustainedPressureProbes
+	cfg.HealthConditionPatch = o.HealthConditionPatch
+	cfg.UpdateRequiresReadyNode = o.UpdateRequiresReadyNode
+	cfg.MaxMachineAge = o.MaxMachineAge
+	cfg.NodeRequirePodCIDR = o.NodeRequirePodCIDR
+	cfg.NodeRequireNetworkAvailable = o.NodeRequireNetworkAvailable
+	cfg.NodeAnnotationPrefixes = o.NodeAnnotationPrefixes
+	cfg.ProbeTimeWriteInterval = o.ProbeTimeWriteInterval
+	cfg.NodeGetTimeout = o.NodeGetTimeout
+	cfg.CreateFailureBackoff = o.CreateFailureBackoff
+	cfg.UpdateFailureBackoff = o.UpdateFailureBackoff
+	cfg.DeleteFailureBackoff = o.DeleteFailureBackoff
+	cfg.NodeHealthCondition = o.NodeHealthCondition
+	cfg.ProviderErrorRetries = o.ProviderErrorRetries
+	cfg.ConflictErrorRetries = o.ConflictErrorRetries
+	cfg.NetworkErrorRetries = o.NetworkErrorRetries
+	cfg.SpecUpdateDebounce = o.SpecUpdateDebounce
+	cfg.LegacyFinalizers = o.LegacyFinalizers
 
 	return nil
 }
@@ -93,6 +322,104 @@ func (o *MachineControllerOptions) Validate() []error {
 	}
 
 	errs := []error{}
+	if _, err := labels.Parse(o.DedicatedPoolSelector); err != nil {
+		errs = append(errs, fmt.Errorf("--%s: %v", flagMachineDedicatedPool, err))
+	}
+	if _, err := parsePhaseResyncPeriods(o.PhaseResyncPeriods); err != nil {
+		errs = append(errs, fmt.Errorf("--%s: %v", flagMachinePhaseResync, err))
+	}
+	if o.MaxConcurrentHealthChecks < 0 {
+		errs = append(errs, fmt.Errorf("--%s must not be negative", flagMachineMaxHealthChecks))
+	}
+	if o.JoinGracePeriod < 0 {
+		errs = append(errs, fmt.Errorf("--%s must not be negative", flagMachineJoinGracePeriod))
+	}
+	if o.StartupStaggerWindow < 0 {
+		errs = append(errs, fmt.Errorf("--%s must not be negative", flagMachineStartupStagger))
+	}
+	if o.HealthAuditConfigMap != "" {
+		if namespace, _, err := cache.SplitMetaNamespaceKey(o.HealthAuditConfigMap); err != nil || namespace == "" {
+			errs = append(errs, fmt.Errorf("--%s must be in form of namespace/name", flagMachineHealthAuditCM))
+		}
+	}
+	if o.HealthAuditMaxRecords <= 0 {
+		errs = append(errs, fmt.Errorf("--%s must be positive", flagMachineHealthAuditMax))
+	}
+	if o.MaxInFlightWrites < 0 {
+		errs = append(errs, fmt.Errorf("--%s must not be negative", flagMachineMaxInFlightWrite))
+	}
+	if o.NodeHeartbeatThreshold < 0 {
+		errs = append(errs, fmt.Errorf("--%s must not be negative", flagMachineNodeHeartbeat))
+	}
+	if o.NodeHeartbeatAnnotation != "" && o.NodeHeartbeatThreshold == 0 {
+		errs = append(errs, fmt.Errorf("--%s requires --%s", flagMachineNodeHeartbeatAnn, flagMachineNodeHeartbeat))
+	}
+	switch o.FailedMachinePodPolicy {
+	case machineconfig.FailedMachinePodPolicyNone, machineconfig.FailedMachinePodPolicyTaint, machineconfig.FailedMachinePodPolicyEvict,
+		machineconfig.FailedMachinePodPolicyCordon:
+	default:
+		errs = append(errs, fmt.Errorf("--%s must be one of none, taint, evict and cordon", flagMachineFailedPodPolicy))
+	}
+	if o.RecoverySuccessThreshold < 1 {
+		errs = append(errs, fmt.Errorf("--%s must be at least 1", flagMachineRecoveryStreak))
+	}
+	if o.KubeletHealthzPath != "" && !strings.HasPrefix(o.KubeletHealthzPath, "/") {
+		errs = append(errs, fmt.Errorf("--%s must start with /", flagMachineKubeletHealthz))
+	}
+	if o.SustainedPressureProbes < 0 {
+		errs = append(errs, fmt.Errorf("--%s must not be negative", flagMachineSustainedPress))
+	}
+	for _, prefix := range o.NodeAnnotationPrefixes {
+		if prefix == "" {
+			errs = append(errs, fmt.Errorf("--%s must not contain empty prefix", flagMachineNodeAnnotations))
+			break
+		}
+	}
+	for _, finalizer := range o.LegacyFinalizers {
+		if finalizer == "" {
+			errs = append(errs, fmt.Errorf("--%s must not contain empty finalizer", flagMachineLegacyFinalizers))
+			break
+		}
+	}
+	if o.MaxMachineAge < 0 {
+		errs = append(errs, fmt.Errorf("--%s must not be negative", flagMachineMaxAge))
+	}
+	if o.ProbeTimeWriteInterval < 0 {
+		errs = append(errs, fmt.Errorf("--%s must not be negative", flagMachineProbeTimeWrite))
+	}
+	if o.NodeGetTimeout < 0 {
+		errs = append(errs, fmt.Errorf("--%s must not be negative", flagMachineNodeGetTimeout))
+	}
+	if o.CreateFailureBackoff < 0 {
+		errs = append(errs, fmt.Errorf("--%s must not be negative", flagMachineCreateBackoff))
+	}
+	if o.UpdateFailureBackoff < 0 {
+		errs = append(errs, fmt.Errorf("--%s must not be negative", flagMachineUpdateBackoff))
+	}
+	if o.DeleteFailureBackoff < 0 {
+		errs = append(errs, fmt.Errorf("--%s must not be negative", flagMachineDeleteBackoff))
+	}
+	switch corev1.NodeConditionType(o.NodeHealthCondition) {
+	case corev1.NodeReady, corev1.NodeMemoryPressure, corev1.NodeDiskPressure, corev1.NodePIDPressure, corev1.NodeNetworkUnavailable:
+		errs = append(errs, fmt.Errorf("--%s must not be a node condition maintained by kubernetes", flagMachineNodeHealthCond))
+	}
+	if o.ProviderErrorRetries < 0 {
+		errs = append(errs, fmt.Errorf("--%s must not be negative", flagMachineProviderRetries))
+	}
+	if o.ConflictErrorRetries < 0 {
+		errs = append(errs, fmt.Errorf("--%s must not be negative", flagMachineConflictRetries))
+	}
+	if o.NetworkErrorRetries < 0 {
+		errs = append(errs, fmt.Errorf("--%s must not be negative", flagMachineNetworkRetries))
+	}
+	if o.SpecUpdateDebounce < 0 {
+		errs = append(errs, fmt.Errorf("--%s must not be negative", flagMachineSpecDebounce))
+	}
+	switch o.HealthConditionPatch {
+	case machineconfig.HealthConditionPatchMerge, machineconfig.HealthConditionPatchApply:
+	default:
+		errs = append(errs, fmt.Errorf("--%s must be one of merge and apply", flagMachineHealthPatch))
+	}
 	return errs
 }
 
@@ -103,5 +430,54 @@ func (o *MachineControllerOptions) ApplyFlags() []error {
 	o.ConcurrentMachineSyncs = viper.GetInt(configConcurrentMachineSyncs)
 	o.BucketRateLimiterLimit = viper.GetInt(configMachineRateLimiterLimit)
 	o.BucketRateLimiterBurst = viper.GetInt(configMachineRateLimiterBurst)
+	o.ProviderTimeout = viper.GetDuration(configMachineProviderTimeout)
+	o.DedicatedPoolSelector = viper.GetString(configMachineDedicatedPool)
+	o.ConcurrentDedicatedMachineSyncs = viper.GetInt(configConcurrentDedicatedSync)
+	o.HealthWebhookURL = viper.GetString(configMachineHealthWebhookURL)
+	o.HealthWebhookTimeout = viper.GetDuration(configMachineHealthWebhookTTL)
+	o.HealthWebhookRetries = viper.GetInt(configMachineHealthWebhookTry)
+	o.PhaseResyncPeriods = viper.GetStringMapString(configMachinePhaseResync)
+	o.SkipRedundantResync = viper.GetBool(configMachineSkipResync)
+	o.MaxConcurrentHealthChecks = viper.GetInt(configMachineMaxHealthChecks)
+	o.JoinGracePeriod = viper.GetDuration(configMachineJoinGracePeriod)
+	o.StartupStaggerWindow = viper.GetDuration(configMachineStartupStagger)
+	o.HealthAuditConfigMap = viper.GetString(configMachineHealthAuditCM)
+	o.HealthAuditMaxRecords = viper.GetInt(configMachineHealthAuditMax)
+	o.MaxInFlightWrites = viper.GetInt(configMachineMaxInFlightWrite)
+	o.NodeHeartbeatThreshold = viper.GetDuration(configMachineNodeHeartbeat)
+	o.NodeHeartbeatAnnotation = viper.GetString(configMachineNodeHeartbeatAnn)
+	o.FailedMachinePodPolicy = viper.GetString(configMachineFailedPodPolicy)
+	o.RecoverySuccessThreshold = viper.GetInt(configMachineRecoveryStreak)
+	o.KubeletHealthzPath = viper.GetString(configMachineKubeletHealthz)
+	o.SustainedPressureProbes = viper.GetInt(configMachineSustainedPress)
+	o.HealthConditionPatch = viper.GetString(configMachineHealthPatch)
+	o.UpdateRequiresReadyNode = viper.GetBool(configMachineUpdateReadyNode)
+	o.MaxMachineAge = viper.GetDuration(configMachineMaxAge)
+	o.NodeRequirePodCIDR = viper.GetBool(configMachineNodePodCIDR)
+	o.NodeRequireNetworkAvailable = viper.GetBool(configMachineNodeNetwork)
+	o.NodeAnnotationPrefixes = viper.GetStringSlice(configMachineNodeAnnotations)
+	o.ProbeTimeWriteInterval = viper.GetDuration(configMachineProbeTimeWrite)
+	o.NodeGetTimeout = viper.GetDuration(configMachineNodeGetTimeout)
+	o.CreateFailureBackoff = viper.GetDuration(configMachineCreateBackoff)
+	o.UpdateFailureBackoff = viper.GetDuration(configMachineUpdateBackoff)
+	o.DeleteFailureBackoff = viper.GetDuration(configMachineDeleteBackoff)
+	o.NodeHealthCondition = viper.GetString(configMachineNodeHealthCond)
+	o.ProviderErrorRetries = viper.GetInt(configMachineProviderRetries)
+	o.ConflictErrorRetries = viper.GetInt(configMachineConflictRetries)
+	o.NetworkErrorRetries = viper.GetInt(configMachineNetworkRetries)
+	o.SpecUpdateDebounce = viper.GetDuration(configMachineSpecDebounce)
+	o.LegacyFinalizers = viper.GetStringSlice(configMachineLegacyFinalizers)
 	return nil
 }
+
+func parsePhaseResyncPeriods(values map[string]string) (map[string]time.Duration, error) {
+	periods := make(map[string]time.Duration, len(values))
+	for phase, value := range values {
+		period, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid resync period of phase %s: %v", phase, err)
+		}
+		periods[phase] = period
+	}
+	return periods, nil
+}
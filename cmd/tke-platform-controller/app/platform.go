@@ -23,6 +23,8 @@ import (
 	"time"
 
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 	versionedclientset "tkestack.io/tke/api/client/clientset/versioned"
 	"tkestack.io/tke/api/client/informers/externalversions"
 	platformv1 "tkestack.io/tke/api/platform/v1"
@@ -33,6 +35,7 @@ import (
 	bootstrapps "tkestack.io/tke/pkg/platform/controller/bootstrapapps"
 	clustercontroller "tkestack.io/tke/pkg/platform/controller/cluster"
 	"tkestack.io/tke/pkg/platform/controller/machine"
+	"tkestack.io/tke/pkg/util/log"
 )
 
 const (
@@ -67,8 +70,17 @@ func startMachineController(ctx ControllerContext) (http.Handler, bool, error) {
 		return nil, false, nil
 	}
 
+	// events of machines are stored in the cluster the controller runs in
+	var kubeclient kubernetes.Interface
+	if config, err := rest.InClusterConfig(); err != nil {
+		log.Warnf("Machine events are only logged, build in-cluster config failed: %v", err)
+	} else if kubeclient, err = kubernetes.NewForConfig(config); err != nil {
+		return nil, false, err
+	}
+
 	ctrl := machine.NewController(
 		ctx.ClientBuilder.ClientOrDie("machine-controller").PlatformV1(),
+		kubeclient,
 		ctx.InformerFactory.Platform().V1().Machines(),
 		ctx.Config.MachineController,
 		platformv1.MachineFinalize,
@@ -78,7 +90,8 @@ func startMachineController(ctx ControllerContext) (http.Handler, bool, error) {
 		_ = ctrl.Run(ctx.Config.MachineController.ConcurrentMachineSyncs, ctx.Stop)
 	}()
 
-	return nil, true, nil
+	// serves the probes under /debug/controllers/machine
+	return ctrl.ProbeHandler(), true, nil
 }
 
 func startPersistentEventController(ctx ControllerContext) (http.Handler, bool, error) {
@@ -72,6 +72,7 @@ require (
 	github.com/pkg/sftp v1.10.1
 	github.com/prometheus/alertmanager v0.20.0
 	github.com/prometheus/client_golang v1.12.1
+	github.com/prometheus/client_model v0.2.0
 	github.com/prometheus/common v0.32.1
 	github.com/rs/cors v1.6.0
 	github.com/segmentio/ksuid v1.0.3
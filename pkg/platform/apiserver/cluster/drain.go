@@ -41,17 +41,25 @@ type drainCmdOptions struct {
 	node    *corev1.Node
 }
 
+// DefaultDrainGracePeriodSeconds is the grace period to evict pods when a node is drained.
+const DefaultDrainGracePeriodSeconds = 5
+
 // DrainNode work as kubectl drain node
 func DrainNode(ctx context.Context, client kubernetes.Interface, node *corev1.Node) error {
-	return newDrainCmdOptions(client, node).RunDrain(ctx)
+	return DrainNodeWithGracePeriod(ctx, client, node, DefaultDrainGracePeriodSeconds)
+}
+
+// DrainNodeWithGracePeriod work as kubectl drain node --grace-period
+func DrainNodeWithGracePeriod(ctx context.Context, client kubernetes.Interface, node *corev1.Node, gracePeriodSeconds int) error {
+	return newDrainCmdOptions(client, node, gracePeriodSeconds).RunDrain(ctx)
 }
 
-func newDrainCmdOptions(client kubernetes.Interface, node *corev1.Node) *drainCmdOptions {
+func newDrainCmdOptions(client kubernetes.Interface, node *corev1.Node, gracePeriodSeconds int) *drainCmdOptions {
 	return &drainCmdOptions{
 		drainer: &drain.Helper{
 			Client:              client,
 			Force:               true,
-			GracePeriodSeconds:  5,
+			GracePeriodSeconds:  gracePeriodSeconds,
 			IgnoreAllDaemonSets: true,
 			Timeout:             1 * time.Minute,
 			DeleteLocalData:     true,
@@ -0,0 +1,129 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/retry"
+
+	clusterprovider "tkestack.io/tke/pkg/platform/provider/cluster"
+)
+
+const (
+	// auditClusterName is the cluster where the audit ConfigMap lives.
+	auditClusterName = "global"
+	// auditRecordsKey holds the current records, one JSON per line.
+	auditRecordsKey = "records"
+	// auditRotatedRecordsKey holds the records rotated out of auditRecordsKey.
+	auditRotatedRecordsKey = "records.1"
+)
+
+// auditSink appends machine health transitions to a ConfigMap for audit, in
+// environments where events can't be collected. The current records are
+// rotated once reaching maxRecords, so at most 2*maxRecords are kept.
+type auditSink struct {
+	// mu serializes appends to reduce conflicts.
+	mu         sync.Mutex
+	namespace  string
+	name       string
+	maxRecords int
+	clientFor  func(ctx context.Context) (kubernetes.Interface, error)
+}
+
+// newAuditSink returns nil if configMap is empty, which is in form of
+// namespace/name.
+func newAuditSink(configMap string, maxRecords int, clientFor func(ctx context.Context) (kubernetes.Interface, error)) (*auditSink, error) {
+	if configMap == "" {
+		return nil, nil
+	}
+	namespace, name, err := cache.SplitMetaNamespaceKey(configMap)
+	if err != nil {
+		return nil, err
+	}
+	return &auditSink{
+		namespace:  namespace,
+		name:       name,
+		maxRecords: maxRecords,
+		clientFor:  clientFor,
+	}, nil
+}
+
+// append appends the notification as a record to the ConfigMap, which is
+// created if missing.
+func (s *auditSink) append(ctx context.Context, notification HealthNotification) error {
+	record, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+	client, err := s.clientFor(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		configMap, err := client.CoreV1().ConfigMaps(s.namespace).Get(ctx, s.name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			configMap = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Namespace: s.namespace, Name: s.name},
+			}
+			s.appendRecord(configMap, string(record))
+			_, err = client.CoreV1().ConfigMaps(s.namespace).Create(ctx, configMap, metav1.CreateOptions{})
+			return err
+		}
+		if err != nil {
+			return err
+		}
+		s.appendRecord(configMap, string(record))
+		_, err = client.CoreV1().ConfigMaps(s.namespace).Update(ctx, configMap, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+func (s *auditSink) appendRecord(configMap *corev1.ConfigMap, record string) {
+	if configMap.Data == nil {
+		configMap.Data = map[string]string{}
+	}
+	records := configMap.Data[auditRecordsKey]
+	if records != "" && strings.Count(records, "\n") >= s.maxRecords {
+		configMap.Data[auditRotatedRecordsKey] = records
+		records = ""
+	}
+	configMap.Data[auditRecordsKey] = records + record + "\n"
+}
+
+// globalClientset returns the clientset of the cluster where the audit
+// ConfigMap lives.
+func (c *Controller) globalClientset(ctx context.Context) (kubernetes.Interface, error) {
+	cluster, err := c.getCluster(ctx, c.platformClient, auditClusterName, clusterprovider.AdminUsername)
+	if err != nil {
+		return nil, err
+	}
+	return c.clientsetFor(cluster)
+}
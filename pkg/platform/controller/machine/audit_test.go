@@ -0,0 +1,99 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	platformv1 "tkestack.io/tke/api/platform/v1"
+)
+
+func TestAuditSink(t *testing.T) {
+	client := kubefake.NewSimpleClientset()
+	sink, err := newAuditSink("kube-system/machine-audit", 2, func(ctx context.Context) (kubernetes.Interface, error) {
+		return client, nil
+	})
+	if err != nil {
+		t.Fatalf("newAuditSink() error = %v", err)
+	}
+
+	records := func() (current, rotated []HealthNotification) {
+		t.Helper()
+		configMap, err := client.CoreV1().ConfigMaps("kube-system").Get(context.Background(), "machine-audit", v1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		decode := func(data string) []HealthNotification {
+			var notifications []HealthNotification
+			for _, line := range strings.Split(strings.TrimSpace(data), "\n") {
+				if line == "" {
+					continue
+				}
+				var notification HealthNotification
+				if err := json.Unmarshal([]byte(line), &notification); err != nil {
+					t.Fatalf("invalid record %q: %v", line, err)
+				}
+				notifications = append(notifications, notification)
+			}
+			return notifications
+		}
+		return decode(configMap.Data[auditRecordsKey]), decode(configMap.Data[auditRotatedRecordsKey])
+	}
+
+	for i := 0; i < 2; i++ {
+		notification := HealthNotification{Machine: fmt.Sprintf("machine-%d", i), Phase: platformv1.MachineFailed}
+		if err := sink.append(context.Background(), notification); err != nil {
+			t.Fatalf("append() error = %v", err)
+		}
+	}
+	current, rotated := records()
+	if len(current) != 2 || current[0].Machine != "machine-0" || current[1].Machine != "machine-1" {
+		t.Errorf("records = %+v, want machine-0 and machine-1 appended", current)
+	}
+	if len(rotated) != 0 {
+		t.Errorf("rotated records = %+v, want none before cap is reached", rotated)
+	}
+
+	if err := sink.append(context.Background(), HealthNotification{Machine: "machine-2", Phase: platformv1.MachineRunning}); err != nil {
+		t.Fatalf("append() error = %v", err)
+	}
+	current, rotated = records()
+	if len(current) != 1 || current[0].Machine != "machine-2" {
+		t.Errorf("records = %+v, want only machine-2 after rotation", current)
+	}
+	if len(rotated) != 2 || rotated[0].Machine != "machine-0" {
+		t.Errorf("rotated records = %+v, want the previous records", rotated)
+	}
+}
+
+func TestNewAuditSink(t *testing.T) {
+	if sink, err := newAuditSink("", 10, nil); sink != nil || err != nil {
+		t.Errorf("newAuditSink() = %v, %v, want disabled without ConfigMap", sink, err)
+	}
+	if _, err := newAuditSink("a/b/c", 10, nil); err == nil {
+		t.Errorf("newAuditSink() expected error with invalid ConfigMap")
+	}
+}
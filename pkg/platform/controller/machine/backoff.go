@@ -0,0 +1,125 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	platformv1 "tkestack.io/tke/api/platform/v1"
+	machineconfig "tkestack.io/tke/pkg/platform/controller/machine/config"
+)
+
+const (
+	// defaultFailureBackoff is the base delay of failure backoff by default.
+	defaultFailureBackoff = 5 * time.Millisecond
+	// maxFailureBackoff is the max delay of failure backoff.
+	maxFailureBackoff = 1000 * time.Second
+)
+
+// The operations which the failures of machines are backed off by.
+const (
+	operationCreate = "create"
+	operationUpdate = "update"
+	operationDelete = "delete"
+)
+
+// operationRateLimiter backs off the failures of machines exponentially from
+// the base delay of the failed operation, e.g. delete failures could back off
+// longer than create ones.
+type operationRateLimiter struct {
+	operationOf func(key string) string
+	limiters    map[string]workqueue.RateLimiter
+}
+
+var _ workqueue.RateLimiter = &operationRateLimiter{}
+
+func newOperationRateLimiter(configuration machineconfig.MachineControllerConfiguration, operationOf func(key string) string) *operationRateLimiter {
+	limiter := func(base time.Duration) workqueue.RateLimiter {
+		if base <= 0 {
+			base = defaultFailureBackoff
+		}
+		return workqueue.NewItemExponentialFailureRateLimiter(base, maxFailureBackoff)
+	}
+	return &operationRateLimiter{
+		operationOf: operationOf,
+		limiters: map[string]workqueue.RateLimiter{
+			operationCreate: limiter(configuration.CreateFailureBackoff),
+			operationUpdate: limiter(configuration.UpdateFailureBackoff),
+			operationDelete: limiter(configuration.DeleteFailureBackoff),
+		},
+	}
+}
+
+// limiter returns the rate limiter of the operation of item, machines whose
+// operation is unknown are backed off as updates.
+func (r *operationRateLimiter) limiter(item interface{}) workqueue.RateLimiter {
+	key, _ := item.(string)
+	if limiter, ok := r.limiters[r.operationOf(key)]; ok {
+		return limiter
+	}
+	return r.limiters[operationUpdate]
+}
+
+func (r *operationRateLimiter) When(item interface{}) time.Duration {
+	return r.limiter(item).When(item)
+}
+
+func (r *operationRateLimiter) Forget(item interface{}) {
+	for _, limiter := range r.limiters {
+		limiter.Forget(item)
+	}
+}
+
+func (r *operationRateLimiter) NumRequeues(item interface{}) int {
+	requeues := 0
+	for _, limiter := range r.limiters {
+		if n := limiter.NumRequeues(item); n > requeues {
+			requeues = n
+		}
+	}
+	return requeues
+}
+
+// failedOperation returns the operation of the machine which is reconciled
+// by its phase, the machine is deleted if it's gone.
+func (c *Controller) failedOperation(key string) string {
+	_, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return operationUpdate
+	}
+	machine, err := c.lister.Get(name)
+	if apierrors.IsNotFound(err) {
+		return operationDelete
+	}
+	if err != nil {
+		return operationUpdate
+	}
+	switch {
+	case machine.DeletionTimestamp != nil, machine.Status.Phase == platformv1.MachineTerminating:
+		return operationDelete
+	case machine.Status.Phase == platformv1.MachineInitializing:
+		return operationCreate
+	default:
+		return operationUpdate
+	}
+}
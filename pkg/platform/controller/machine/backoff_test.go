@@ -0,0 +1,81 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"testing"
+	"time"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	platformv1 "tkestack.io/tke/api/platform/v1"
+	machineconfig "tkestack.io/tke/pkg/platform/controller/machine/config"
+)
+
+func TestController_failureBackoffByOperation(t *testing.T) {
+	named := func(name string, phase platformv1.MachinePhase) *platformv1.Machine {
+		machine := newMachineForTest("1", nil, phase, nil)
+		machine.Name = name
+		return machine
+	}
+	deleting := named("deleting", platformv1.MachineRunning)
+	now := v1.Now()
+	deleting.DeletionTimestamp = &now
+	c := newControllerForTest(
+		named("initializing", platformv1.MachineInitializing),
+		named("running", platformv1.MachineRunning),
+		named("terminating", platformv1.MachineTerminating),
+		deleting,
+	)
+	limiter := newOperationRateLimiter(machineconfig.MachineControllerConfiguration{
+		CreateFailureBackoff: 10 * time.Millisecond,
+		DeleteFailureBackoff: time.Second,
+	}, c.failedOperation)
+
+	for key, want := range map[string]string{
+		"initializing": operationCreate,
+		"running":      operationUpdate,
+		"terminating":  operationDelete,
+		"deleting":     operationDelete,
+		"gone":         operationDelete,
+	} {
+		if got := c.failedOperation(key); got != want {
+			t.Errorf("failedOperation(%s) = %s, want %s", key, got, want)
+		}
+	}
+
+	for i := 0; i < 3; i++ {
+		create, update, remove := limiter.When("initializing"), limiter.When("running"), limiter.When("terminating")
+		if remove <= create {
+			t.Errorf("failure %d: delete backoff %s, want longer than create backoff %s", i+1, remove, create)
+		}
+		if want := defaultFailureBackoff << uint(i); update != want {
+			t.Errorf("failure %d: update backoff %s, want default %s", i+1, update, want)
+		}
+	}
+	if got, want := limiter.When("terminating"), 8*time.Second; got != want {
+		t.Errorf("delete backoff = %s after 3 failures, want %s", got, want)
+	}
+	if got := limiter.NumRequeues("terminating"); got != 4 {
+		t.Errorf("NumRequeues() = %d, want 4", got)
+	}
+	limiter.Forget("terminating")
+	if got := limiter.When("terminating"); got != time.Second {
+		t.Errorf("delete backoff = %s after forget, want %s", got, time.Second)
+	}
+}
@@ -0,0 +1,161 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	platformv1 "tkestack.io/tke/api/platform/v1"
+	machineprovider "tkestack.io/tke/pkg/platform/provider/machine"
+	typesv1 "tkestack.io/tke/pkg/platform/types/v1"
+	"tkestack.io/tke/pkg/util/log"
+)
+
+const (
+	clusterProbeInterval = 30 * time.Second
+
+	reasonClusterUnreachable = "ClusterUnreachable"
+)
+
+// clusterBreaker is a per cluster circuit breaker of machine health checks.
+// When the cluster API is unreachable the breaker opens, and machines of the
+// cluster are not probed one by one until the cluster API recovers.
+type clusterBreaker struct {
+	mu       sync.Mutex
+	interval time.Duration
+	clusters map[string]*breakerState
+	// onClose is called with the cluster name when the breaker closes, it
+	// must not block.
+	onClose func(name string)
+}
+
+type breakerState struct {
+	mu        sync.Mutex
+	err       error
+	probeTime time.Time
+}
+
+func newClusterBreaker(interval time.Duration) *clusterBreaker {
+	return &clusterBreaker{
+		interval: interval,
+		clusters: make(map[string]*breakerState),
+	}
+}
+
+func (b *clusterBreaker) state(name string) *breakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	state, ok := b.clusters[name]
+	if !ok {
+		state = &breakerState{}
+		b.clusters[name] = state
+	}
+	return state
+}
+
+// check returns the error of the last probe of the cluster, a nil error
+// means the breaker is closed. The probe is repeated once per interval.
+func (b *clusterBreaker) check(ctx context.Context, name string, probe func() error) error {
+	state := b.state(name)
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	if !state.probeTime.IsZero() && time.Since(state.probeTime) < b.interval {
+		return state.err
+	}
+
+	err := probe()
+	if (state.err == nil) != (err == nil) {
+		if err != nil {
+			log.FromContext(ctx).Error(err, "Cluster is unreachable, open machine health check breaker")
+		} else {
+			log.FromContext(ctx).Info("Cluster has recovered, close machine health check breaker")
+			if b.onClose != nil {
+				b.onClose(name)
+			}
+		}
+	}
+	state.err = err
+	state.probeTime = time.Now()
+
+	return err
+}
+
+// probeCluster checks whether the cluster API is reachable.
+func (c *Controller) probeCluster(ctx context.Context, cluster *typesv1.Cluster) error {
+	clientset, err := c.clientsetFor(cluster)
+	if err != nil {
+		return err
+	}
+	_, err = clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{Limit: 1})
+	return err
+}
+
+// checkClusterBreaker returns an error if the cluster API of the machine is
+// unreachable. Only machines whose health is checked are concerned.
+func (c *Controller) checkClusterBreaker(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster) error {
+	if c.breaker == nil {
+		return nil
+	}
+	if !(machine.Status.Phase == platformv1.MachineRunning ||
+		machine.Status.Phase == platformv1.MachineFailed) {
+		return nil
+	}
+	return c.breaker.check(ctx, machine.Spec.ClusterName, func() error {
+		return c.probeCluster(ctx, cluster)
+	})
+}
+
+// reprobeCluster enqueues all machines of the cluster whose health is
+// checked, so that the recovery of the cluster is reflected at once rather
+// than after their staggered resyncs.
+func (c *Controller) reprobeCluster(name string) {
+	machines, err := c.lister.List(labels.Everything())
+	if err != nil {
+		c.log.Error(err, "Failed to list machines to probe on cluster recovery", "cluster", name)
+		return
+	}
+	for _, machine := range machines {
+		if machine.Spec.ClusterName != name {
+			continue
+		}
+		if !(machine.Status.Phase == platformv1.MachineRunning ||
+			machine.Status.Phase == platformv1.MachineFailed) {
+			continue
+		}
+		c.enqueue(machine)
+	}
+}
+
+// setClusterUnreachable marks the health of machine as unknown while the
+// cluster API is unreachable, the machine phase is left unchanged.
+func setClusterUnreachable(machine *platformv1.Machine, err error) {
+	machine.SetCondition(platformv1.MachineCondition{
+		Type:    machineprovider.ConditionTypeHealthCheck,
+		Status:  platformv1.ConditionUnknown,
+		Reason:  reasonClusterUnreachable,
+		Message: err.Error(),
+	})
+}
@@ -0,0 +1,149 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/util/workqueue"
+	platformv1 "tkestack.io/tke/api/platform/v1"
+	machineprovider "tkestack.io/tke/pkg/platform/provider/machine"
+	typesv1 "tkestack.io/tke/pkg/platform/types/v1"
+)
+
+func TestController_clusterBreaker(t *testing.T) {
+	providerName := registerFakeProvider(t, &fakeProvider{
+		onHealthCheck: func(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster) *platformv1.Machine {
+			if _, err := cluster.Clientset(); err != nil {
+				machine.Status.Phase = platformv1.MachineFailed
+			}
+			return machine
+		},
+	})
+	var machines []*platformv1.Machine
+	for _, name := range []string{"machine-1", "machine-2"} {
+		machine := newMachineForTest("1", nil, platformv1.MachineRunning, nil)
+		machine.Name = name
+		machine.Spec.Type = providerName
+		machines = append(machines, machine)
+	}
+	c := newControllerForTest(machines...)
+	c.getCluster = fakeGetCluster
+	c.breaker = newClusterBreaker(time.Hour)
+
+	// simulate the cluster outage
+	probes := 0
+	c.clientsetFor = func(cluster *typesv1.Cluster) (kubernetes.Interface, error) {
+		probes++
+		return nil, errors.New("connection refused")
+	}
+	for _, machine := range machines {
+		if err := c.onUpdate(context.Background(), machine.DeepCopy()); err != nil {
+			t.Fatalf("onUpdate() error = %v", err)
+		}
+		got, err := c.platformClient.Machines().Get(context.Background(), machine.Name, v1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Status.Phase != platformv1.MachineRunning {
+			t.Errorf("machine %s phase = %s, want %s", machine.Name, got.Status.Phase, platformv1.MachineRunning)
+		}
+		condition := got.GetCondition(machineprovider.ConditionTypeHealthCheck)
+		if condition == nil || condition.Status != platformv1.ConditionUnknown || condition.Reason != reasonClusterUnreachable {
+			t.Errorf("machine %s health check condition = %+v, want unknown", machine.Name, condition)
+		}
+	}
+	if probes != 1 {
+		t.Errorf("cluster probes = %d, want 1 while breaker is open", probes)
+	}
+
+	// cluster recovers, machines are probed again
+	c.breaker.interval = 0
+	c.clientsetFor = func(cluster *typesv1.Cluster) (kubernetes.Interface, error) {
+		return kubefake.NewSimpleClientset(), nil
+	}
+	if err := c.checkClusterBreaker(context.Background(), machines[0], &typesv1.Cluster{}); err != nil {
+		t.Errorf("checkClusterBreaker() error = %v after cluster recovers", err)
+	}
+}
+
+func TestController_clusterRecoveryReprobesMachines(t *testing.T) {
+	providerName := registerFakeProvider(t, &fakeProvider{})
+	named := func(name string, phase platformv1.MachinePhase) *platformv1.Machine {
+		machine := newMachineForTest("1", nil, phase, nil)
+		machine.Name = name
+		machine.Spec.Type = providerName
+		return machine
+	}
+	otherCluster := named("other", platformv1.MachineRunning)
+	otherCluster.Spec.ClusterName = "other"
+	c := newControllerForTest(
+		named("running", platformv1.MachineRunning),
+		named("failed", platformv1.MachineFailed),
+		named("initializing", platformv1.MachineInitializing),
+		otherCluster,
+	)
+	c.getCluster = fakeGetCluster
+	c.queue = workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	defer c.queue.ShutDown()
+	c.breaker = newClusterBreaker(0)
+	c.breaker.onClose = c.reprobeCluster
+
+	machine := named("running", platformv1.MachineRunning)
+	c.clientsetFor = func(cluster *typesv1.Cluster) (kubernetes.Interface, error) {
+		return nil, errors.New("connection refused")
+	}
+	if err := c.checkClusterBreaker(context.Background(), machine, &typesv1.Cluster{}); err == nil {
+		t.Fatal("checkClusterBreaker() error = nil during cluster outage")
+	}
+	if n := c.queue.Len(); n != 0 {
+		t.Fatalf("queue length = %d during cluster outage, want 0", n)
+	}
+
+	c.clientsetFor = func(cluster *typesv1.Cluster) (kubernetes.Interface, error) {
+		return kubefake.NewSimpleClientset(), nil
+	}
+	if err := c.checkClusterBreaker(context.Background(), machine, &typesv1.Cluster{}); err != nil {
+		t.Fatalf("checkClusterBreaker() error = %v after cluster recovers", err)
+	}
+	got := sets.NewString()
+	for c.queue.Len() > 0 {
+		key, _ := c.queue.Get()
+		got.Insert(key.(string))
+		c.queue.Done(key)
+	}
+	if want := sets.NewString("running", "failed"); !got.Equal(want) {
+		t.Errorf("machines enqueued on cluster recovery = %v, want %v", got.List(), want.List())
+	}
+
+	// the breaker stays closed, machines are not enqueued again
+	if err := c.checkClusterBreaker(context.Background(), machine, &typesv1.Cluster{}); err != nil {
+		t.Fatalf("checkClusterBreaker() error = %v", err)
+	}
+	if n := c.queue.Len(); n != 0 {
+		t.Errorf("queue length = %d while the breaker stays closed, want 0", n)
+	}
+}
@@ -0,0 +1,70 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/client-go/tools/cache"
+
+	"tkestack.io/tke/pkg/util/log"
+)
+
+const (
+	// cacheSyncTimeout is the timeout of a single attempt to sync caches.
+	cacheSyncTimeout = 2 * time.Minute
+	// cacheSyncAttempts is the max number of attempts to sync caches.
+	cacheSyncAttempts = 5
+)
+
+// waitForCacheSync waits for the caches to sync with a timeout per attempt,
+// a slow sync is retried up to attempts times before giving up, so that
+// a transient slowness of the apiserver doesn't stop the controller.
+func waitForCacheSync(stopCh <-chan struct{}, synced cache.InformerSynced, timeout time.Duration, attempts int) error {
+	start := time.Now()
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if waitForCacheSyncOnce(stopCh, synced, timeout) {
+			cacheSyncDuration.Observe(time.Since(start).Seconds())
+			log.Info("Machine caches are synced", log.Int("attempts", attempt), log.Duration("duration", time.Since(start)))
+			return nil
+		}
+		select {
+		case <-stopCh:
+			return fmt.Errorf("failed to wait for machine caches to sync: stopped")
+		default:
+		}
+		log.Warn("Machine caches are not synced in time", log.Int("attempt", attempt), log.Duration("timeout", timeout))
+	}
+	return fmt.Errorf("failed to wait for machine caches to sync after %d attempts", attempts)
+}
+
+func waitForCacheSyncOnce(stopCh <-chan struct{}, synced cache.InformerSynced, timeout time.Duration) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	go func() {
+		select {
+		case <-stopCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return cache.WaitForCacheSync(ctx.Done(), synced)
+}
@@ -0,0 +1,69 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"testing"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+)
+
+func TestWaitForCacheSync(t *testing.T) {
+	// The cache syncs in the third attempt, so it's synced only if retried.
+	syncedAfter := 250 * time.Millisecond
+	start := time.Now()
+	synced := func() bool {
+		return time.Since(start) > syncedAfter
+	}
+
+	observed := func() uint64 {
+		metric := &dto.Metric{}
+		if err := cacheSyncDuration.Write(metric); err != nil {
+			t.Fatal(err)
+		}
+		return metric.GetHistogram().GetSampleCount()
+	}
+	before := observed()
+	if err := waitForCacheSync(make(chan struct{}), synced, 100*time.Millisecond, 5); err != nil {
+		t.Fatalf("waitForCacheSync() error = %v, want retried until synced", err)
+	}
+	if observed() != before+1 {
+		t.Errorf("cache sync duration is not observed")
+	}
+
+	start = time.Now()
+	syncedAfter = time.Hour
+	if err := waitForCacheSync(make(chan struct{}), synced, 50*time.Millisecond, 2); err == nil {
+		t.Errorf("waitForCacheSync() expected error after attempts are exhausted")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("waitForCacheSync() took %s, want bounded by attempts", elapsed)
+	}
+
+	stopCh := make(chan struct{})
+	close(stopCh)
+	start = time.Now()
+	if err := waitForCacheSync(stopCh, synced, time.Hour, 5); err == nil {
+		t.Errorf("waitForCacheSync() expected error when stopped")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("waitForCacheSync() took %s after stopped, want no retry", elapsed)
+	}
+}
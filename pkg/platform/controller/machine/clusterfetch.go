@@ -0,0 +1,94 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"context"
+	"errors"
+	"net"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+
+	platformv1 "tkestack.io/tke/api/platform/v1"
+	"tkestack.io/tke/pkg/util/log"
+)
+
+const (
+	conditionTypeClusterAvailable = "ClusterAvailable"
+	reasonClusterNotFound         = "ClusterNotFound"
+	reasonClusterFetchFailed      = "ClusterFetchFailed"
+
+	// clusterUnavailablePeriod is the period to requeue machines whose
+	// cluster is not found or can't be got for a permanent error.
+	clusterUnavailablePeriod = 5 * time.Minute
+)
+
+// errClusterUnavailable is returned when the cluster of machine is not found
+// or can't be got for a permanent error, the machine is requeued after
+// clusterUnavailablePeriod rather than retried rapidly.
+var errClusterUnavailable = errors.New("cluster is unavailable")
+
+// isTransientError returns true if the error is likely to go away on retry,
+// e.g. network errors, timeouts and throttling.
+func isTransientError(err error) bool {
+	if apierrors.IsServerTimeout(err) || apierrors.IsTimeout(err) ||
+		apierrors.IsTooManyRequests(err) || apierrors.IsInternalError(err) ||
+		apierrors.IsServiceUnavailable(err) || apierrors.IsUnexpectedServerError(err) {
+		return true
+	}
+	if errors.Is(err, context.DeadlineExceeded) ||
+		utilnet.IsConnectionRefused(err) || utilnet.IsConnectionReset(err) || utilnet.IsProbableEOF(err) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// handleClusterError handles the error getting the cluster of machine. The
+// transient errors are returned to be retried with the rate limiter, while
+// for a missing cluster or permanent errors the ClusterAvailable condition
+// is set and the machine is requeued after clusterUnavailablePeriod.
+func (c *Controller) handleClusterError(ctx context.Context, machine *platformv1.Machine, err error) error {
+	if isTransientError(err) {
+		return err
+	}
+	reason := reasonClusterFetchFailed
+	if apierrors.IsNotFound(err) {
+		reason = reasonClusterNotFound
+	}
+	log.FromContext(ctx).Error(err, "Failed to get cluster of machine", "reason", reason)
+
+	if condition := machine.GetCondition(conditionTypeClusterAvailable); condition == nil ||
+		condition.Reason != reason || condition.Message != err.Error() {
+		machine = machine.DeepCopy()
+		machine.SetCondition(platformv1.MachineCondition{
+			Type:    conditionTypeClusterAvailable,
+			Status:  platformv1.ConditionFalse,
+			Reason:  reason,
+			Message: err.Error(),
+		})
+		if _, err := c.platformClient.Machines().Update(ctx, machine, metav1.UpdateOptions{}); err != nil {
+			return err
+		}
+	}
+	return errClusterUnavailable
+}
@@ -0,0 +1,95 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	platformversionedclient "tkestack.io/tke/api/client/clientset/versioned/typed/platform/v1"
+	platformv1 "tkestack.io/tke/api/platform/v1"
+	typesv1 "tkestack.io/tke/pkg/platform/types/v1"
+)
+
+func TestController_onUpdateClusterError(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantReason string
+		wantRetry  bool
+	}{
+		{
+			name:      "transient",
+			err:       apierrors.NewServiceUnavailable("apiserver is restarting"),
+			wantRetry: true,
+		},
+		{
+			name:       "not found",
+			err:        apierrors.NewNotFound(schema.GroupResource{Group: "platform.tkestack.io", Resource: "clusters"}, "global"),
+			wantReason: reasonClusterNotFound,
+		},
+		{
+			name:       "permanent",
+			err:        errors.New("cluster: unknown provider \"Unknown\""),
+			wantReason: reasonClusterFetchFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			providerName := registerFakeProvider(t, &fakeProvider{})
+			machine := newMachineForTest("1", nil, platformv1.MachineRunning, nil)
+			machine.Name = "machine"
+			machine.Spec.Type = providerName
+			c := newControllerForTest(machine)
+			c.getCluster = func(ctx context.Context, platformClient platformversionedclient.PlatformV1Interface, name, username string) (*typesv1.Cluster, error) {
+				return nil, tt.err
+			}
+
+			err := c.onUpdate(context.Background(), machine)
+			delay, requeued := requeueDelay(err)
+			if tt.wantRetry {
+				if !errors.Is(err, tt.err) || requeued {
+					t.Errorf("onUpdate() error = %v, want %v retried by rate limiter", err, tt.err)
+				}
+			} else if !errors.Is(err, errClusterUnavailable) || delay != clusterUnavailablePeriod {
+				t.Errorf("onUpdate() error = %v requeued after %s, want %v requeued after %s", err, delay, errClusterUnavailable, clusterUnavailablePeriod)
+			}
+
+			got, err := c.platformClient.Machines().Get(context.Background(), machine.Name, v1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			condition := got.GetCondition(conditionTypeClusterAvailable)
+			if tt.wantReason == "" {
+				if condition != nil {
+					t.Errorf("cluster condition = %+v, want none for transient error", condition)
+				}
+				return
+			}
+			if condition == nil || condition.Status != platformv1.ConditionFalse || condition.Reason != tt.wantReason {
+				t.Errorf("cluster condition = %+v, want false with reason %s", condition, tt.wantReason)
+			}
+		})
+	}
+}
@@ -20,6 +20,30 @@ package config
 
 import "time"
 
+const (
+	// FailedMachinePodPolicyNone leaves the pods on the node of failed machine.
+	FailedMachinePodPolicyNone = "none"
+	// FailedMachinePodPolicyTaint taints the node of failed machine, so that
+	// no more pods are scheduled to it.
+	FailedMachinePodPolicyTaint = "taint"
+	// FailedMachinePodPolicyEvict taints the node of failed machine and evicts
+	// its pods once the machine fails, so that they are rescheduled to other
+	// nodes.
+	FailedMachinePodPolicyEvict = "evict"
+	// FailedMachinePodPolicyCordon cordons the node of failed machine rather
+	// than tainting it, so that no more pods are scheduled to it.
+	FailedMachinePodPolicyCordon = "cordon"
+)
+
+const (
+	// HealthConditionPatchMerge writes the health check condition along with
+	// other changes of machine by strategic merge patch.
+	HealthConditionPatchMerge = "merge"
+	// HealthConditionPatchApply writes the health check condition by
+	// server-side apply, so that the controller owns just that condition.
+	HealthConditionPatchApply = "apply"
+)
+
 // MachineControllerConfiguration contains elements describing MachineController.
 type MachineControllerConfiguration struct {
 	// machineSyncPeriod is the period for syncing machine life-cycle
@@ -32,4 +56,118 @@ type MachineControllerConfiguration struct {
 	BucketRateLimiterLimit int
 	// BucketRateLimiterBurst bursts of at most b tokens.
 	BucketRateLimiterBurst int
+	// ProviderTimeout is the timeout of a single provider operation,
+	// such as OnCreate and OnUpdate. Zero means no timeout.
+	ProviderTimeout time.Duration
+	// DedicatedPoolSelector is an annotation selector of machines which are
+	// processed by dedicated workers, separately from the general workers.
+	DedicatedPoolSelector string
+	// ConcurrentDedicatedMachineSyncs is the number of dedicated workers.
+	ConcurrentDedicatedMachineSyncs int
+	// HealthWebhookURL is the outbound webhook notified when machines
+	// fail or recover. Empty means no notification.
+	HealthWebhookURL string
+	// HealthWebhookTimeout is the timeout of a single webhook request.
+	HealthWebhookTimeout time.Duration
+	// HealthWebhookRetries is the number of retries of a failed webhook request.
+	HealthWebhookRetries int
+	// PhaseResyncPeriods is the period to requeue machines after reconcile
+	// keyed by machine phase, e.g. to resync Failed machines sooner.
+	PhaseResyncPeriods map[string]time.Duration
+	// SkipRedundantResync drops informer resyncs of machines which have been
+	// reconciled at the same resourceVersion and have no health check due.
+	// It's opt-in since those resyncs also repair drift outside the machine,
+	// e.g. of its node, which is then only repaired by the health check.
+	SkipRedundantResync bool
+	// MaxConcurrentHealthChecks is the max number of machine health checks
+	// in flight. Zero means no limit.
+	MaxConcurrentHealthChecks int
+	// JoinGracePeriod is the period after a machine joined the cluster during
+	// which a missing node is treated as unknown health rather than failed.
+	JoinGracePeriod time.Duration
+	// StartupStaggerWindow is the window over which the first syncs of
+	// existing machines are spread on start, e.g. the sync period, to avoid
+	// a burst of health checks. Zero means no stagger.
+	StartupStaggerWindow time.Duration
+	// HealthAuditConfigMap is the namespace/name of the ConfigMap in the
+	// global cluster which machine failures and recoveries are appended to.
+	// Empty means no audit.
+	HealthAuditConfigMap string
+	// HealthAuditMaxRecords is the number of records in the audit ConfigMap
+	// before they are rotated.
+	HealthAuditMaxRecords int
+	// MaxInFlightWrites is the max number of concurrent machine writes to
+	// the platform API across all workers. Zero means no limit.
+	MaxInFlightWrites int
+	// NodeHeartbeatThreshold is the max age of the Ready heartbeat of node
+	// for a machine to be healthy. Zero means the heartbeat isn't checked,
+	// which is the default since the right threshold depends on how often
+	// kubelet reports the node status in the cluster.
+	NodeHeartbeatThreshold time.Duration
+	// NodeHeartbeatAnnotation is the node annotation whose timestamp is
+	// checked against NodeHeartbeatThreshold besides the Ready heartbeat.
+	NodeHeartbeatAnnotation string
+	// NodeRequirePodCIDR requires the node of healthy machines to have
+	// PodCIDR assigned.
+	NodeRequirePodCIDR bool
+	// NodeRequireNetworkAvailable requires the NetworkUnavailable condition
+	// of the node of healthy machines to be False.
+	NodeRequireNetworkAvailable bool
+	// NodeAnnotationPrefixes are the prefixes of machine annotations which
+	// are mirrored onto the node of machine. Empty means none.
+	NodeAnnotationPrefixes []string
+	// FailedMachinePodPolicy is the policy to the pods on the node of failed
+	// machines, one of none, taint, evict and cordon.
+	FailedMachinePodPolicy string
+	// RecoverySuccessThreshold is the number of consecutive successful health
+	// checks for a failed machine to be Running again.
+	RecoverySuccessThreshold int
+	// KubeletHealthzPath is the path of kubelet healthz probed on port 10250
+	// of machines besides the node status. Empty means it isn't probed.
+	KubeletHealthzPath string
+	// SustainedPressureProbes is the number of consecutive probes in which
+	// the node is under pressure to report sustained pressure. Zero means
+	// the pressure isn't tracked.
+	SustainedPressureProbes int
+	// HealthConditionPatch is how the health check condition is written,
+	// one of merge and apply.
+	HealthConditionPatch string
+	// UpdateRequiresReadyNode skips the provider OnUpdate of machines whose
+	// node is not ready or not found, until the node is ready.
+	UpdateRequiresReadyNode bool
+	// MaxMachineAge is the max age of running machines since provisioned,
+	// older machines are provisioned again. Zero means no limit.
+	MaxMachineAge time.Duration
+	// ProbeTimeWriteInterval is the min interval to write the probe time of
+	// conditions if nothing else of machine is changed by health check. Zero
+	// means it's written on every probe.
+	ProbeTimeWriteInterval time.Duration
+	// NodeGetTimeout is the timeout of getting the node of machine by health
+	// check, distinct from the provider timeout. Zero means no timeout.
+	NodeGetTimeout time.Duration
+	// CreateFailureBackoff, UpdateFailureBackoff and DeleteFailureBackoff
+	// are the base delays of the exponential backoff to requeue machines
+	// which failed to be created, updated and deleted. Zero means the
+	// default base delay.
+	CreateFailureBackoff time.Duration
+	UpdateFailureBackoff time.Duration
+	DeleteFailureBackoff time.Duration
+	// ProviderErrorRetries, ConflictErrorRetries and NetworkErrorRetries are
+	// the max number of retries of machines which failed by provider errors,
+	// API conflicts and network errors, counted separately until the machine
+	// is synced. Zero means no limit.
+	ProviderErrorRetries int
+	ConflictErrorRetries int
+	NetworkErrorRetries  int
+	// SpecUpdateDebounce is the window a machine is reconciled after its last
+	// spec change, so rapid spec changes are reconciled once by the latest
+	// spec. Zero disables debouncing.
+	SpecUpdateDebounce time.Duration
+	// NodeHealthCondition is the type of node condition mirroring the
+	// HealthCheck condition of machine. Empty means none is written.
+	NodeHealthCondition string
+	// LegacyFinalizers are the finalizer tokens of machines created by
+	// earlier deployments, which are replaced by the current token so that
+	// those machines are cleaned up on deletion. Empty means none.
+	LegacyFinalizers []string
 }
@@ -0,0 +1,68 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"context"
+	"errors"
+
+	platformv1 "tkestack.io/tke/api/platform/v1"
+	machineprovider "tkestack.io/tke/pkg/platform/provider/machine"
+	typesv1 "tkestack.io/tke/pkg/platform/types/v1"
+	"tkestack.io/tke/pkg/util/log"
+)
+
+const (
+	conditionTypeConverging = "Converging"
+
+	reasonUpdateInProgress = "UpdateInProgress"
+)
+
+// errConverging is returned by onUpdate while the asynchronous update of the
+// provider isn't done, the machine is requeued with backoff.
+var errConverging = errors.New("machine update is converging")
+
+// providerUpdate updates the machine by the provider, done is false if the
+// provider implements AsyncUpdater and needs another pass.
+func providerUpdate(ctx context.Context, provider machineprovider.Provider, machine *platformv1.Machine, cluster *typesv1.Cluster, options machineprovider.UpdateOptions) (bool, error) {
+	if updater, ok := provider.(machineprovider.AsyncUpdater); ok {
+		return updater.OnAsyncUpdate(ctx, machine, cluster, options)
+	}
+	return true, provider.OnUpdate(ctx, machine, cluster, options)
+}
+
+// setConverging marks the machine by a condition while the update of the
+// provider isn't done and returns errConverging, the condition is removed
+// once the update is done.
+func setConverging(ctx context.Context, machine *platformv1.Machine, done bool) error {
+	if done {
+		if machine.GetCondition(conditionTypeConverging) != nil {
+			log.FromContext(ctx).Info("Machine update has converged")
+			removeCondition(machine, conditionTypeConverging)
+		}
+		return nil
+	}
+	machine.SetCondition(platformv1.MachineCondition{
+		Type:    conditionTypeConverging,
+		Status:  platformv1.ConditionTrue,
+		Reason:  reasonUpdateInProgress,
+		Message: "provider update is in progress, waiting for another pass",
+	})
+	return errConverging
+}
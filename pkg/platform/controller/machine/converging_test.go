@@ -0,0 +1,81 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	platformv1 "tkestack.io/tke/api/platform/v1"
+	machineprovider "tkestack.io/tke/pkg/platform/provider/machine"
+	typesv1 "tkestack.io/tke/pkg/platform/types/v1"
+)
+
+// asyncProvider is a fake provider whose update converges after the passes
+// of dones.
+type asyncProvider struct {
+	fakeProvider
+
+	dones []bool
+}
+
+func (p *asyncProvider) OnAsyncUpdate(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster, options machineprovider.UpdateOptions) (bool, error) {
+	done := p.dones[0]
+	p.dones = p.dones[1:]
+	return done, nil
+}
+
+func TestController_onUpdateConverging(t *testing.T) {
+	providerName := registerFakeProvider(t, &asyncProvider{dones: []bool{false, true}})
+	machine := newMachineForTest("1", nil, platformv1.MachineRunning, nil)
+	machine.Name = "machine"
+	machine.Spec.Type = providerName
+	c := newControllerForTest(machine)
+	c.getCluster = fakeGetCluster
+
+	get := func() *platformv1.Machine {
+		got, err := c.platformClient.Machines().Get(context.Background(), machine.Name, v1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return got
+	}
+
+	err := c.onUpdate(context.Background(), machine.DeepCopy())
+	if !errors.Is(err, errConverging) {
+		t.Fatalf("onUpdate() error = %v, want %v", err, errConverging)
+	}
+	if _, requeue := requeueDelay(err); requeue {
+		t.Errorf("requeueDelay(%v) should back off", err)
+	}
+	got := get()
+	condition := got.GetCondition(conditionTypeConverging)
+	if condition == nil || condition.Status != platformv1.ConditionTrue || condition.Reason != reasonUpdateInProgress {
+		t.Errorf("converging condition = %+v, want true while the update is in progress", condition)
+	}
+
+	if err := c.onUpdate(context.Background(), got.DeepCopy()); err != nil {
+		t.Fatalf("onUpdate() error = %v after the update is done", err)
+	}
+	if condition := get().GetCondition(conditionTypeConverging); condition != nil {
+		t.Errorf("converging condition = %+v, want it removed once the update is done", condition)
+	}
+}
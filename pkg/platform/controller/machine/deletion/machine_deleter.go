@@ -22,21 +22,39 @@ import (
 	"context"
 	"fmt"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes"
 
 	v1clientset "tkestack.io/tke/api/client/clientset/versioned/typed/platform/v1"
 	v1 "tkestack.io/tke/api/platform/v1"
 	clusterprovider "tkestack.io/tke/pkg/platform/provider/cluster"
 	machineprovider "tkestack.io/tke/pkg/platform/provider/machine"
+	"tkestack.io/tke/pkg/util/apiclient"
 	"tkestack.io/tke/pkg/util/log"
 )
 
 // MachineDeleterInterface to delete a machine with all resources in it.
 type MachineDeleterInterface interface {
-	Delete(ctx context.Context, name string) error
+	Delete(ctx context.Context, name string) (DeletionResult, error)
+}
+
+// DeletionResult summarizes the actions taken by a call of Delete, the
+// actions of earlier calls for the same machine are not included.
+type DeletionResult struct {
+	// ProviderCleaned is true if the machine provider cleaned the machine up.
+	ProviderCleaned bool `json:"providerCleaned,omitempty"`
+	// NodeDeleted is true if the node of machine is confirmed deleted.
+	NodeDeleted bool `json:"nodeDeleted,omitempty"`
+	// NodeKept is true if the node is left in the cluster on request.
+	NodeKept bool `json:"nodeKept,omitempty"`
+	// FinalizersRemoved are the finalizers removed from the machine.
+	FinalizersRemoved []v1.FinalizerName `json:"finalizersRemoved,omitempty"`
+	// MachineDeleted is true if the machine object is deleted.
+	MachineDeleted bool `json:"machineDeleted,omitempty"`
 }
 
 // NewMachineDeleter creates the machineeleter object and returns it.
@@ -77,12 +95,13 @@ type machineDeleter struct {
 // * It removes finalizer token from the given machine.
 // * Deletes the machine if deleteWhenDone is true.
 //
-// Returns an error if any of those steps fail.
+// Returns the summary of actions taken, and an error if any of those steps fail.
 // Returns ResourcesRemainingError if it deleted some resources but needs
 // to wait for them to go away.
 // Caller is expected to keep calling this until it succeeds.
-func (d *machineDeleter) Delete(ctx context.Context, name string) error {
+func (d *machineDeleter) Delete(ctx context.Context, name string) (DeletionResult, error) {
 	ctx = log.FromContext(ctx).WithName("Delete").WithContext(ctx)
+	result := DeletionResult{}
 
 	// Multiple controllers may edit a machine during termination
 	// first get the latest state of the machine before proceeding
@@ -90,12 +109,12 @@ func (d *machineDeleter) Delete(ctx context.Context, name string) error {
 	machine, err := d.machineClient.Get(context.Background(), name, metav1.GetOptions{})
 	if err != nil {
 		if errors.IsNotFound(err) {
-			return nil
+			return result, nil
 		}
-		return err
+		return result, err
 	}
 	if machine.DeletionTimestamp == nil {
-		return nil
+		return result, nil
 	}
 
 	// ensure that the status is up to date on the machine
@@ -103,48 +122,52 @@ func (d *machineDeleter) Delete(ctx context.Context, name string) error {
 	machine, err = d.retryOnConflictError(ctx, machine, d.updateMachineStatusFunc)
 	if err != nil {
 		if errors.IsNotFound(err) {
-			return nil
+			return result, nil
 		}
-		return err
+		return result, err
 	}
 
 	// the latest view of the machine asserts that machine is no longer deleting..
 	if machine.DeletionTimestamp.IsZero() {
-		return nil
+		return result, nil
 	}
 
 	// Delete the machine if it is already finalized.
 	if d.deleteWhenDone && finalized(machine) {
-		return d.deleteMachine(machine)
+		return result, d.deleteMachine(machine, &result)
 	}
 
 	// there may still be content for us to remove
-	err = d.deleteAllContent(ctx, machine)
+	err = d.deleteAllContent(ctx, machine, &result)
 	if err != nil {
-		return err
+		return result, err
 	}
 
 	// we have removed content, so mark it finalized by us
+	finalizing := hasFinalizer(machine, d.finalizerToken)
 	machine, err = d.retryOnConflictError(ctx, machine, d.finalizeMachine)
 	if err != nil {
 		// in normal practice, this should not be possible, but if a deployment is running
 		// two controllers to do machine deletion that share a common finalizer token it's
 		// possible that a not found could occur since the other controller would have finished the delete.
 		if errors.IsNotFound(err) {
-			return nil
+			return result, nil
 		}
-		return err
+		return result, err
+	}
+	if finalizing {
+		result.FinalizersRemoved = append(result.FinalizersRemoved, d.finalizerToken)
 	}
 
 	// Check if we can delete now.
 	if d.deleteWhenDone && finalized(machine) {
-		return d.deleteMachine(machine)
+		return result, d.deleteMachine(machine, &result)
 	}
-	return nil
+	return result, nil
 }
 
 // Deletes the given machine.
-func (d *machineDeleter) deleteMachine(machine *v1.Machine) error {
+func (d *machineDeleter) deleteMachine(machine *v1.Machine, result *DeletionResult) error {
 	var opts metav1.DeleteOptions
 	uid := machine.UID
 	if len(uid) > 0 {
@@ -154,6 +177,7 @@ func (d *machineDeleter) deleteMachine(machine *v1.Machine) error {
 	if err != nil && !errors.IsNotFound(err) {
 		return err
 	}
+	result.MachineDeleted = true
 	return nil
 }
 
@@ -201,22 +225,38 @@ func finalized(machine *v1.Machine) bool {
 	return len(machine.Spec.Finalizers) == 0
 }
 
-// finalizeMachine removes the specified finalizerToken and finalizes the machine
-func (d *machineDeleter) finalizeMachine(ctx context.Context, machine *v1.Machine) (*v1.Machine, error) {
-	machineFinalize := v1.Machine{}
-	machineFinalize.ObjectMeta = machine.ObjectMeta
-	machineFinalize.Spec = machine.Spec
+// hasFinalizer returns true if the finalizer is in machine.Spec.Finalizers
+func hasFinalizer(machine *v1.Machine, finalizer v1.FinalizerName) bool {
+	for _, f := range machine.Spec.Finalizers {
+		if f == finalizer {
+			return true
+		}
+	}
+	return false
+}
 
+// removeFinalizer returns the deduplicated finalizers without finalizer, all
+// of its entries are removed even if it has been added more than once.
+func removeFinalizer(finalizers []v1.FinalizerName, finalizer v1.FinalizerName) []v1.FinalizerName {
 	finalizerSet := sets.NewString()
-	for i := range machine.Spec.Finalizers {
-		if machine.Spec.Finalizers[i] != d.finalizerToken {
-			finalizerSet.Insert(string(machine.Spec.Finalizers[i]))
+	for i := range finalizers {
+		if finalizers[i] != finalizer {
+			finalizerSet.Insert(string(finalizers[i]))
 		}
 	}
-	machineFinalize.Spec.Finalizers = make([]v1.FinalizerName, 0, len(finalizerSet))
+	result := make([]v1.FinalizerName, 0, len(finalizerSet))
 	for _, value := range finalizerSet.List() {
-		machineFinalize.Spec.Finalizers = append(machineFinalize.Spec.Finalizers, v1.FinalizerName(value))
+		result = append(result, v1.FinalizerName(value))
 	}
+	return result
+}
+
+// finalizeMachine removes the specified finalizerToken and finalizes the machine
+func (d *machineDeleter) finalizeMachine(ctx context.Context, machine *v1.Machine) (*v1.Machine, error) {
+	machineFinalize := v1.Machine{}
+	machineFinalize.ObjectMeta = machine.ObjectMeta
+	machineFinalize.Spec = machine.Spec
+	machineFinalize.Spec.Finalizers = removeFinalizer(machine.Spec.Finalizers, d.finalizerToken)
 
 	machine = &v1.Machine{}
 	err := d.platformClient.RESTClient().Put().
@@ -236,19 +276,20 @@ func (d *machineDeleter) finalizeMachine(ctx context.Context, machine *v1.Machin
 	return machine, err
 }
 
-type deleteResourceFunc func(ctx context.Context, deleter *machineDeleter, machine *v1.Machine) error
+type deleteResourceFunc func(ctx context.Context, deleter *machineDeleter, machine *v1.Machine, result *DeletionResult) error
 
 var deleteResourceFuncs = []deleteResourceFunc{
 	deleteMachineProvider,
+	ensureNodeDeleted,
 }
 
 // deleteAllContent will use the client to delete each resource identified in machine.
-func (d *machineDeleter) deleteAllContent(ctx context.Context, machine *v1.Machine) error {
+func (d *machineDeleter) deleteAllContent(ctx context.Context, machine *v1.Machine, result *DeletionResult) error {
 	log.FromContext(ctx).Info("deleteAllContent doing")
 
 	var errs []error
 	for _, deleteFunc := range deleteResourceFuncs {
-		err := deleteFunc(ctx, d, machine)
+		err := deleteFunc(ctx, d, machine, result)
 		if err != nil {
 			// If there is an error, hold on to it but proceed with all the remaining resource.
 			errs = append(errs, err)
@@ -264,7 +305,7 @@ func (d *machineDeleter) deleteAllContent(ctx context.Context, machine *v1.Machi
 	return nil
 }
 
-func deleteMachineProvider(ctx context.Context, deleter *machineDeleter, machine *v1.Machine) error {
+func deleteMachineProvider(ctx context.Context, deleter *machineDeleter, machine *v1.Machine, result *DeletionResult) error {
 	log.FromContext(ctx).Info("deleteMachineProvider doing")
 
 	provider, err := machineprovider.GetProvider(machine.Spec.Type)
@@ -280,8 +321,68 @@ func deleteMachineProvider(ctx context.Context, deleter *machineDeleter, machine
 	if err != nil {
 		return err
 	}
+	result.ProviderCleaned = true
 
 	log.FromContext(ctx).Info("deleteMachineProvider done")
 
 	return nil
 }
+
+// ensureNodeDeleted confirms that the node of the machine has been removed
+// from the cluster, unless the node is kept explicitly or the cluster is gone
+// with its nodes. The finalizer is not removed while the node lingers, so
+// that the deletion is retried.
+func ensureNodeDeleted(ctx context.Context, deleter *machineDeleter, machine *v1.Machine, result *DeletionResult) error {
+	if _, ok := machine.Annotations[v1.MachineKeepNodeAnno]; ok {
+		log.FromContext(ctx).Info("Node is kept, skip checking node deletion")
+		result.NodeKept = true
+		return nil
+	}
+	cls, err := deleter.platformClient.Clusters().Get(ctx, machine.Spec.ClusterName, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		log.FromContext(ctx).Info("Cluster is not found, skip checking node deletion")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if cls.DeletionTimestamp != nil || cls.Status.Phase == v1.ClusterTerminating {
+		log.FromContext(ctx).Info("Cluster is deleting, skip checking node deletion")
+		return nil
+	}
+	cluster, err := clusterprovider.GetV1Cluster(ctx, deleter.platformClient, cls, clusterprovider.AdminUsername)
+	if err != nil {
+		return err
+	}
+	client, err := cluster.Clientset()
+	if err != nil {
+		return err
+	}
+
+	if err := checkNodeDeleted(ctx, client, machine); err != nil {
+		return err
+	}
+	result.NodeDeleted = true
+	return nil
+}
+
+// checkNodeDeleted returns an error if the node of the machine still exists.
+func checkNodeDeleted(ctx context.Context, client kubernetes.Interface, machine *v1.Machine) error {
+	var (
+		node *corev1.Node
+		err  error
+	)
+	if name := machine.Status.NodeName; name != "" {
+		node, err = client.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{})
+	}
+	if node == nil || errors.IsNotFound(err) {
+		node, err = apiclient.GetNodeByMachineIP(ctx, client, machine.Spec.IP)
+	}
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return fmt.Errorf("node %s of machine still exists", node.Name)
+}
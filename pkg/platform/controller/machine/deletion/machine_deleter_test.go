@@ -0,0 +1,171 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package deletion
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	platformfake "tkestack.io/tke/api/client/clientset/versioned/fake"
+	platformv1client "tkestack.io/tke/api/client/clientset/versioned/typed/platform/v1"
+	v1 "tkestack.io/tke/api/platform/v1"
+)
+
+func TestCheckNodeDeleted(t *testing.T) {
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "10.0.0.1"}}
+	client := fake.NewSimpleClientset(node)
+	machine := &v1.Machine{
+		ObjectMeta: metav1.ObjectMeta{Name: "machine"},
+		Spec:       v1.MachineSpec{IP: "10.0.0.1"},
+	}
+
+	if err := checkNodeDeleted(context.Background(), client, machine); err == nil {
+		t.Errorf("checkNodeDeleted() error = nil, want blocked while node exists")
+	}
+
+	if err := client.CoreV1().Nodes().Delete(context.Background(), node.Name, metav1.DeleteOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := checkNodeDeleted(context.Background(), client, machine); err != nil {
+		t.Errorf("checkNodeDeleted() error = %v after node is deleted", err)
+	}
+}
+
+func TestMachineDeleter_DeleteResult(t *testing.T) {
+	now := metav1.Now()
+	machine := &v1.Machine{
+		ObjectMeta: metav1.ObjectMeta{Name: "machine", DeletionTimestamp: &now},
+		Status:     v1.MachineStatus{Phase: v1.MachineTerminating},
+	}
+	client := platformfake.NewSimpleClientset(machine)
+	d := NewMachineDeleter(client.PlatformV1().Machines(), client.PlatformV1(), v1.MachineFinalize, true)
+
+	result, err := d.Delete(context.Background(), machine.Name)
+	if err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if want := (DeletionResult{MachineDeleted: true}); !reflect.DeepEqual(result, want) {
+		t.Errorf("Delete() = %+v, want %+v", result, want)
+	}
+
+	result, err = d.Delete(context.Background(), machine.Name)
+	if err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if !reflect.DeepEqual(result, DeletionResult{}) {
+		t.Errorf("Delete() of deleted machine = %+v, want nothing done", result)
+	}
+}
+
+func TestEnsureNodeDeletedKeepNode(t *testing.T) {
+	machine := &v1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "machine",
+			Annotations: map[string]string{v1.MachineKeepNodeAnno: ""},
+		},
+	}
+	result := DeletionResult{}
+
+	if err := ensureNodeDeleted(context.Background(), &machineDeleter{}, machine, &result); err != nil {
+		t.Fatalf("ensureNodeDeleted() error = %v", err)
+	}
+	if want := (DeletionResult{NodeKept: true}); !reflect.DeepEqual(result, want) {
+		t.Errorf("result = %+v, want %+v", result, want)
+	}
+}
+
+func TestEnsureNodeDeletedClusterGone(t *testing.T) {
+	now := metav1.Now()
+	tests := []struct {
+		name     string
+		clusters []runtime.Object
+	}{
+		{name: "cluster not found"},
+		{name: "cluster deleting", clusters: []runtime.Object{&v1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "cls", DeletionTimestamp: &now}}}},
+		{name: "cluster terminating", clusters: []runtime.Object{&v1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "cls"}, Status: v1.ClusterStatus{Phase: v1.ClusterTerminating}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := platformfake.NewSimpleClientset(tt.clusters...)
+			machine := &v1.Machine{
+				ObjectMeta: metav1.ObjectMeta{Name: "machine"},
+				Spec:       v1.MachineSpec{ClusterName: "cls", IP: "10.0.0.1"},
+			}
+			result := DeletionResult{}
+
+			if err := ensureNodeDeleted(context.Background(), &machineDeleter{platformClient: client.PlatformV1()}, machine, &result); err != nil {
+				t.Fatalf("ensureNodeDeleted() error = %v, want node wait skipped", err)
+			}
+			if !reflect.DeepEqual(result, DeletionResult{}) {
+				t.Errorf("result = %+v, want node neither deleted nor kept", result)
+			}
+		})
+	}
+}
+
+func TestMachineDeleter_finalizeMachineDuplicated(t *testing.T) {
+	var finalized *v1.Machine
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/apis/platform.tkestack.io/v1/machines/machine/finalize" {
+			http.NotFound(w, r)
+			return
+		}
+		finalized = &v1.Machine{}
+		if err := json.NewDecoder(r.Body).Decode(finalized); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(finalized)
+	}))
+	defer server.Close()
+	platformClient, err := platformv1client.NewForConfig(&rest.Config{Host: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	machine := &v1.Machine{
+		ObjectMeta: metav1.ObjectMeta{Name: "machine"},
+		Spec: v1.MachineSpec{
+			Finalizers: []v1.FinalizerName{v1.MachineFinalize, "other", v1.MachineFinalize, "other"},
+		},
+	}
+	client := platformfake.NewSimpleClientset(machine)
+	d := NewMachineDeleter(client.PlatformV1().Machines(), platformClient, v1.MachineFinalize, true).(*machineDeleter)
+
+	got, err := d.finalizeMachine(context.Background(), machine)
+	if err != nil {
+		t.Fatalf("finalizeMachine() error = %v", err)
+	}
+	want := []v1.FinalizerName{"other"}
+	if !reflect.DeepEqual(finalized.Spec.Finalizers, want) {
+		t.Errorf("finalized finalizers = %v, want %v", finalized.Spec.Finalizers, want)
+	}
+	if hasFinalizer(got, v1.MachineFinalize) {
+		t.Errorf("finalizeMachine() = %v, want all duplicated %s removed", got.Spec.Finalizers, v1.MachineFinalize)
+	}
+}
@@ -0,0 +1,136 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	platformv1 "tkestack.io/tke/api/platform/v1"
+	"tkestack.io/tke/pkg/util/log"
+)
+
+const defaultDrainPollInterval = 5 * time.Second
+
+// DrainPool terminates the machines of the pool selected by selector in
+// waves. At most maxUnavailable machines of the pool are unavailable at any
+// time, a machine is unavailable while it's terminating or not yet Running,
+// e.g. a replacement which is still initializing. The next machine is only
+// deleted once the pool is ready again. DrainPool returns when all machines
+// which were in the pool when it was called have been deleted.
+func (c *Controller) DrainPool(ctx context.Context, selector labels.Selector, maxUnavailable int) error {
+	if maxUnavailable < 1 {
+		return fmt.Errorf("max unavailable must be positive, got %d", maxUnavailable)
+	}
+	interval := c.drainPollInterval
+	if interval == 0 {
+		interval = defaultDrainPollInterval
+	}
+
+	machines, err := c.listPool(ctx, selector)
+	if err != nil {
+		return err
+	}
+	pending := sets.NewString()
+	for _, machine := range machines {
+		if machine.DeletionTimestamp.IsZero() && machine.Status.Phase != platformv1.MachineTerminating {
+			pending.Insert(machine.Name)
+		}
+	}
+	logger := log.FromContext(ctx).WithValues("selector", selector.String())
+	logger.Info("Start draining machine pool", "machines", pending.Len(), "maxUnavailable", maxUnavailable)
+
+	return wait.PollImmediateUntil(interval, func() (bool, error) {
+		machines, err := c.listPool(ctx, selector)
+		if err != nil {
+			return false, err
+		}
+		wave := nextDrainWave(machines, pending, maxUnavailable)
+		for _, machine := range wave {
+			logger.Info("Delete machine of the pool", "machine", machine.Name)
+			if err := c.platformClient.Machines().Delete(ctx, machine.Name, metav1.DeleteOptions{}); err != nil {
+				return false, err
+			}
+		}
+
+		remaining := 0
+		for _, machine := range machines {
+			if pending.Has(machine.Name) {
+				remaining++
+			}
+		}
+		return remaining == 0, nil
+	}, ctx.Done())
+}
+
+func (c *Controller) listPool(ctx context.Context, selector labels.Selector) ([]platformv1.Machine, error) {
+	machineList, err := c.platformClient.Machines().List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, err
+	}
+	return machineList.Items, nil
+}
+
+// nextDrainWave returns the pending machines to be deleted next, so that no
+// more than maxUnavailable machines of the pool are unavailable. Pending
+// machines which are already unavailable or exempt from the coordination are
+// deleted without waiting, and exempt machines aren't counted unavailable.
+func nextDrainWave(machines []platformv1.Machine, pending sets.String, maxUnavailable int) []*platformv1.Machine {
+	unavailable := 0
+	var wave, candidates []*platformv1.Machine
+	for i := range machines {
+		machine := &machines[i]
+		deleting := !machine.DeletionTimestamp.IsZero() || machine.Status.Phase == platformv1.MachineTerminating
+		if isCoordinationExempt(machine) {
+			if !deleting && pending.Has(machine.Name) {
+				wave = append(wave, machine)
+			}
+			continue
+		}
+		if deleting || machine.Status.Phase != platformv1.MachineRunning {
+			unavailable++
+			if !deleting && pending.Has(machine.Name) {
+				wave = append(wave, machine)
+			}
+			continue
+		}
+		if pending.Has(machine.Name) {
+			candidates = append(candidates, machine)
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].Name < candidates[j].Name
+	})
+
+	count := maxUnavailable - unavailable
+	if count > len(candidates) {
+		count = len(candidates)
+	}
+	if count > 0 {
+		wave = append(wave, candidates[:count]...)
+	}
+	return wave
+}
@@ -0,0 +1,147 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	k8stesting "k8s.io/client-go/testing"
+	"tkestack.io/tke/api/client/clientset/versioned/fake"
+	platformv1 "tkestack.io/tke/api/platform/v1"
+)
+
+func TestController_DrainPool(t *testing.T) {
+	resource := schema.GroupVersionResource{Group: "platform.tkestack.io", Version: "v1", Resource: "machines"}
+	var objects []runtime.Object
+	for i := 0; i < 5; i++ {
+		machine := newMachineForTest("1", nil, platformv1.MachineRunning, nil)
+		machine.Name = fmt.Sprintf("machine-%d", i)
+		machine.Labels = map[string]string{"pool": "workers"}
+		objects = append(objects, machine)
+	}
+	other := newMachineForTest("1", nil, platformv1.MachineRunning, nil)
+	other.Name = "other"
+	objects = append(objects, other)
+	client := fake.NewSimpleClientset(objects...)
+	tracker := client.Tracker()
+
+	var (
+		mu             sync.Mutex
+		deleted        []string
+		maxTerminating int
+	)
+	terminating := func() []string {
+		var names []string
+		obj, _ := tracker.List(resource, platformv1.SchemeGroupVersion.WithKind("Machine"), "")
+		for _, machine := range obj.(*platformv1.MachineList).Items {
+			if machine.Status.Phase == platformv1.MachineTerminating {
+				names = append(names, machine.Name)
+			}
+		}
+		return names
+	}
+	// deletion marks the machine terminating as the apiserver does
+	client.PrependReactor("delete", "machines", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		name := action.(k8stesting.DeleteAction).GetName()
+		obj, err := tracker.Get(resource, "", name)
+		if err != nil {
+			return true, nil, err
+		}
+		machine := obj.(*platformv1.Machine)
+		machine.Status.Phase = platformv1.MachineTerminating
+		mu.Lock()
+		deleted = append(deleted, name)
+		if n := len(terminating()) + 1; n > maxTerminating {
+			maxTerminating = n
+		}
+		mu.Unlock()
+		return true, nil, tracker.Update(resource, machine, "")
+	})
+
+	// the deleter finishes terminating machines in the background
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	go func() {
+		for ctx.Err() == nil {
+			mu.Lock()
+			for _, name := range terminating() {
+				_ = tracker.Delete(resource, "", name)
+			}
+			mu.Unlock()
+			time.Sleep(5 * time.Millisecond)
+		}
+	}()
+
+	c := &Controller{platformClient: client.PlatformV1(), drainPollInterval: time.Millisecond}
+	if err := c.DrainPool(ctx, labels.SelectorFromSet(labels.Set{"pool": "workers"}), 1); err != nil {
+		t.Fatalf("DrainPool() error = %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	want := []string{"machine-0", "machine-1", "machine-2", "machine-3", "machine-4"}
+	if fmt.Sprint(deleted) != fmt.Sprint(want) {
+		t.Errorf("deleted machines = %v, want %v", deleted, want)
+	}
+	if maxTerminating != 1 {
+		t.Errorf("max terminating machines = %d, want 1", maxTerminating)
+	}
+	if _, err := client.PlatformV1().Machines().Get(context.Background(), other.Name, v1.GetOptions{}); err != nil {
+		t.Errorf("machine out of the pool should not be deleted: %v", err)
+	}
+}
+
+func TestNextDrainWaveCoordinationExempt(t *testing.T) {
+	newMachine := func(name string, phase platformv1.MachinePhase, exempt bool) platformv1.Machine {
+		machine := platformv1.Machine{
+			ObjectMeta: v1.ObjectMeta{Name: name},
+			Status:     platformv1.MachineStatus{Phase: phase},
+		}
+		if exempt {
+			machine.Annotations = map[string]string{platformv1.MachineCoordinationExemptAnno: "true"}
+		}
+		return machine
+	}
+	machines := []platformv1.Machine{
+		newMachine("a", platformv1.MachineRunning, false),
+		newMachine("b", platformv1.MachineRunning, false),
+		newMachine("canary", platformv1.MachineRunning, true),
+		newMachine("canary-replacement", platformv1.MachineInitializing, true),
+	}
+	pending := sets.NewString("a", "b", "canary")
+
+	var got []string
+	for _, machine := range nextDrainWave(machines, pending, 1) {
+		got = append(got, machine.Name)
+	}
+	want := []string{"canary", "a"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("nextDrainWave() = %v, want %v, exempt machines neither wait nor hold the pool", got, want)
+	}
+}
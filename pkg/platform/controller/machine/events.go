@@ -0,0 +1,109 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	"tkestack.io/tke/api/client/clientset/versioned/scheme"
+	platformv1 "tkestack.io/tke/api/platform/v1"
+	"tkestack.io/tke/pkg/util/log"
+)
+
+const (
+	eventReasonMachineFailed     = "MachineFailed"
+	eventReasonMachineRecovered  = "MachineRecovered"
+	eventReasonHealthCheckFailed = "HealthCheckFailed"
+)
+
+// newEventRecorder creates an event recorder for the machine controller,
+// whose events are logged and, if kubeclient isn't nil, stored through it.
+// Machines are cluster scoped, so their events are in the default namespace.
+// Stored events are correlated: repeated identical events of a machine are
+// aggregated into a single event with increasing count and last timestamp,
+// similar events are combined, and spamming events are dropped.
+func newEventRecorder(kubeclient kubernetes.Interface) record.EventRecorder {
+	broadcaster := record.NewBroadcasterWithCorrelatorOptions(record.CorrelatorOptions{})
+	broadcaster.StartLogging(log.Infof)
+	if kubeclient != nil {
+		broadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: kubeclient.CoreV1().Events(metav1.NamespaceDefault)})
+	}
+	return broadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: "machine-controller"})
+}
+
+// recordHealthCheckFailure emits an event for a failed health check, which
+// is repeated every health check interval until the machine recovers and
+// aggregated by the recorder.
+func (c *Controller) recordHealthCheckFailure(ctx context.Context, machine *platformv1.Machine, condition *platformv1.MachineCondition) {
+	if c.recorder == nil || condition.Status != platformv1.ConditionFalse {
+		return
+	}
+	c.recorder.AnnotatedEventf(machine, eventAnnotations(ctx), corev1.EventTypeWarning, eventReasonHealthCheckFailed, "%s", condition.Message)
+}
+
+// recordHealthTransition emits an event when the machine moves between
+// Running and Failed, including how long it stayed in the previous state.
+// The duration is computed from the previous health check condition.
+// The transition is also posted to the health webhook and appended to the
+// audit ConfigMap if configured.
+func (c *Controller) recordHealthTransition(ctx context.Context, machine *platformv1.Machine, oldPhase platformv1.MachinePhase, oldCondition *platformv1.MachineCondition) {
+	failed := oldPhase == platformv1.MachineRunning && machine.Status.Phase == platformv1.MachineFailed
+	recovered := oldPhase == platformv1.MachineFailed && machine.Status.Phase == platformv1.MachineRunning
+	if !(failed || recovered) {
+		return
+	}
+
+	notification := newHealthNotification(machine)
+	if c.webhook != nil {
+		go func() {
+			if err := c.webhook.notify(ctx, notification); err != nil {
+				log.FromContext(ctx).Error(err, "Notify machine health transition failed")
+			}
+		}()
+	}
+	if c.audit != nil {
+		go func() {
+			if err := c.audit.append(ctx, notification); err != nil {
+				log.FromContext(ctx).Error(err, "Append machine health transition to audit ConfigMap failed")
+			}
+		}()
+	}
+
+	if c.recorder == nil {
+		return
+	}
+	var since time.Duration
+	if oldCondition != nil && !oldCondition.LastTransitionTime.IsZero() {
+		since = time.Since(oldCondition.LastTransitionTime.Time).Round(time.Second)
+	}
+	if failed {
+		c.recorder.AnnotatedEventf(machine, eventAnnotations(ctx), corev1.EventTypeWarning, eventReasonMachineFailed,
+			"Machine was healthy for %s before failing: %s", since, machine.Status.Message)
+	} else {
+		c.recorder.AnnotatedEventf(machine, eventAnnotations(ctx), corev1.EventTypeNormal, eventReasonMachineRecovered,
+			"Machine was unhealthy for %s before recovering", since)
+	}
+}
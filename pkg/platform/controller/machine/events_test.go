@@ -0,0 +1,136 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+	platformv1 "tkestack.io/tke/api/platform/v1"
+	machineprovider "tkestack.io/tke/pkg/platform/provider/machine"
+	typesv1 "tkestack.io/tke/pkg/platform/types/v1"
+)
+
+func TestNewEventRecorder(t *testing.T) {
+	kubeclient := kubefake.NewSimpleClientset()
+	recorder := newEventRecorder(kubeclient)
+	machine := newMachineForTest("1", nil, platformv1.MachineFailed, nil)
+	machine.Name = "machine"
+
+	recorder.Eventf(machine, corev1.EventTypeWarning, eventReasonMachineFailed, "node is not ready")
+
+	err := wait.PollImmediate(10*time.Millisecond, wait.ForeverTestTimeout, func() (bool, error) {
+		events, err := kubeclient.CoreV1().Events("").List(context.Background(), v1.ListOptions{})
+		if err != nil {
+			return false, err
+		}
+		return len(events.Items) == 1 && events.Items[0].Reason == eventReasonMachineFailed, nil
+	})
+	if err != nil {
+		t.Errorf("expected the event to be stored: %v", err)
+	}
+}
+
+func TestController_recordHealthTransition(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	c := &Controller{recorder: recorder}
+
+	oldCondition := &platformv1.MachineCondition{
+		Type:               machineprovider.ConditionTypeHealthCheck,
+		Status:             platformv1.ConditionTrue,
+		LastTransitionTime: v1.NewTime(time.Now().Add(-3 * time.Hour)),
+	}
+	machine := newMachineForTest("1", nil, platformv1.MachineFailed, nil)
+	c.recordHealthTransition(context.Background(), machine, platformv1.MachineRunning, oldCondition)
+
+	select {
+	case event := <-recorder.Events:
+		if !strings.Contains(event, eventReasonMachineFailed) {
+			t.Errorf("event %q does not contain reason %s", event, eventReasonMachineFailed)
+		}
+		if !strings.Contains(event, "was healthy for 3h0m0s before failing") {
+			t.Errorf("event %q does not contain prior healthy duration", event)
+		}
+	default:
+		t.Fatal("expected an event for Running to Failed transition")
+	}
+
+	c.recordHealthTransition(context.Background(), machine, platformv1.MachineFailed, oldCondition)
+	select {
+	case event := <-recorder.Events:
+		t.Errorf("unexpected event %q without phase transition", event)
+	default:
+	}
+}
+
+func TestController_healthCheckFailureEventsAggregated(t *testing.T) {
+	providerName := registerFakeProvider(t, &fakeProvider{
+		onHealthCheck: func(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster) *platformv1.Machine {
+			machine.Status.Phase = platformv1.MachineFailed
+			machine.SetCondition(platformv1.MachineCondition{
+				Type:    machineprovider.ConditionTypeHealthCheck,
+				Status:  platformv1.ConditionFalse,
+				Reason:  machineprovider.FailedHealthCheckReason,
+				Message: "node is not ready",
+			})
+			return machine
+		},
+	})
+	machine := newMachineForTest("1", nil, platformv1.MachineFailed, nil)
+	machine.Name = "machine"
+	machine.Spec.Type = providerName
+	c := newControllerForTest(machine)
+	c.getCluster = fakeGetCluster
+	kubeclient := kubefake.NewSimpleClientset()
+	c.recorder = newEventRecorder(kubeclient)
+
+	for i := 0; i < 3; i++ {
+		if err := c.onUpdate(context.Background(), machine.DeepCopy()); err != nil {
+			t.Fatalf("onUpdate() error = %v", err)
+		}
+		// wait for the event to be stored before the next one, so that
+		// it's aggregated instead of racing with the creation
+		want := int32(i + 1)
+		err := wait.PollImmediate(10*time.Millisecond, wait.ForeverTestTimeout, func() (bool, error) {
+			events, err := kubeclient.CoreV1().Events(v1.NamespaceDefault).List(context.Background(), v1.ListOptions{})
+			if err != nil {
+				return false, err
+			}
+			return len(events.Items) == 1 && events.Items[0].Count == want, nil
+		})
+		if err != nil {
+			t.Fatalf("expected failed health check %d aggregated into one event with count %d: %v", i+1, want, err)
+		}
+	}
+
+	events, err := kubeclient.CoreV1().Events(v1.NamespaceDefault).List(context.Background(), v1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if event := events.Items[0]; event.Reason != eventReasonHealthCheckFailed {
+		t.Errorf("event reason = %s, want %s", event.Reason, eventReasonHealthCheckFailed)
+	}
+}
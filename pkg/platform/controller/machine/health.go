@@ -0,0 +1,282 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/runtime"
+
+	platformv1 "tkestack.io/tke/api/platform/v1"
+	clusterprovider "tkestack.io/tke/pkg/platform/provider/cluster"
+	machineprovider "tkestack.io/tke/pkg/platform/provider/machine"
+	typesv1 "tkestack.io/tke/pkg/platform/types/v1"
+	"tkestack.io/tke/pkg/util/log"
+)
+
+// HealthResult is the result of a machine health probe.
+type HealthResult struct {
+	// Healthy is true if the machine passed the health check.
+	Healthy bool
+	// Phase is the machine phase determined by the health check.
+	Phase platformv1.MachinePhase
+	// Reason and Message describe why the health check failed.
+	Reason  string
+	Message string
+	// ProbeTime is the time of the health check.
+	ProbeTime metav1.Time
+}
+
+// CheckMachineHealth probes the health of the named machine the same way the
+// controller does, without updating the machine or the state tracked across
+// health checks.
+func (c *Controller) CheckMachineHealth(ctx context.Context, name string) (HealthResult, error) {
+	machine, err := c.platformClient.Machines().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return HealthResult{}, err
+	}
+	provider, err := machineprovider.GetProvider(machine.Spec.Type)
+	if err != nil {
+		return HealthResult{}, err
+	}
+	cluster, err := c.getCluster(ctx, c.platformClient, machine.Spec.ClusterName, clusterprovider.AdminUsername)
+	if err != nil {
+		return HealthResult{}, err
+	}
+
+	probed, _ := c.probeHealth(ctx, provider, machine.DeepCopy(), cluster, true)
+
+	return newHealthResult(probed), nil
+}
+
+// probeHealth checks the health of the machine unless the circuit breaker of
+// its cluster is open, which leaves the health unknown and returns false.
+// A dry run doesn't advance the state tracked across health checks, nor
+// applies the failed pod policy.
+func (c *Controller) probeHealth(ctx context.Context, provider machineprovider.Provider, machine *platformv1.Machine, cluster *typesv1.Cluster, dryRun bool) (*platformv1.Machine, bool) {
+	if err := c.checkClusterBreaker(ctx, machine, cluster); err != nil {
+		setClusterUnreachable(machine, err)
+		return machine, false
+	}
+	machine = c.checkHealth(ctx, provider, machine, cluster, dryRun)
+	ensureHealthCondition(machine)
+	return machine, true
+}
+
+// newHealthResult builds the result from a machine after health check.
+func newHealthResult(machine *platformv1.Machine) HealthResult {
+	result := HealthResult{
+		Phase: machine.Status.Phase,
+	}
+	condition := machine.GetCondition(machineprovider.ConditionTypeHealthCheck)
+	if condition == nil {
+		result.Healthy = machine.Status.Phase == platformv1.MachineRunning
+		return result
+	}
+	result.Healthy = condition.Status == platformv1.ConditionTrue
+	result.Reason = condition.Reason
+	result.Message = condition.Message
+	result.ProbeTime = condition.LastProbeTime
+
+	return result
+}
+
+// ensureHealthCondition sets the health check condition by machine phase if the
+// provider didn't set it, so that new machines show health status immediately.
+func ensureHealthCondition(machine *platformv1.Machine) {
+	if !(machine.Status.Phase == platformv1.MachineRunning ||
+		machine.Status.Phase == platformv1.MachineFailed) {
+		return
+	}
+	if machine.GetCondition(machineprovider.ConditionTypeHealthCheck) != nil {
+		return
+	}
+
+	condition := platformv1.MachineCondition{
+		Type:   machineprovider.ConditionTypeHealthCheck,
+		Status: platformv1.ConditionTrue,
+	}
+	if machine.Status.Phase == platformv1.MachineFailed {
+		condition.Status = platformv1.ConditionFalse
+		condition.Reason = machineprovider.FailedHealthCheckReason
+		condition.Message = machine.Status.Message
+	}
+	machine.SetCondition(condition)
+}
+
+// setLastHealthError annotates the machine with the message of its failed
+// health check for quick triage, and removes it once the machine is healthy.
+// The annotation is kept while the health is unknown or recovering.
+func setLastHealthError(machine *platformv1.Machine) {
+	condition := machine.GetCondition(machineprovider.ConditionTypeHealthCheck)
+	if condition == nil {
+		return
+	}
+	switch {
+	case condition.Status == platformv1.ConditionTrue:
+		delete(machine.Annotations, platformv1.MachineLastHealthErrorAnno)
+	case condition.Status == platformv1.ConditionFalse && condition.Reason != reasonRecovering && condition.Message != "":
+		if machine.Annotations == nil {
+			machine.Annotations = make(map[string]string)
+		}
+		machine.Annotations[platformv1.MachineLastHealthErrorAnno] = condition.Message
+	}
+}
+
+// checkProviderHealth updates the health of machine by the probe of provider,
+// the machine is left unchanged if the probe fails.
+func checkProviderHealth(ctx context.Context, checker machineprovider.HealthChecker, machine *platformv1.Machine, cluster *typesv1.Cluster) (*platformv1.Machine, error) {
+	if !(machine.Status.Phase == platformv1.MachineRunning ||
+		machine.Status.Phase == platformv1.MachineFailed) {
+		return machine, nil
+	}
+	condition, err := checker.CheckHealth(ctx, machine, cluster)
+	if err != nil {
+		return machine, err
+	}
+
+	condition.Type = machineprovider.ConditionTypeHealthCheck
+	switch condition.Status {
+	case platformv1.ConditionTrue:
+		machine.Status.Phase = platformv1.MachineRunning
+	case platformv1.ConditionFalse:
+		machine.Status.Phase = platformv1.MachineFailed
+		if condition.Reason == "" {
+			condition.Reason = machineprovider.FailedHealthCheckReason
+		}
+	}
+	// SetCondition keeps the previous transition time, so reset it when status flips.
+	if old := machine.GetCondition(machineprovider.ConditionTypeHealthCheck); old != nil && old.Status != condition.Status {
+		condition.LastTransitionTime = metav1.Now()
+	}
+	machine.SetCondition(condition)
+
+	return machine, nil
+}
+
+// semaphore bounds the number of concurrent operations, a nil semaphore
+// doesn't bound at all.
+type semaphore chan struct{}
+
+func newSemaphore(size int) semaphore {
+	if size <= 0 {
+		return nil
+	}
+	return make(semaphore, size)
+}
+
+func (s semaphore) acquire(ctx context.Context) error {
+	if s == nil {
+		return nil
+	}
+	select {
+	case s <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s semaphore) release() {
+	if s != nil {
+		<-s
+	}
+}
+
+// checkHealth runs the health check of the machine within the bound of
+// concurrent health checks, and updates its phase and failed pod policy by
+// the result.
+func (c *Controller) checkHealth(ctx context.Context, provider machineprovider.Provider, machine *platformv1.Machine, cluster *typesv1.Cluster, dryRun bool) *platformv1.Machine {
+	// machines in other phases, e.g. Initializing ones whose node isn't
+	// registered yet, aren't checked at all
+	if !(machine.Status.Phase == platformv1.MachineRunning ||
+		machine.Status.Phase == platformv1.MachineFailed) {
+		return machine
+	}
+	// terminally failed machines stay Failed until they are reset
+	if isTerminallyFailed(machine) {
+		return machine
+	}
+	if err := c.healthChecks.acquire(ctx); err != nil {
+		return machine
+	}
+	defer c.healthChecks.release()
+	atomic.AddInt64(&c.probe.healthChecks, 1)
+	defer atomic.AddInt64(&c.probe.healthChecks, -1)
+
+	oldPhase := machine.Status.Phase
+	var previous *platformv1.MachineCondition
+	if condition := machine.GetCondition(machineprovider.ConditionTypeHealthCheck); condition != nil {
+		copied := *condition
+		previous = &copied
+	}
+	machine = runHealthCheck(ctx, provider, machine, cluster, c.healthCheckOptions)
+	kubeletErr := c.checkKubelet(ctx, machine, cluster)
+	c.updatePhase(oldPhase, previous, machine, kubeletErr, dryRun)
+	if !dryRun {
+		c.applyFailedPodPolicy(ctx, machine, cluster, oldPhase)
+	}
+
+	return machine
+}
+
+// runHealthCheck runs the health check of provider and tracks its lifecycle.
+func runHealthCheck(ctx context.Context, provider machineprovider.Provider, machine *platformv1.Machine, cluster *typesv1.Cluster, options machineprovider.HealthCheckOptions) *platformv1.Machine {
+	healthChecksStarted.Inc()
+	healthChecksRunning.Inc()
+	defer func() {
+		healthChecksRunning.Dec()
+		healthChecksStopped.Inc()
+	}()
+
+	if checker, ok := provider.(machineprovider.HealthChecker); ok {
+		probed, err := checkProviderHealth(ctx, checker, machine, cluster)
+		if err == nil {
+			return probed
+		}
+		log.FromContext(ctx).Error(err, "Provider health check failed, fall back to node health check")
+	}
+	return provider.OnHealthCheck(ctx, machine, cluster, options)
+}
+
+// resumeHealthChecks enqueues all Running and Failed machines once caches are
+// synced, so that their health checks resume after the controller restarts
+// rather than waiting for the machines to change.
+func (c *Controller) resumeHealthChecks() {
+	machines, err := c.lister.List(labels.Everything())
+	if err != nil {
+		runtime.HandleError(fmt.Errorf("failed to list machines to resume health checks: %v", err))
+		return
+	}
+	for _, machine := range machines {
+		if !(machine.Status.Phase == platformv1.MachineRunning ||
+			machine.Status.Phase == platformv1.MachineFailed) {
+			continue
+		}
+		if delay := c.stagger.delay(); delay > 0 {
+			c.enqueueAfter(machine, delay)
+			continue
+		}
+		c.enqueue(machine)
+	}
+}
@@ -0,0 +1,381 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/util/workqueue"
+	platformv1 "tkestack.io/tke/api/platform/v1"
+	machineprovider "tkestack.io/tke/pkg/platform/provider/machine"
+	typesv1 "tkestack.io/tke/pkg/platform/types/v1"
+)
+
+func TestCheckMachineHealth(t *testing.T) {
+	providerName := registerFakeProvider(t, &fakeProvider{
+		onHealthCheck: func(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster) *platformv1.Machine {
+			machine.Status.Phase = platformv1.MachineFailed
+			machine.SetCondition(platformv1.MachineCondition{
+				Type:    machineprovider.ConditionTypeHealthCheck,
+				Status:  platformv1.ConditionFalse,
+				Reason:  machineprovider.FailedHealthCheckReason,
+				Message: "node not found",
+			})
+			return machine
+		},
+	})
+	machine := newMachineForTest("1", nil, platformv1.MachineRunning, nil)
+	machine.Name = "machine"
+	machine.Spec.Type = providerName
+	c := newControllerForTest(machine)
+	c.getCluster = fakeGetCluster
+
+	result, err := c.CheckMachineHealth(context.Background(), machine.Name)
+	if err != nil {
+		t.Fatalf("CheckMachineHealth() error = %v", err)
+	}
+	if got, _ := c.platformClient.Machines().Get(context.Background(), machine.Name, v1.GetOptions{}); got.Status.Phase != platformv1.MachineRunning {
+		t.Errorf("CheckMachineHealth() should not update the machine")
+	}
+
+	if err := c.onUpdate(context.Background(), machine.DeepCopy()); err != nil {
+		t.Fatalf("onUpdate() error = %v", err)
+	}
+	updated, err := c.platformClient.Machines().Get(context.Background(), machine.Name, v1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := newHealthResult(updated)
+
+	if result.Healthy != want.Healthy || result.Phase != want.Phase ||
+		result.Reason != want.Reason || result.Message != want.Message {
+		t.Errorf("CheckMachineHealth() = %+v, controller determined %+v", result, want)
+	}
+	if result.Healthy {
+		t.Errorf("CheckMachineHealth() healthy = true, want false")
+	}
+}
+
+func TestController_CheckMachineHealthRecovering(t *testing.T) {
+	providerName := registerFakeProvider(t, &fakeProvider{
+		onHealthCheck: func(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster) *platformv1.Machine {
+			machine.Status.Phase = platformv1.MachineRunning
+			machine.SetCondition(platformv1.MachineCondition{
+				Type:   machineprovider.ConditionTypeHealthCheck,
+				Status: platformv1.ConditionTrue,
+			})
+			return machine
+		},
+	})
+	machine := newMachineForTest("1", nil, platformv1.MachineFailed, nil)
+	machine.Name = "machine"
+	machine.UID = "uid"
+	machine.Spec.Type = providerName
+	c := newControllerForTest(machine)
+	c.getCluster = fakeGetCluster
+	c.recoveryStreaks = newRecoveryStreaks(3)
+
+	for i := 0; i < 3; i++ {
+		result, err := c.CheckMachineHealth(context.Background(), machine.Name)
+		if err != nil {
+			t.Fatalf("CheckMachineHealth() error = %v", err)
+		}
+		if result.Phase != platformv1.MachineFailed || result.Reason != reasonRecovering {
+			t.Errorf("CheckMachineHealth() = %+v, want failed machine held recovering", result)
+		}
+	}
+	if streak := c.recoveryStreaks.streak(string(machine.UID)); streak != 0 {
+		t.Errorf("recovery streak = %d after health probes, want it left as is", streak)
+	}
+}
+
+func TestController_onUpdateFirstProbe(t *testing.T) {
+	providerName := registerFakeProvider(t, &fakeProvider{})
+	machine := newMachineForTest("1", nil, platformv1.MachineRunning, []platformv1.MachineCondition{})
+	machine.Name = "machine"
+	machine.Spec.Type = providerName
+	c := newControllerForTest(machine)
+	c.getCluster = fakeGetCluster
+
+	if err := c.onUpdate(context.Background(), machine.DeepCopy()); err != nil {
+		t.Fatalf("onUpdate() error = %v", err)
+	}
+	got, err := c.platformClient.Machines().Get(context.Background(), machine.Name, v1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	condition := got.GetCondition(machineprovider.ConditionTypeHealthCheck)
+	if condition == nil {
+		t.Fatal("health check condition is not written on first probe")
+	}
+	if condition.Status != platformv1.ConditionTrue {
+		t.Errorf("health check condition status = %s, want %s", condition.Status, platformv1.ConditionTrue)
+	}
+	if condition.LastProbeTime.IsZero() || condition.LastTransitionTime.IsZero() {
+		t.Errorf("health check condition timestamps are not set: %+v", condition)
+	}
+}
+
+func TestController_checkHealthConcurrency(t *testing.T) {
+	const limit = 3
+	var (
+		mu       sync.Mutex
+		inFlight int
+		maxCalls int
+	)
+	client := kubefake.NewSimpleClientset()
+	client.PrependReactor("get", "nodes", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxCalls {
+			maxCalls = inFlight
+		}
+		mu.Unlock()
+		time.Sleep(10 * time.Millisecond)
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		return false, nil, nil
+	})
+	provider := &fakeProvider{
+		onHealthCheck: func(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster) *platformv1.Machine {
+			_, _ = client.CoreV1().Nodes().Get(ctx, machine.Name, v1.GetOptions{})
+			return machine
+		},
+	}
+	c := &Controller{healthChecks: newSemaphore(limit)}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		machine := newMachineForTest("1", nil, platformv1.MachineRunning, nil)
+		machine.Name = fmt.Sprintf("machine-%d", i)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.checkHealth(context.Background(), provider, machine, &typesv1.Cluster{}, false)
+		}()
+	}
+	wg.Wait()
+
+	if maxCalls > limit {
+		t.Errorf("max concurrent node gets = %d, want at most %d", maxCalls, limit)
+	}
+	if maxCalls == 0 {
+		t.Errorf("health checks are not run")
+	}
+}
+
+func TestController_checkHealthIgnoresInitializing(t *testing.T) {
+	probes := 0
+	provider := &fakeProvider{
+		onHealthCheck: func(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster) *platformv1.Machine {
+			probes++
+			machine.Status.Phase = platformv1.MachineFailed
+			machine.SetCondition(platformv1.MachineCondition{
+				Type:    machineprovider.ConditionTypeHealthCheck,
+				Status:  platformv1.ConditionFalse,
+				Reason:  machineprovider.FailedHealthCheckReason,
+				Message: "node not found",
+			})
+			return machine
+		},
+	}
+	c := &Controller{
+		healthChecks:    newSemaphore(1),
+		recoveryStreaks: newRecoveryStreaks(3),
+		kubeletProber:   newKubeletProber("/healthz"),
+	}
+	machine := newMachineForTest("1", nil, platformv1.MachineInitializing, []platformv1.MachineCondition{})
+
+	got := c.checkHealth(context.Background(), provider, machine.DeepCopy(), &typesv1.Cluster{}, false)
+	if probes != 0 {
+		t.Errorf("initializing machine is probed %d times, want none", probes)
+	}
+	if got.Status.Phase != platformv1.MachineInitializing {
+		t.Errorf("phase = %s, want initializing machine left as is", got.Status.Phase)
+	}
+	if len(got.Status.Conditions) != 0 {
+		t.Errorf("conditions = %+v, want none set on initializing machine", got.Status.Conditions)
+	}
+}
+
+// probingProvider is a fake provider which probes the health of machines itself.
+type probingProvider struct {
+	fakeProvider
+	condition platformv1.MachineCondition
+	err       error
+}
+
+func (p *probingProvider) CheckHealth(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster) (platformv1.MachineCondition, error) {
+	return p.condition, p.err
+}
+
+func TestController_providerHealthCheck(t *testing.T) {
+	nodeChecked := false
+	provider := &probingProvider{
+		fakeProvider: fakeProvider{
+			onHealthCheck: func(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster) *platformv1.Machine {
+				nodeChecked = true
+				return machine
+			},
+		},
+		condition: platformv1.MachineCondition{
+			Status:  platformv1.ConditionFalse,
+			Message: "BMC reports power off",
+		},
+	}
+	providerName := registerFakeProvider(t, provider)
+	machine := newMachineForTest("1", nil, platformv1.MachineRunning, nil)
+	machine.Name = "machine"
+	machine.Spec.Type = providerName
+	c := newControllerForTest(machine)
+	c.getCluster = fakeGetCluster
+
+	if err := c.onUpdate(context.Background(), machine.DeepCopy()); err != nil {
+		t.Fatalf("onUpdate() error = %v", err)
+	}
+	if nodeChecked {
+		t.Errorf("node health check is used while provider implements CheckHealth")
+	}
+	got, err := c.platformClient.Machines().Get(context.Background(), machine.Name, v1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	condition := got.GetCondition(machineprovider.ConditionTypeHealthCheck)
+	if got.Status.Phase != platformv1.MachineFailed || condition.Message != provider.condition.Message {
+		t.Errorf("phase = %s, health condition = %+v, want failed by provider probe", got.Status.Phase, condition)
+	}
+
+	provider.err = fmt.Errorf("BMC is unreachable")
+	if err := c.onUpdate(context.Background(), got); err != nil {
+		t.Fatalf("onUpdate() error = %v", err)
+	}
+	if !nodeChecked {
+		t.Errorf("node health check should be the fallback when provider probe fails")
+	}
+}
+
+func TestController_RunResumesHealthChecks(t *testing.T) {
+	named := func(name string, phase platformv1.MachinePhase) *platformv1.Machine {
+		machine := newMachineForTest("1", nil, phase, nil)
+		machine.Name = name
+		return machine
+	}
+	c := newControllerForTest(
+		named("running", platformv1.MachineRunning),
+		named("failed", platformv1.MachineFailed),
+		named("initializing", platformv1.MachineInitializing),
+	)
+	c.queue = workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	c.listerSynced = func() bool { return true }
+
+	stopCh := make(chan struct{})
+	done := make(chan error)
+	go func() { done <- c.Run(0, stopCh) }()
+
+	err := wait.PollImmediate(10*time.Millisecond, 5*time.Second, func() (bool, error) {
+		return c.queue.Len() == 2, nil
+	})
+	close(stopCh)
+	if err != nil {
+		t.Fatalf("queue length = %d, want 2", c.queue.Len())
+	}
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+
+	queued := map[interface{}]bool{}
+	for c.queue.Len() > 0 {
+		key, _ := c.queue.Get()
+		queued[key] = true
+	}
+	for _, name := range []string{"running", "failed"} {
+		if !queued[name] {
+			t.Errorf("health check of %s machine is not resumed", name)
+		}
+	}
+}
+
+func TestController_onUpdateLastHealthError(t *testing.T) {
+	healthy := false
+	providerName := registerFakeProvider(t, &fakeProvider{
+		onHealthCheck: func(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster) *platformv1.Machine {
+			if healthy {
+				machine.Status.Phase = platformv1.MachineRunning
+				machine.SetCondition(platformv1.MachineCondition{
+					Type:   machineprovider.ConditionTypeHealthCheck,
+					Status: platformv1.ConditionTrue,
+				})
+				return machine
+			}
+			machine.Status.Phase = platformv1.MachineFailed
+			machine.SetCondition(platformv1.MachineCondition{
+				Type:    machineprovider.ConditionTypeHealthCheck,
+				Status:  platformv1.ConditionFalse,
+				Reason:  machineprovider.FailedHealthCheckReason,
+				Message: "node not found",
+			})
+			return machine
+		},
+	})
+	machine := newMachineForTest("1", nil, platformv1.MachineRunning, nil)
+	machine.Name = "machine"
+	machine.Spec.Type = providerName
+	c := newControllerForTest(machine)
+	c.getCluster = fakeGetCluster
+
+	get := func() *platformv1.Machine {
+		got, err := c.platformClient.Machines().Get(context.Background(), machine.Name, v1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return got
+	}
+
+	if err := c.onUpdate(context.Background(), machine.DeepCopy()); err != nil {
+		t.Fatalf("onUpdate() error = %v", err)
+	}
+	got := get()
+	if msg := got.Annotations[platformv1.MachineLastHealthErrorAnno]; msg != "node not found" {
+		t.Errorf("annotation %s = %q, want %q", platformv1.MachineLastHealthErrorAnno, msg, "node not found")
+	}
+
+	healthy = true
+	for i := 0; i < 5 && got.Status.Phase != platformv1.MachineRunning; i++ {
+		if err := c.onUpdate(context.Background(), got.DeepCopy()); err != nil {
+			t.Fatalf("onUpdate() error = %v", err)
+		}
+		got = get()
+	}
+	if got.Status.Phase != platformv1.MachineRunning {
+		t.Fatalf("machine phase = %s, want %s", got.Status.Phase, platformv1.MachineRunning)
+	}
+	if msg, ok := got.Annotations[platformv1.MachineLastHealthErrorAnno]; ok {
+		t.Errorf("annotation %s = %q, want it removed on recovery", platformv1.MachineLastHealthErrorAnno, msg)
+	}
+}
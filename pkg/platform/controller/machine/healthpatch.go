@@ -0,0 +1,98 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"context"
+	"encoding/json"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	platformv1 "tkestack.io/tke/api/platform/v1"
+	machineprovider "tkestack.io/tke/pkg/platform/provider/machine"
+)
+
+// healthFieldManager is the field manager owning the health check condition,
+// and the ready condition derived from it, when they're written by
+// server-side apply.
+const healthFieldManager = "machine-controller-health"
+
+// healthConditionTypes are the conditions written by applyHealthCondition.
+var healthConditionTypes = []string{machineprovider.ConditionTypeHealthCheck, platformv1.MachineConditionReady}
+
+// applyHealthCondition writes the health check condition of new, along with
+// the ready condition, by server-side apply, so that the controller owns just
+// those conditions rather than clobbering concurrent writes of others. It
+// returns old with the conditions updated, to leave them out of the
+// following merge patch.
+func (c *Controller) applyHealthCondition(ctx context.Context, old, new *platformv1.Machine) (*platformv1.Machine, error) {
+	var conditions []platformv1.MachineCondition
+	changed := false
+	for _, conditionType := range healthConditionTypes {
+		condition := new.GetCondition(conditionType)
+		if condition == nil {
+			continue
+		}
+		conditions = append(conditions, *condition)
+		if previous := old.GetCondition(conditionType); previous == nil || !apiequality.Semantic.DeepEqual(*previous, *condition) {
+			changed = true
+		}
+	}
+	if !changed {
+		return old, nil
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"apiVersion": platformv1.SchemeGroupVersion.String(),
+		"kind":       "Machine",
+		"metadata":   map[string]interface{}{"name": new.Name},
+		"status": map[string]interface{}{
+			"conditions": conditions,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	force := true
+	if _, err := c.platformClient.Machines().Patch(ctx, new.Name, types.ApplyPatchType, patch, metav1.PatchOptions{
+		FieldManager: healthFieldManager,
+		Force:        &force,
+	}); err != nil {
+		return nil, err
+	}
+
+	applied := old.DeepCopy()
+	for _, condition := range conditions {
+		replaceCondition(applied, condition)
+	}
+	return applied, nil
+}
+
+// replaceCondition sets the condition as is, unlike SetCondition which keeps
+// the previous transition time.
+func replaceCondition(machine *platformv1.Machine, condition platformv1.MachineCondition) {
+	for i := range machine.Status.Conditions {
+		if machine.Status.Conditions[i].Type == condition.Type {
+			machine.Status.Conditions[i] = condition
+			return
+		}
+	}
+	machine.Status.Conditions = append(machine.Status.Conditions, condition)
+}
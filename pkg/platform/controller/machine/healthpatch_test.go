@@ -0,0 +1,145 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	platformversionedclient "tkestack.io/tke/api/client/clientset/versioned/typed/platform/v1"
+	platformv1 "tkestack.io/tke/api/platform/v1"
+	machineconfig "tkestack.io/tke/pkg/platform/controller/machine/config"
+	machineprovider "tkestack.io/tke/pkg/platform/provider/machine"
+	typesv1 "tkestack.io/tke/pkg/platform/types/v1"
+)
+
+type recordedPatch struct {
+	patchType types.PatchType
+	data      []byte
+	opts      metav1.PatchOptions
+}
+
+// patchRecordingClient records machine patches, apply patches aren't sent
+// as the fake clientset doesn't support them.
+type patchRecordingClient struct {
+	platformversionedclient.PlatformV1Interface
+	patches []recordedPatch
+}
+
+func (c *patchRecordingClient) Machines() platformversionedclient.MachineInterface {
+	return &patchRecordingMachines{MachineInterface: c.PlatformV1Interface.Machines(), client: c}
+}
+
+type patchRecordingMachines struct {
+	platformversionedclient.MachineInterface
+	client *patchRecordingClient
+}
+
+func (m *patchRecordingMachines) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (*platformv1.Machine, error) {
+	m.client.patches = append(m.client.patches, recordedPatch{patchType: pt, data: data, opts: opts})
+	if pt == types.ApplyPatchType {
+		return m.MachineInterface.Get(ctx, name, metav1.GetOptions{})
+	}
+	return m.MachineInterface.Patch(ctx, name, pt, data, opts, subresources...)
+}
+
+func TestController_onUpdateHealthConditionPatch(t *testing.T) {
+	providerName := registerFakeProvider(t, &fakeProvider{
+		onHealthCheck: func(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster) *platformv1.Machine {
+			machine.Status.Phase = platformv1.MachineFailed
+			machine.SetCondition(platformv1.MachineCondition{
+				Type:    machineprovider.ConditionTypeHealthCheck,
+				Status:  platformv1.ConditionFalse,
+				Reason:  machineprovider.FailedHealthCheckReason,
+				Message: "node not ready",
+			})
+			return machine
+		},
+	})
+
+	for _, patch := range []string{machineconfig.HealthConditionPatchMerge, machineconfig.HealthConditionPatchApply} {
+		t.Run(patch, func(t *testing.T) {
+			machine := newMachineForTest("1", nil, platformv1.MachineRunning, nil)
+			machine.Name = "machine"
+			machine.Spec.Type = providerName
+			machine.Status.Conditions = append(machine.Status.Conditions, platformv1.MachineCondition{
+				Type:   "Other",
+				Status: platformv1.ConditionTrue,
+			})
+			c := newControllerForTest(machine)
+			client := &patchRecordingClient{PlatformV1Interface: c.platformClient}
+			c.platformClient = client
+			c.getCluster = fakeGetCluster
+			c.healthConditionPatch = patch
+
+			if err := c.onUpdate(context.Background(), machine); err != nil {
+				t.Fatalf("onUpdate() error = %v", err)
+			}
+
+			var applies, merges []recordedPatch
+			for _, p := range client.patches {
+				if p.patchType == types.ApplyPatchType {
+					applies = append(applies, p)
+				} else {
+					merges = append(merges, p)
+				}
+			}
+			if len(merges) != 1 || !strings.Contains(string(merges[0].data), string(platformv1.MachineFailed)) {
+				t.Fatalf("merge patches = %d, want the phase patched by merge", len(merges))
+			}
+			if patch == machineconfig.HealthConditionPatchMerge {
+				if len(applies) != 0 {
+					t.Errorf("apply patches = %d, want none", len(applies))
+				}
+				if !strings.Contains(string(merges[0].data), "conditions") {
+					t.Errorf("merge patch %s, want the health condition", merges[0].data)
+				}
+				return
+			}
+
+			if len(applies) != 1 {
+				t.Fatalf("apply patches = %d, want 1", len(applies))
+			}
+			apply := applies[0]
+			if apply.opts.FieldManager != healthFieldManager || apply.opts.Force == nil || !*apply.opts.Force {
+				t.Errorf("apply options = %+v, want forced by %s", apply.opts, healthFieldManager)
+			}
+			var applied platformv1.Machine
+			if err := json.Unmarshal(apply.data, &applied); err != nil {
+				t.Fatal(err)
+			}
+			conditions := applied.Status.Conditions
+			if len(conditions) != 2 || conditions[0].Type != machineprovider.ConditionTypeHealthCheck || conditions[0].Status != platformv1.ConditionFalse ||
+				conditions[1].Type != platformv1.MachineConditionReady || conditions[1].Status != platformv1.ConditionFalse {
+				t.Errorf("applied conditions = %+v, want just the health and ready conditions", conditions)
+			}
+			if applied.Status.Phase != "" || applied.Spec.IP != "" {
+				t.Errorf("apply patch %s, want no fields but the health condition", apply.data)
+			}
+			if strings.Contains(string(merges[0].data), "conditions") {
+				t.Errorf("merge patch %s, want no conditions", merges[0].data)
+			}
+		})
+	}
+}
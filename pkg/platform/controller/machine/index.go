@@ -0,0 +1,67 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"k8s.io/client-go/tools/cache"
+
+	platformv1 "tkestack.io/tke/api/platform/v1"
+	machineprovider "tkestack.io/tke/pkg/platform/provider/machine"
+)
+
+// healthIndex is the name of the machine informer index keyed by the status
+// of machine health check condition.
+const healthIndex = "health"
+
+// healthIndexers returns the indexers of machines by health.
+func healthIndexers() cache.Indexers {
+	return cache.Indexers{healthIndex: healthIndexFunc}
+}
+
+// healthIndexFunc indexes the machine by its health check condition status,
+// machines which haven't been checked are not indexed.
+func healthIndexFunc(obj interface{}) ([]string, error) {
+	machine, err := asMachine(obj)
+	if err != nil {
+		return nil, err
+	}
+	condition := machine.GetCondition(machineprovider.ConditionTypeHealthCheck)
+	if condition == nil {
+		return nil, nil
+	}
+	return []string{string(condition.Status)}, nil
+}
+
+// MachinesByHealth lists the machines in cache whose health check condition
+// is of the status, e.g. ConditionFalse for unhealthy machines.
+func (c *Controller) MachinesByHealth(status platformv1.ConditionStatus) ([]*platformv1.Machine, error) {
+	objs, err := c.indexer.ByIndex(healthIndex, string(status))
+	if err != nil {
+		return nil, err
+	}
+	machines := make([]*platformv1.Machine, 0, len(objs))
+	for _, obj := range objs {
+		machine, err := asMachine(obj)
+		if err != nil {
+			return nil, err
+		}
+		machines = append(machines, machine)
+	}
+	return machines, nil
+}
@@ -0,0 +1,86 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"k8s.io/client-go/tools/cache"
+	platformv1 "tkestack.io/tke/api/platform/v1"
+	machineprovider "tkestack.io/tke/pkg/platform/provider/machine"
+)
+
+func TestController_MachinesByHealth(t *testing.T) {
+	newMachine := func(name string, status platformv1.ConditionStatus) *platformv1.Machine {
+		machine := newMachineForTest("1", nil, platformv1.MachineRunning, []platformv1.MachineCondition{
+			{Type: machineprovider.ConditionTypeHealthCheck, Status: status},
+		})
+		machine.Name = name
+		return machine
+	}
+	unchecked := newMachineForTest("1", nil, platformv1.MachineInitializing, []platformv1.MachineCondition{})
+	unchecked.Name = "unchecked"
+	c := &Controller{indexer: cache.NewIndexer(cache.MetaNamespaceKeyFunc, healthIndexers())}
+	for _, machine := range []*platformv1.Machine{
+		newMachine("a", platformv1.ConditionTrue),
+		newMachine("b", platformv1.ConditionTrue),
+		newMachine("c", platformv1.ConditionFalse),
+		unchecked,
+	} {
+		if err := c.indexer.Add(machine); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	names := func(status platformv1.ConditionStatus) []string {
+		t.Helper()
+		machines, err := c.MachinesByHealth(status)
+		if err != nil {
+			t.Fatalf("MachinesByHealth(%s) error = %v", status, err)
+		}
+		var names []string
+		for _, machine := range machines {
+			names = append(names, machine.Name)
+		}
+		sort.Strings(names)
+		return names
+	}
+	assert := func(status platformv1.ConditionStatus, want ...string) {
+		t.Helper()
+		if got := names(status); !reflect.DeepEqual(got, want) {
+			t.Errorf("MachinesByHealth(%s) = %v, want %v", status, got, want)
+		}
+	}
+
+	assert(platformv1.ConditionTrue, "a", "b")
+	assert(platformv1.ConditionFalse, "c")
+
+	// b fails and c recovers.
+	if err := c.indexer.Update(newMachine("b", platformv1.ConditionFalse)); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.indexer.Update(newMachine("c", platformv1.ConditionTrue)); err != nil {
+		t.Fatal(err)
+	}
+	assert(platformv1.ConditionTrue, "a", "c")
+	assert(platformv1.ConditionFalse, "b")
+	assert(platformv1.ConditionUnknown)
+}
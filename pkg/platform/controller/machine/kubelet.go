@@ -0,0 +1,137 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	platformv1 "tkestack.io/tke/api/platform/v1"
+	machineprovider "tkestack.io/tke/pkg/platform/provider/machine"
+	typesv1 "tkestack.io/tke/pkg/platform/types/v1"
+)
+
+const (
+	conditionTypeKubeletHealthy = "KubeletHealthy"
+	reasonKubeletUnhealthy      = "KubeletUnhealthy"
+
+	// kubeletPort is the port of kubelet server.
+	kubeletPort = 10250
+	// kubeletProbeTimeout is the timeout to probe the kubelet healthz.
+	kubeletProbeTimeout = 5 * time.Second
+)
+
+// kubeletProber probes the healthz of kubelet on the machine directly, so
+// that a live kubelet is detected even if it isn't registered as a node.
+type kubeletProber struct {
+	client *http.Client
+	path   string
+}
+
+type proxyKey struct{}
+
+// proxyFromContext returns the proxy of the cluster which the request is
+// made to, nil for a direct connection.
+func proxyFromContext(req *http.Request) (*url.URL, error) {
+	proxy, _ := req.Context().Value(proxyKey{}).(*url.URL)
+	return proxy, nil
+}
+
+// newKubeletProber returns nil if the path is empty, which doesn't probe.
+// The serving certificate of kubelet is usually self signed, so it isn't
+// verified. The kubelet is probed through the proxy of cluster if any.
+func newKubeletProber(path string) *kubeletProber {
+	if path == "" {
+		return nil
+	}
+	return &kubeletProber{
+		client: &http.Client{
+			Timeout: kubeletProbeTimeout,
+			Transport: &http.Transport{
+				Proxy:           proxyFromContext,
+				TLSClientConfig: &tls.Config{InsecureSkipVerify: true}, // #nosec
+			},
+		},
+		path: path,
+	}
+}
+
+// probe returns an error if the kubelet healthz of the machine isn't ok.
+func (p *kubeletProber) probe(ctx context.Context, machine *platformv1.Machine) error {
+	url := fmt.Sprintf("https://%s%s", net.JoinHostPort(machine.Spec.IP, strconv.Itoa(kubeletPort)), p.path)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("kubelet healthz returned %s", resp.Status)
+	}
+	return nil
+}
+
+// checkKubelet records the kubelet health of machine in a separate condition,
+// and returns the error of probe, nil if the kubelet isn't probed. A healthy
+// machine fails by its unhealthy kubelet by nextPhase.
+func (c *Controller) checkKubelet(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster) error {
+	if c.kubeletProber == nil {
+		return nil
+	}
+	if !(machine.Status.Phase == platformv1.MachineRunning ||
+		machine.Status.Phase == platformv1.MachineFailed) {
+		return nil
+	}
+
+	proxy, err := cluster.ProxyURL()
+	if err == nil {
+		err = c.kubeletProber.probe(context.WithValue(ctx, proxyKey{}, proxy), machine)
+	}
+	kubelet := platformv1.MachineCondition{
+		Type:   conditionTypeKubeletHealthy,
+		Status: platformv1.ConditionTrue,
+	}
+	if err != nil {
+		kubelet.Status = platformv1.ConditionFalse
+		kubelet.Reason = reasonKubeletUnhealthy
+		kubelet.Message = err.Error()
+	}
+	if old := machine.GetCondition(conditionTypeKubeletHealthy); old != nil && old.Status != kubelet.Status {
+		kubelet.LastTransitionTime = metav1.Now()
+	}
+	health := machine.GetCondition(machineprovider.ConditionTypeHealthCheck)
+	machine.SetCondition(kubelet)
+	// SetCondition overwrites the status reason, so health is set last
+	if health != nil {
+		machine.SetCondition(*health)
+	}
+
+	return err
+}
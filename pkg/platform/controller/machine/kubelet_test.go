@@ -0,0 +1,89 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	platformv1 "tkestack.io/tke/api/platform/v1"
+	machineprovider "tkestack.io/tke/pkg/platform/provider/machine"
+	typesv1 "tkestack.io/tke/pkg/platform/types/v1"
+)
+
+// kubeletTransport responds the kubelet healthz with status code.
+type kubeletTransport struct {
+	code int
+	urls []string
+}
+
+func (t *kubeletTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.urls = append(t.urls, req.URL.String())
+	return &http.Response{
+		StatusCode: t.code,
+		Status:     http.StatusText(t.code),
+		Body:       io.NopCloser(strings.NewReader("")),
+		Request:    req,
+	}, nil
+}
+
+func TestController_checkHealthKubelet(t *testing.T) {
+	tests := []struct {
+		name        string
+		code        int
+		wantKubelet platformv1.ConditionStatus
+		wantPhase   platformv1.MachinePhase
+	}{
+		{name: "healthy kubelet", code: http.StatusOK, wantKubelet: platformv1.ConditionTrue, wantPhase: platformv1.MachineRunning},
+		{name: "unhealthy kubelet", code: http.StatusInternalServerError, wantKubelet: platformv1.ConditionFalse, wantPhase: platformv1.MachineFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := &kubeletTransport{code: tt.code}
+			prober := newKubeletProber("/healthz")
+			prober.client.Transport = transport
+			c := &Controller{kubeletProber: prober}
+			machine := newMachineForTest("1", nil, platformv1.MachineRunning, nil)
+			machine.Spec.IP = "10.0.0.1"
+
+			provider := &fakeProvider{
+				onHealthCheck: func(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster) *platformv1.Machine {
+					return machine
+				},
+			}
+			machine = c.checkHealth(context.Background(), provider, machine, &typesv1.Cluster{}, false)
+			if want := []string{"https://10.0.0.1:10250/healthz"}; len(transport.urls) != 1 || transport.urls[0] != want[0] {
+				t.Errorf("probed urls = %v, want %v", transport.urls, want)
+			}
+			if condition := machine.GetCondition(conditionTypeKubeletHealthy); condition == nil || condition.Status != tt.wantKubelet {
+				t.Errorf("kubelet condition = %+v, want %s", condition, tt.wantKubelet)
+			}
+			if machine.Status.Phase != tt.wantPhase {
+				t.Errorf("phase = %s, want %s", machine.Status.Phase, tt.wantPhase)
+			}
+			health := machine.GetCondition(machineprovider.ConditionTypeHealthCheck)
+			if got := health.Status == platformv1.ConditionTrue; got != (tt.wantPhase == platformv1.MachineRunning) {
+				t.Errorf("health condition = %+v, want healthy %v", health, tt.wantPhase == platformv1.MachineRunning)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	platformv1 "tkestack.io/tke/api/platform/v1"
+	machineprovider "tkestack.io/tke/pkg/platform/provider/machine"
+	typesv1 "tkestack.io/tke/pkg/platform/types/v1"
+	"tkestack.io/tke/pkg/util/log"
+)
+
+const conditionTypeKubeletRestarting = "KubeletRestarting"
+
+// errWaitingForKubeletRestart is returned by onUpdate until the node of
+// machine is ready again after kubelet was restarted, the machine is
+// requeued with backoff.
+var errWaitingForKubeletRestart = errors.New("machine is waiting for its node to be ready after kubelet restart")
+
+// kubeletConfigHash returns the hash of the spec labels of machine which its
+// provider declares as rendered into the kubelet configuration, and false if
+// the provider declares none.
+func kubeletConfigHash(machine *platformv1.Machine) (string, bool) {
+	metadata, err := machineprovider.GetProviderMetadata(machine.Spec.Type)
+	if err != nil || len(metadata.KubeletConfigLabels) == 0 {
+		return "", false
+	}
+	labels := make(map[string]string, len(metadata.KubeletConfigLabels))
+	for _, key := range metadata.KubeletConfigLabels {
+		if value, ok := machine.Spec.Labels[key]; ok {
+			labels[key] = value
+		}
+	}
+	data, _ := json.Marshal(labels)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8]), true
+}
+
+// recordKubeletConfig records the kubelet configuration of machine as applied,
+// nothing is recorded if no spec field maps to the kubelet configuration.
+func recordKubeletConfig(machine *platformv1.Machine) {
+	hash, ok := kubeletConfigHash(machine)
+	if !ok {
+		return
+	}
+	if machine.Annotations == nil {
+		machine.Annotations = make(map[string]string)
+	}
+	machine.Annotations[platformv1.MachineKubeletConfigHashAnno] = hash
+}
+
+// kubeletRestartRequired returns true if the kubelet configuration of machine
+// changed since it was last applied. Machines provisioned before the
+// configuration was recorded get it recorded as is.
+func kubeletRestartRequired(machine *platformv1.Machine) bool {
+	hash, ok := kubeletConfigHash(machine)
+	if !ok {
+		return false
+	}
+	applied, recorded := machine.Annotations[platformv1.MachineKubeletConfigHashAnno]
+	if !recorded {
+		recordKubeletConfig(machine)
+		return false
+	}
+	return applied != hash
+}
+
+// kubeletRestarted records the kubelet configuration of machine as applied
+// and marks it by a condition until its node is verified ready.
+func kubeletRestarted(ctx context.Context, machine *platformv1.Machine) {
+	log.FromContext(ctx).Info("Kubelet has been restarted for the changed configuration")
+	recordKubeletConfig(machine)
+	machine.SetCondition(platformv1.MachineCondition{
+		Type:    conditionTypeKubeletRestarting,
+		Status:  platformv1.ConditionTrue,
+		Reason:  reasonNodeNotReady,
+		Message: "kubelet is restarted, waiting for the node to be ready",
+	})
+}
+
+// checkKubeletRestart verifies the node of machine is ready after kubelet was
+// restarted, it returns errWaitingForKubeletRestart until then.
+func (c *Controller) checkKubeletRestart(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster) error {
+	if machine.GetCondition(conditionTypeKubeletRestarting) == nil {
+		return nil
+	}
+	client, err := c.clientsetFor(cluster)
+	if err != nil {
+		return err
+	}
+	node, err := getNode(ctx, client, machine)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	if err == nil && isNodeReady(node) {
+		log.FromContext(ctx).Info("Node is ready after kubelet restart")
+		removeCondition(machine, conditionTypeKubeletRestarting)
+		return nil
+	}
+	return fmt.Errorf("%w: node of machine %s is not ready", errWaitingForKubeletRestart, machine.Spec.IP)
+}
@@ -0,0 +1,130 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+
+	platformv1 "tkestack.io/tke/api/platform/v1"
+	machineprovider "tkestack.io/tke/pkg/platform/provider/machine"
+	typesv1 "tkestack.io/tke/pkg/platform/types/v1"
+)
+
+func TestController_onUpdateKubeletRestart(t *testing.T) {
+	restarts := 0
+	providerName := "Fake-" + t.Name()
+	machineprovider.RegisterWithMetadata(providerName, &fakeProvider{
+		onUpdate: func(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster, options machineprovider.UpdateOptions) error {
+			if options.KubeletRestart {
+				restarts++
+			}
+			return nil
+		},
+		onHealthCheck: func(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster) *platformv1.Machine {
+			return machine
+		},
+	}, machineprovider.ProviderMetadata{KubeletConfigLabels: []string{"role"}})
+	machine := newMachineForTest("1", nil, platformv1.MachineRunning, nil)
+	machine.Name = "machine"
+	machine.Spec.Type = providerName
+	node := &corev1.Node{
+		ObjectMeta: v1.ObjectMeta{Name: machine.Spec.IP},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}},
+		},
+	}
+	client := kubefake.NewSimpleClientset(node)
+	c := newControllerForTest(machine)
+	c.getCluster = fakeGetCluster
+	c.clientsetFor = func(cluster *typesv1.Cluster) (kubernetes.Interface, error) {
+		return client, nil
+	}
+	ctx := context.Background()
+	latest := func() *platformv1.Machine {
+		got, err := c.platformClient.Machines().Get(ctx, machine.Name, v1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return got
+	}
+	setNodeReady := func(status corev1.ConditionStatus) {
+		node.Status.Conditions[0].Status = status
+		if _, err := client.CoreV1().Nodes().UpdateStatus(ctx, node, v1.UpdateOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := c.onUpdate(ctx, latest()); err != nil || restarts != 0 {
+		t.Fatalf("onUpdate() error = %v, restarts = %d, want no restart without spec change", err, restarts)
+	}
+
+	// labels and taints applied through the API don't restart kubelet
+	changed := latest()
+	changed.Spec.Labels = map[string]string{"zone": "a"}
+	changed.Spec.Taints = []corev1.Taint{{Key: "dedicated", Effect: corev1.TaintEffectNoSchedule}}
+	if _, err := c.platformClient.Machines().Update(ctx, changed, v1.UpdateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.onUpdate(ctx, latest()); err != nil || restarts != 0 {
+		t.Fatalf("onUpdate() error = %v, restarts = %d, want no restart for undeclared fields", err, restarts)
+	}
+
+	changed = latest()
+	changed.Spec.Labels["role"] = "gpu"
+	if _, err := c.platformClient.Machines().Update(ctx, changed, v1.UpdateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	// the restarted kubelet hasn't reported ready yet
+	setNodeReady(corev1.ConditionFalse)
+	err := c.onUpdate(ctx, latest())
+	if !errors.Is(err, errWaitingForKubeletRestart) {
+		t.Fatalf("onUpdate() error = %v, want %v", err, errWaitingForKubeletRestart)
+	}
+	if restarts != 1 {
+		t.Errorf("restarts = %d after labels changed, want 1", restarts)
+	}
+	if condition := latest().GetCondition(conditionTypeKubeletRestarting); condition == nil {
+		t.Errorf("machine has no %s condition while node is not ready", conditionTypeKubeletRestarting)
+	}
+
+	if err := c.onUpdate(ctx, latest()); !errors.Is(err, errWaitingForKubeletRestart) {
+		t.Fatalf("onUpdate() error = %v, want readiness re-checked", err)
+	}
+	if restarts != 1 {
+		t.Errorf("restarts = %d while waiting for node, want no more restart", restarts)
+	}
+
+	setNodeReady(corev1.ConditionTrue)
+	if err := c.onUpdate(ctx, latest()); err != nil {
+		t.Fatalf("onUpdate() error = %v once node is ready", err)
+	}
+	if condition := latest().GetCondition(conditionTypeKubeletRestarting); condition != nil {
+		t.Errorf("%s condition = %+v once node is ready, want removed", conditionTypeKubeletRestarting, condition)
+	}
+	if restarts != 1 {
+		t.Errorf("restarts = %d, want 1", restarts)
+	}
+}
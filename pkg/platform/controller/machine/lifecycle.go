@@ -0,0 +1,122 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	platformv1 "tkestack.io/tke/api/platform/v1"
+	"tkestack.io/tke/pkg/platform/controller/machine/deletion"
+	machineprovider "tkestack.io/tke/pkg/platform/provider/machine"
+)
+
+// Types of lifecycle records.
+const (
+	LifecycleRecordPhaseTransition  = "PhaseTransition"
+	LifecycleRecordHealthTransition = "HealthTransition"
+	LifecycleRecordDeletion         = "Deletion"
+)
+
+// LifecycleRecord is a structured audit record of a step in the lifecycle of
+// machine, for shipping to an external audit system.
+type LifecycleRecord struct {
+	Timestamp metav1.Time `json:"timestamp"`
+	Type      string      `json:"type"`
+	Machine   string      `json:"machine"`
+	Cluster   string      `json:"cluster"`
+	// From and To are the phases of phase transitions, or the statuses of
+	// the health check condition of health transitions.
+	From    string `json:"from,omitempty"`
+	To      string `json:"to,omitempty"`
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+	// Deletion is the result of the deletion step of Deletion records.
+	Deletion *deletion.DeletionResult `json:"deletion,omitempty"`
+}
+
+// SetLifecycleStream sets the channel every phase transition, health flip
+// and deletion step of machines is sent to as a LifecycleRecord. It must be
+// called before Run. Sending never blocks the controller, records are dropped
+// if the channel is full, so the consumer should drain it promptly.
+func (c *Controller) SetLifecycleStream(stream chan<- LifecycleRecord) {
+	c.lifecycleStream = stream
+}
+
+func (c *Controller) emitLifecycle(record LifecycleRecord) {
+	if c.lifecycleStream == nil {
+		return
+	}
+	record.Timestamp = metav1.Now()
+	select {
+	case c.lifecycleStream <- record:
+	default:
+		lifecycleRecordsDropped.Inc()
+	}
+}
+
+// recordLifecycle emits the phase transition and health flip of machine.
+func (c *Controller) recordLifecycle(machine *platformv1.Machine, oldPhase platformv1.MachinePhase, oldHealth platformv1.ConditionStatus) {
+	if machine.Status.Phase != oldPhase {
+		c.emitLifecycle(LifecycleRecord{
+			Type:    LifecycleRecordPhaseTransition,
+			Machine: machine.Name,
+			Cluster: machine.Spec.ClusterName,
+			From:    string(oldPhase),
+			To:      string(machine.Status.Phase),
+			Reason:  machine.Status.Reason,
+			Message: machine.Status.Message,
+		})
+	}
+	condition := machine.GetCondition(machineprovider.ConditionTypeHealthCheck)
+	if condition == nil || condition.Status == oldHealth {
+		return
+	}
+	c.emitLifecycle(LifecycleRecord{
+		Type:    LifecycleRecordHealthTransition,
+		Machine: machine.Name,
+		Cluster: machine.Spec.ClusterName,
+		From:    string(oldHealth),
+		To:      string(condition.Status),
+		Reason:  condition.Reason,
+		Message: condition.Message,
+	})
+}
+
+// healthStatus returns the status of the health check condition if any.
+func healthStatus(machine *platformv1.Machine) platformv1.ConditionStatus {
+	if condition := machine.GetCondition(machineprovider.ConditionTypeHealthCheck); condition != nil {
+		return condition.Status
+	}
+	return ""
+}
+
+// recordDeletion emits the deletion step of machine.
+func (c *Controller) recordDeletion(machine *platformv1.Machine, result deletion.DeletionResult, err error) {
+	record := LifecycleRecord{
+		Type:     LifecycleRecordDeletion,
+		Machine:  machine.Name,
+		Cluster:  machine.Spec.ClusterName,
+		From:     string(machine.Status.Phase),
+		Deletion: &result,
+	}
+	if err != nil {
+		record.Message = err.Error()
+	}
+	c.emitLifecycle(record)
+}
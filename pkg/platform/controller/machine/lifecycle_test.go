@@ -0,0 +1,119 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"context"
+	"testing"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	platformv1 "tkestack.io/tke/api/platform/v1"
+	machineprovider "tkestack.io/tke/pkg/platform/provider/machine"
+	typesv1 "tkestack.io/tke/pkg/platform/types/v1"
+)
+
+func TestController_LifecycleStream(t *testing.T) {
+	install := func(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster) error {
+		return nil
+	}
+	providerName := registerFakeProvider(t, &fakeProvider{
+		DelegateProvider: machineprovider.DelegateProvider{
+			CreateHandlers: []machineprovider.Handler{install},
+		},
+		onHealthCheck: func(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster) *platformv1.Machine {
+			machine.Status.Phase = platformv1.MachineFailed
+			machine.SetCondition(platformv1.MachineCondition{
+				Type:    machineprovider.ConditionTypeHealthCheck,
+				Status:  platformv1.ConditionFalse,
+				Reason:  machineprovider.FailedHealthCheckReason,
+				Message: "node not ready",
+			})
+			return machine
+		},
+	})
+	machine := newMachineForTest("1", nil, platformv1.MachineInitializing, []platformv1.MachineCondition{})
+	machine.Name = "machine"
+	machine.Spec.Type = providerName
+	c := newControllerForTest(machine)
+	c.getCluster = fakeGetCluster
+	c.createSteps = newProcessedVersions()
+	c.deleter = &recordingDeleter{}
+	stream := make(chan LifecycleRecord, 10)
+	c.SetLifecycleStream(stream)
+
+	ctx := context.Background()
+	latest := func() *platformv1.Machine {
+		got, err := c.platformClient.Machines().Get(ctx, machine.Name, v1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return got
+	}
+	if err := c.reconcile(ctx, machine.Name, latest()); err != nil {
+		t.Fatalf("reconcile() of initializing machine error = %v", err)
+	}
+	if err := c.reconcile(ctx, machine.Name, latest()); err != nil {
+		t.Fatalf("reconcile() of running machine error = %v", err)
+	}
+	deleting := latest()
+	now := v1.Now()
+	deleting.DeletionTimestamp = &now
+	if err := c.reconcile(ctx, machine.Name, deleting); err != nil {
+		t.Fatalf("reconcile() of deleting machine error = %v", err)
+	}
+	close(stream)
+
+	type step struct{ typ, from, to string }
+	want := []step{
+		{LifecycleRecordPhaseTransition, string(platformv1.MachineInitializing), string(platformv1.MachineRunning)},
+		{LifecycleRecordPhaseTransition, string(platformv1.MachineRunning), string(platformv1.MachineFailed)},
+		// the machine just created has no health condition yet
+		{LifecycleRecordHealthTransition, "", string(platformv1.ConditionFalse)},
+		{LifecycleRecordDeletion, string(platformv1.MachineFailed), ""},
+	}
+	var got []LifecycleRecord
+	for record := range stream {
+		got = append(got, record)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d records %+v, want %d", len(got), got, len(want))
+	}
+	for i, record := range got {
+		if (step{record.Type, record.From, record.To}) != want[i] {
+			t.Errorf("record %d = %+v, want %+v", i, record, want[i])
+		}
+		if record.Machine != machine.Name || record.Cluster != machine.Spec.ClusterName || record.Timestamp.IsZero() {
+			t.Errorf("record %d = %+v, want machine, cluster and timestamp set", i, record)
+		}
+	}
+	if got[3].Deletion == nil {
+		t.Errorf("deletion record has no deletion result")
+	}
+}
+
+func TestController_emitLifecycleNonBlocking(t *testing.T) {
+	c := &Controller{}
+	// no stream, nothing is emitted
+	c.emitLifecycle(LifecycleRecord{Type: LifecycleRecordDeletion})
+
+	c.SetLifecycleStream(make(chan LifecycleRecord))
+	// nobody receives, the record is dropped rather than blocking
+	c.emitLifecycle(LifecycleRecord{Type: LifecycleRecordDeletion})
+}
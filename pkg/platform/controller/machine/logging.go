@@ -0,0 +1,105 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"tkestack.io/tke/pkg/util/log"
+)
+
+// repeatedErrorLogInterval is the interval to summarize a repeated error.
+const repeatedErrorLogInterval = 10 * time.Minute
+
+// errorDeduplicator suppresses repeated identical errors per machine, so
+// that a persistent failure, e.g. a cluster outage, doesn't flood the logs.
+// The first occurrence is logged, then the repeats are summarized once per
+// interval.
+type errorDeduplicator struct {
+	mu       sync.Mutex
+	interval time.Duration
+	now      func() time.Time
+	entries  map[string]*errorEntry
+}
+
+type errorEntry struct {
+	message    string
+	loggedTime time.Time
+	suppressed int
+}
+
+func newErrorDeduplicator(interval time.Duration) *errorDeduplicator {
+	return &errorDeduplicator{
+		interval: interval,
+		now:      time.Now,
+		entries:  make(map[string]*errorEntry),
+	}
+}
+
+// allow returns true if the error message of key should be logged, along
+// with the number of repeats suppressed since it was logged last time.
+func (d *errorDeduplicator) allow(key, message string) (bool, int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := d.now()
+	entry, ok := d.entries[key]
+	if !ok || entry.message != message {
+		d.entries[key] = &errorEntry{message: message, loggedTime: now}
+		return true, 0
+	}
+	if now.Sub(entry.loggedTime) < d.interval {
+		entry.suppressed++
+		return false, 0
+	}
+	suppressed := entry.suppressed
+	entry.loggedTime = now
+	entry.suppressed = 0
+	return true, suppressed
+}
+
+// reset forgets the error of key, e.g. after the machine recovers.
+func (d *errorDeduplicator) reset(key string) {
+	if d == nil {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.entries, key)
+}
+
+// logHealthError logs the health check failure of the machine of uid,
+// identical failures are logged at a reduced rate.
+func (c *Controller) logHealthError(ctx context.Context, uid, message string) {
+	if c.healthErrors == nil {
+		return
+	}
+	ok, suppressed := c.healthErrors.allow(uid, message)
+	if !ok {
+		return
+	}
+	if suppressed > 0 {
+		log.FromContext(ctx).Error(errors.New(message), "Machine health check is still failing", "repeated", suppressed)
+		return
+	}
+	log.FromContext(ctx).Error(errors.New(message), "Machine health check failed")
+}
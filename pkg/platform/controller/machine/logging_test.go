@@ -0,0 +1,59 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"testing"
+	"time"
+)
+
+func TestErrorDeduplicator(t *testing.T) {
+	now := time.Now()
+	d := newErrorDeduplicator(time.Minute)
+	d.now = func() time.Time { return now }
+
+	logged := 0
+	for i := 0; i < 100; i++ {
+		if ok, _ := d.allow("machine", "cluster is unreachable"); ok {
+			logged++
+		}
+		now = now.Add(time.Second)
+	}
+	// the first occurrence and one summary per minute
+	if logged != 2 {
+		t.Errorf("logged %d times of 100 identical errors, want 2", logged)
+	}
+
+	now = now.Add(time.Minute)
+	ok, suppressed := d.allow("machine", "cluster is unreachable")
+	if !ok || suppressed == 0 {
+		t.Errorf("allow() = %v, %d, want a summary of suppressed errors", ok, suppressed)
+	}
+
+	if ok, _ := d.allow("machine", "node not found"); !ok {
+		t.Errorf("a different error should be logged immediately")
+	}
+	if ok, _ := d.allow("other", "cluster is unreachable"); !ok {
+		t.Errorf("errors of other machines should be logged")
+	}
+	d.reset("other")
+	if ok, _ := d.allow("other", "cluster is unreachable"); !ok {
+		t.Errorf("error should be logged again after reset")
+	}
+}
@@ -20,17 +20,27 @@ package machine
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"math/rand"
 	"reflect"
+	"sync"
+	"sync/atomic"
 	"time"
+	"unicode/utf8"
 
 	"golang.org/x/time/rate"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
-	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 
 	platformversionedclient "tkestack.io/tke/api/client/clientset/versioned/typed/platform/v1"
@@ -41,41 +51,242 @@ import (
 	"tkestack.io/tke/pkg/platform/controller/machine/deletion"
 	clusterprovider "tkestack.io/tke/pkg/platform/provider/cluster"
 	machineprovider "tkestack.io/tke/pkg/platform/provider/machine"
+	typesv1 "tkestack.io/tke/pkg/platform/types/v1"
 	"tkestack.io/tke/pkg/util/log"
 	"tkestack.io/tke/pkg/util/metrics"
+	"tkestack.io/tke/pkg/util/strategicpatch"
 )
 
 const (
 	resyncInternal = 1 * time.Minute
+
+	conditionTypeProviderTimeout = "ProviderTimeout"
+	reasonProviderTimeout        = "ProviderTimeout"
+
+	conditionTypeClusterName  = "ClusterName"
+	reasonClusterNameMissing  = "ClusterNameMissing"
+	messageClusterNameMissing = "spec.clusterName is required"
+
+	conditionTypePaused = "Paused"
+	// pausePollPeriod is the period to requeue paused machines, besides
+	// the update of machine on unpause.
+	pausePollPeriod = time.Minute
+
+	conditionTypeWaitingForCluster         = "WaitingForCluster"
+	conditionTypeDeferredForClusterUpgrade = "DeferredForClusterUpgrade"
+	// clusterWaitPeriod is the period to requeue machines of a provisioning
+	// or upgrading cluster.
+	clusterWaitPeriod = 30 * time.Second
+
+	// maxInstallLogSize is the max size of install log stored in the machine.
+	maxInstallLogSize = 4096
+
+	// syncingRetryPeriod is the period to requeue a machine which is being
+	// synced by the workers of the other queue.
+	syncingRetryPeriod = time.Second
+	// providerCallRetryPeriod is the period to requeue a machine whose
+	// provider call is still running after it timed out.
+	providerCallRetryPeriod = 10 * time.Second
 )
 
+// errRequeue is returned when the machine should be processed again
+// immediately, e.g. for the next step of initialization.
+var errRequeue = errors.New("requeue machine")
+
+// errWaitingForCluster is returned when the cluster of the machine is still
+// provisioning or upgrading, the machine is requeued after clusterWaitPeriod.
+var errWaitingForCluster = errors.New("waiting for cluster")
+
+// errPaused is returned when the provisioning of machine is paused, the
+// machine is requeued after pausePollPeriod.
+var errPaused = errors.New("machine is paused")
+
+// errProviderTimeout is returned when a provider operation doesn't finish in time.
+var errProviderTimeout = errors.New("machine provider operation timed out")
+
+// errProviderCallRunning is returned when the provider call of a machine which
+// timed out is still running, the machine is requeued after
+// providerCallRetryPeriod.
+var errProviderCallRunning = errors.New("machine provider operation which timed out is still running")
+
 // Controller is responsible for performing actions dependent upon a machine phase.
 type Controller struct {
-	queue        workqueue.RateLimitingInterface
+	queue workqueue.RateLimitingInterface
+	// dedicatedQueue holds machines matching dedicatedSelector, which are
+	// processed by dedicated workers separately from queue.
+	dedicatedQueue    workqueue.RateLimitingInterface
+	dedicatedSelector labels.Selector
+	dedicatedWorkers  int
+	// syncing holds the keys being synced across both queues, since a
+	// machine moves between them as its annotations change.
+	syncing *syncingKeys
+	// providerCalls holds the UIDs of machines whose provider call is
+	// running, which may outlive the sync of machine after a timeout.
+	providerCalls *syncingKeys
+
 	lister       platformv1lister.MachineLister
 	listerSynced cache.InformerSynced
+	// indexer indexes the cached machines by health.
+	indexer cache.Indexer
 
 	log            log.Logger
 	platformClient platformversionedclient.PlatformV1Interface
 	deleter        deletion.MachineDeleterInterface
+	recorder       record.EventRecorder
+	webhook        *healthWebhook
+	audit          *auditSink
+	// lifecycleStream receives the lifecycle records of machines if set.
+	lifecycleStream chan<- LifecycleRecord
+	// phaseResyncPeriods is the period to requeue machines keyed by phase.
+	phaseResyncPeriods map[string]time.Duration
+	// providerTimeout is the timeout of a single provider operation.
+	providerTimeout time.Duration
+	// drainPollInterval is the interval to check the pool when DrainPool.
+	drainPollInterval time.Duration
+	// reconcileStats counts meaningful reconciles of machines.
+	reconcileStats *reconcileStats
+	// healthErrors deduplicates the logs of repeated health check failures.
+	healthErrors *errorDeduplicator
+	// healthChecks bounds the number of concurrent health checks.
+	healthChecks semaphore
+	// probe tracks the workers and health checks for Healthz and Readyz.
+	probe controllerProbe
+	// healthCheckOptions configures the node health check of providers.
+	healthCheckOptions machineprovider.HealthCheckOptions
+	// nodeAnnotationPrefixes are the prefixes of machine annotations
+	// mirrored onto the node.
+	nodeAnnotationPrefixes []string
+	// probeTimeWriteInterval is the min interval to write the probe time of
+	// conditions alone.
+	probeTimeWriteInterval time.Duration
+	// nodeHealthCondition is the type of node condition mirroring the health
+	// check of machine, empty if none.
+	nodeHealthCondition corev1.NodeConditionType
+	// specDebounce coalesces rapid spec changes of machines into one
+	// reconcile, nil if disabled.
+	specDebounce *specDebouncer
+	// retryBudget caps the retries of failed machines by error category.
+	retryBudget *retryBudget
+	// podCounter limits how often the pods on the node of machine are counted.
+	podCounter *podCounter
+	// progress delivers the provisioning progress of machines to subscribers.
+	progress *progressHub
+	// failedPodPolicy is the policy to the pods on the node of failed machines.
+	failedPodPolicy string
+	// recoveryStreaks holds failed machines until they pass enough
+	// consecutive health checks.
+	recoveryStreaks *recoveryStreaks
+	// kubeletProber probes the kubelet healthz of machines if configured.
+	kubeletProber *kubeletProber
+	// pressure tracks the sustained resource pressure of nodes.
+	pressure *pressureTracker
+	// updateRequiresReadyNode skips the provider OnUpdate of machines whose
+	// node isn't ready.
+	updateRequiresReadyNode bool
+	// ageLimit recreates machines older than the max age if configured.
+	ageLimit *ageLimit
+	// healthConditionPatch is how the health check condition is written.
+	healthConditionPatch string
+	// stagger delays the first sync of existing machines on start.
+	stagger *stagger
+	// loggedProviders records the providers whose metadata has been logged.
+	loggedProviders sync.Map
+	// breaker avoids marking all machines Failed during a cluster outage.
+	breaker      *clusterBreaker
+	clientsetFor func(cluster *typesv1.Cluster) (kubernetes.Interface, error)
+	// getCluster returns the cluster the machine belongs to.
+	getCluster func(ctx context.Context, platformClient platformversionedclient.PlatformV1Interface, name, username string) (*typesv1.Cluster, error)
+
+	finalizerToken platformv1.FinalizerName
+	// legacyFinalizers are the finalizer tokens of earlier deployments which
+	// are migrated to finalizerToken.
+	legacyFinalizers []platformv1.FinalizerName
+	// processed is nil unless redundant informer resyncs are skipped.
+	processed *processedVersions
+	// createSteps records the resourceVersion from which the last create
+	// step was done, to avoid repeating the step on a stale cache.
+	createSteps *processedVersions
 }
 
 // NewController creates a new Controller object.
 func NewController(
 	platformclient platformversionedclient.PlatformV1Interface,
+	kubeclient kubernetes.Interface,
 	machineInformer platformv1informer.MachineInformer,
 	configuration machineconfig.MachineControllerConfiguration,
 	finalizerToken platformv1.FinalizerName) *Controller {
-	rateLimit := workqueue.NewMaxOfRateLimiter(
-		workqueue.NewItemExponentialFailureRateLimiter(5*time.Millisecond, 1000*time.Second),
-		&workqueue.BucketRateLimiter{Limiter: rate.NewLimiter(rate.Limit(configuration.BucketRateLimiterLimit), configuration.BucketRateLimiterBurst)},
-	)
+	platformclient = newWriteLimitedClient(platformclient, newSemaphore(configuration.MaxInFlightWrites))
 	c := &Controller{
-		queue: workqueue.NewNamedRateLimitingQueue(rateLimit, "machine"),
-
 		log:            log.WithName("MachineController"),
 		platformClient: platformclient,
 		deleter:        deletion.NewMachineDeleter(platformclient.Machines(), platformclient, finalizerToken, true),
+		recorder:       newEventRecorder(kubeclient),
+		webhook:        newHealthWebhook(configuration.HealthWebhookURL, configuration.HealthWebhookTimeout, configuration.HealthWebhookRetries),
+		getCluster:     clusterprovider.GetV1ClusterByName,
+		breaker:        newClusterBreaker(clusterProbeInterval),
+		clientsetFor:   (*typesv1.Cluster).Clientset,
+
+		providerTimeout:         configuration.ProviderTimeout,
+		phaseResyncPeriods:      configuration.PhaseResyncPeriods,
+		healthChecks:            newSemaphore(configuration.MaxConcurrentHealthChecks),
+		nodeAnnotationPrefixes:  configuration.NodeAnnotationPrefixes,
+		failedPodPolicy:         configuration.FailedMachinePodPolicy,
+		recoveryStreaks:         newRecoveryStreaks(configuration.RecoverySuccessThreshold),
+		kubeletProber:           newKubeletProber(configuration.KubeletHealthzPath),
+		pressure:                newPressureTracker(configuration.SustainedPressureProbes),
+		healthConditionPatch:    configuration.HealthConditionPatch,
+		updateRequiresReadyNode: configuration.UpdateRequiresReadyNode,
+		ageLimit:                newAgeLimit(configuration.MaxMachineAge),
+		probeTimeWriteInterval:  configuration.ProbeTimeWriteInterval,
+		nodeHealthCondition:     corev1.NodeConditionType(configuration.NodeHealthCondition),
+		specDebounce:            newSpecDebouncer(configuration.SpecUpdateDebounce),
+		retryBudget:             newRetryBudget(configuration),
+		podCounter:              newPodCounter(podCountInterval),
+		progress:                newProgressHub(),
+		stagger:                 newStagger(configuration.StartupStaggerWindow, rand.NewSource(time.Now().UnixNano())),
+		healthErrors:            newErrorDeduplicator(repeatedErrorLogInterval),
+		reconcileStats:          newReconcileStats(reconcileStatsInterval),
+		providerCalls:           newSyncingKeys(),
+		healthCheckOptions: machineprovider.HealthCheckOptions{
+			JoinGracePeriod:     configuration.JoinGracePeriod,
+			HeartbeatThreshold:  configuration.NodeHeartbeatThreshold,
+			HeartbeatAnnotation: configuration.NodeHeartbeatAnnotation,
+			NodeNetworkCheck: machineprovider.NodeNetworkCheck{
+				PodCIDR:          configuration.NodeRequirePodCIDR,
+				NetworkAvailable: configuration.NodeRequireNetworkAvailable,
+			},
+			NodeGetTimeout: configuration.NodeGetTimeout,
+		},
+
+		finalizerToken:   finalizerToken,
+		legacyFinalizers: legacyFinalizers(configuration.LegacyFinalizers),
+		createSteps:      newProcessedVersions(),
+	}
+
+	c.breaker.onClose = c.reprobeCluster
+	c.queue = workqueue.NewNamedRateLimitingQueue(newRateLimiter(configuration, c.failedOperation), "machine")
+
+	if configuration.SkipRedundantResync {
+		c.processed = newProcessedVersions()
+	}
+
+	audit, err := newAuditSink(configuration.HealthAuditConfigMap, configuration.HealthAuditMaxRecords, c.globalClientset)
+	if err != nil {
+		c.log.Error(err, "Invalid health audit ConfigMap, audit is disabled", "configMap", configuration.HealthAuditConfigMap)
+	} else {
+		c.audit = audit
+	}
+
+	if configuration.DedicatedPoolSelector != "" {
+		selector, err := labels.Parse(configuration.DedicatedPoolSelector)
+		if err != nil {
+			c.log.Error(err, "Invalid dedicated pool selector, dedicated workers are disabled", "selector", configuration.DedicatedPoolSelector)
+		} else {
+			c.dedicatedSelector = selector
+			c.dedicatedWorkers = configuration.ConcurrentDedicatedMachineSyncs
+			c.dedicatedQueue = workqueue.NewNamedRateLimitingQueue(newRateLimiter(configuration, c.failedOperation), "machine_dedicated")
+			c.syncing = newSyncingKeys()
+		}
 	}
 
 	if platformclient != nil && platformclient.RESTClient().GetRateLimiter() != nil {
@@ -86,25 +297,69 @@ func NewController(
 		cache.ResourceEventHandlerFuncs{
 			AddFunc:    c.addMachine,
 			UpdateFunc: c.updateMachine,
+			DeleteFunc: c.deleteMachine,
 		},
 		configuration.MachineSyncPeriod,
 	)
+	if err := machineInformer.Informer().AddIndexers(healthIndexers()); err != nil {
+		c.log.Error(err, "Failed to add machine health indexer")
+	}
 	c.lister = machineInformer.Lister()
 	c.listerSynced = machineInformer.Informer().HasSynced
+	c.indexer = machineInformer.Informer().GetIndexer()
 
 	return c
 }
 
+func newRateLimiter(configuration machineconfig.MachineControllerConfiguration, operationOf func(key string) string) workqueue.RateLimiter {
+	return workqueue.NewMaxOfRateLimiter(
+		newOperationRateLimiter(configuration, operationOf),
+		&workqueue.BucketRateLimiter{Limiter: rate.NewLimiter(rate.Limit(configuration.BucketRateLimiterLimit), configuration.BucketRateLimiterBurst)},
+	)
+}
+
 func (c *Controller) addMachine(obj interface{}) {
-	machine := obj.(*platformv1.Machine)
+	machine, err := asMachine(obj)
+	if err != nil {
+		warnUnexpectedObject("add", err)
+		return
+	}
+	exportConditionMetrics(machine)
 	c.log.Info("Adding machine", "machine", machine.Name)
+	if delay := c.startupDelay(machine); delay > 0 {
+		c.enqueueAfter(machine, delay)
+		return
+	}
 	c.enqueue(machine)
 }
 
 func (c *Controller) updateMachine(old, obj interface{}) {
-	oldMachine := old.(*platformv1.Machine)
-	machine := obj.(*platformv1.Machine)
+	oldMachine, err := asMachine(old)
+	if err != nil {
+		warnUnexpectedObject("update", err)
+		return
+	}
+	machine, err := asMachine(obj)
+	if err != nil {
+		warnUnexpectedObject("update", err)
+		return
+	}
 
+	exportConditionMetrics(machine)
+	if oldMachine.UID != machine.UID {
+		// the machine is deleted and recreated with the same name, e.g. while
+		// the watch is down and the informer relists, so the state tracked for
+		// the old one is dropped and the new one is checked from scratch
+		c.log.Info("Machine is recreated", "machine", machine.Name, "uid", machine.UID)
+		if key, err := cache.MetaNamespaceKeyFunc(oldMachine); err == nil {
+			c.forgetMachine(key, oldMachine.UID)
+		}
+		c.enqueue(machine)
+		return
+	}
+	if c.isRedundantResync(oldMachine, machine) {
+		return
+	}
 	controllerNeedUpddateResult := c.needsUpdate(oldMachine, machine)
 	var providerNeedUpddateResult bool
 	provider, _ := machineprovider.GetProvider(machine.Spec.Type)
@@ -115,6 +370,15 @@ func (c *Controller) updateMachine(old, obj interface{}) {
 		return
 	}
 	c.log.Info("Updating machine", "machine", machine.Name)
+	if c.specDebounce != nil && !reflect.DeepEqual(oldMachine.Spec, machine.Spec) {
+		// the delaying queue keeps the earliest add of a key, so the last
+		// change is recorded and checked again when the key is processed
+		if key, err := cache.MetaNamespaceKeyFunc(machine); err == nil {
+			c.specDebounce.touch(key, time.Now())
+		}
+		c.enqueueAfter(machine, c.specDebounce.window)
+		return
+	}
 	c.enqueue(machine)
 }
 
@@ -139,14 +403,15 @@ func (c *Controller) needsUpdate(old *platformv1.Machine, new *platformv1.Machin
 		if old.ResourceVersion == new.ResourceVersion {
 			return true
 		}
-		if len(new.Status.Conditions) == 0 {
+		last := lastStepCondition(new)
+		if last == nil {
 			return true
 		}
-		if new.Status.Conditions[len(new.Status.Conditions)-1].Status == platformv1.ConditionUnknown {
+		if last.Status == platformv1.ConditionUnknown {
 			return true
 		}
 		// if user set last condition false block procesee until resync envent
-		if new.Status.Conditions[len(new.Status.Conditions)-1].Status == platformv1.ConditionFalse {
+		if last.Status == platformv1.ConditionFalse {
 			return false
 		}
 	}
@@ -158,13 +423,67 @@ func (c *Controller) needsUpdate(old *platformv1.Machine, new *platformv1.Machin
 	return true
 }
 
+// lastStepCondition returns the last condition of the create steps of
+// machine, skipping the Ready condition derived by the controller.
+func lastStepCondition(machine *platformv1.Machine) *platformv1.MachineCondition {
+	for i := len(machine.Status.Conditions) - 1; i >= 0; i-- {
+		if machine.Status.Conditions[i].Type != platformv1.MachineConditionReady {
+			return &machine.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
 func (c *Controller) enqueue(obj *platformv1.Machine) {
 	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
 	if err != nil {
 		runtime.HandleError(fmt.Errorf("couldn't get key for object %+v: %v", obj, err))
 		return
 	}
-	c.queue.Add(key)
+	c.queueFor(obj).Add(key)
+}
+
+// queueFor returns the queue which the machine should be processed by.
+func (c *Controller) queueFor(machine *platformv1.Machine) workqueue.RateLimitingInterface {
+	if c.dedicatedQueue != nil && c.dedicatedSelector.Matches(labels.Set(machine.Annotations)) {
+		return c.dedicatedQueue
+	}
+	return c.queue
+}
+
+// syncingKeys are the keys of machines being synced. The workqueue never
+// hands out a key being processed, but the same key may be in both queues.
+// It also holds the machines whose provider call is running.
+type syncingKeys struct {
+	mu   sync.Mutex
+	keys map[string]struct{}
+}
+
+func newSyncingKeys() *syncingKeys {
+	return &syncingKeys{keys: make(map[string]struct{})}
+}
+
+// acquire returns false if the key is being synced already.
+func (s *syncingKeys) acquire(key string) bool {
+	if s == nil {
+		return true
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.keys[key]; ok {
+		return false
+	}
+	s.keys[key] = struct{}{}
+	return true
+}
+
+func (s *syncingKeys) release(key string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.keys, key)
 }
 
 // Run will set up the event handlers for types we are interested in, as well
@@ -172,17 +491,31 @@ func (c *Controller) enqueue(obj *platformv1.Machine) {
 func (c *Controller) Run(workers int, stopCh <-chan struct{}) error {
 	defer runtime.HandleCrash()
 	defer c.queue.ShutDown()
+	if c.dedicatedQueue != nil {
+		defer c.dedicatedQueue.ShutDown()
+	}
 
 	// Start the informer factories to begin populating the informer caches
 	log.Info("Starting machine controller")
 	defer log.Info("Shutting down machine controller")
 
-	if ok := cache.WaitForCacheSync(stopCh, c.listerSynced); !ok {
-		return fmt.Errorf("failed to wait for machine caches to sync")
+	if err := waitForCacheSync(stopCh, c.listerSynced, cacheSyncTimeout, cacheSyncAttempts); err != nil {
+		return err
 	}
+	c.resumeHealthChecks()
 
+	started := workers
+	if c.dedicatedQueue != nil {
+		started += c.dedicatedWorkers
+	}
+	atomic.StoreInt32(&c.probe.workers, int32(started))
 	for i := 0; i < workers; i++ {
-		go wait.Until(c.worker, time.Second, stopCh)
+		c.startWorker(func() { c.worker(c.queue) }, stopCh)
+	}
+	if c.dedicatedQueue != nil {
+		for i := 0; i < c.dedicatedWorkers; i++ {
+			c.startWorker(func() { c.worker(c.dedicatedQueue) }, stopCh)
+		}
 	}
 
 	<-stopCh
@@ -193,35 +526,99 @@ func (c *Controller) Run(workers int, stopCh <-chan struct{}) error {
 // Each machine can be in the queue at most once.
 // The system ensures that no two workers can process
 // the same namespace at the same time.
-func (c *Controller) worker() {
-	for c.processNextWorkItem() {
+func (c *Controller) worker(queue workqueue.RateLimitingInterface) {
+	for c.processNextWorkItem(queue) {
 	}
 }
 
-func (c *Controller) processNextWorkItem() bool {
-	key, quit := c.queue.Get()
+func (c *Controller) processNextWorkItem(queue workqueue.RateLimitingInterface) bool {
+	key, quit := queue.Get()
 	if quit {
 		return false
 	}
-	defer c.queue.Done(key)
+	defer queue.Done(key)
+	if wait := c.specDebounce.remaining(key.(string), time.Now()); wait > 0 {
+		queue.AddAfter(key, wait)
+		return true
+	}
+	if !c.syncing.acquire(key.(string)) {
+		queue.AddAfter(key, syncingRetryPeriod)
+		return true
+	}
+	defer c.syncing.release(key.(string))
 
 	err := c.syncMachine(key.(string))
+	if delay, ok := requeueDelay(err); ok {
+		queue.Forget(key)
+		queue.AddAfter(key, delay)
+		return true
+	}
 	if err == nil {
-		c.queue.Forget(key)
+		queue.Forget(key)
+		c.retryBudget.forget(key.(string))
+		c.resyncByPhase(queue, key.(string))
+		return true
+	}
+	if !c.retryBudget.retry(key.(string), err) {
+		runtime.HandleError(fmt.Errorf("error processing machine %v (retry budget of %s errors exhausted): %v", key, errorCategory(err), err))
+		queue.Forget(key)
 		return true
 	}
 
 	runtime.HandleError(fmt.Errorf("error processing machine %v (will retry): %v", key, err))
-	c.queue.AddRateLimited(key)
+	queue.AddRateLimited(key)
 	return true
 }
 
+// requeueDelay returns the delay to requeue the machine if err asks for it.
+func requeueDelay(err error) (time.Duration, bool) {
+	switch {
+	case errors.Is(err, errRequeue):
+		return 0, true
+	case errors.Is(err, errWaitingForCluster):
+		return clusterWaitPeriod, true
+	case errors.Is(err, errWaitingForReboot):
+		return rebootPollPeriod, true
+	case errors.Is(err, errPaused):
+		return pausePollPeriod, true
+	case errors.Is(err, errProviderCallRunning):
+		return providerCallRetryPeriod, true
+	case errors.Is(err, errClusterUnavailable):
+		return clusterUnavailablePeriod, true
+	}
+	// honor the Retry-After of throttled requests rather than backing off
+	if apierrors.IsTooManyRequests(err) {
+		if seconds, ok := apierrors.SuggestsClientDelay(err); ok {
+			return time.Duration(seconds) * time.Second, true
+		}
+	}
+	return 0, false
+}
+
+// resyncByPhase requeues the machine after the resync period of its phase.
+func (c *Controller) resyncByPhase(queue workqueue.RateLimitingInterface, key string) {
+	if len(c.phaseResyncPeriods) == 0 {
+		return
+	}
+	_, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return
+	}
+	machine, err := c.lister.Get(name)
+	if err != nil {
+		return
+	}
+	if period, ok := c.phaseResyncPeriods[string(machine.Status.Phase)]; ok && period > 0 {
+		queue.AddAfter(key, period)
+	}
+}
+
 // syncMachine will sync the Machine with the given key if it has had
 // its expectations fulfilled, meaning it did not expect to see any more of its
 // namespaces created or deleted. This function is not meant to be invoked
 // concurrently with the same key.
 func (c *Controller) syncMachine(key string) error {
-	ctx := c.log.WithValues("machine", key).WithContext(context.TODO())
+	ctx := withReconcileID(c.log.WithValues("machine", key).WithContext(context.TODO()))
 
 	startTime := time.Now()
 	defer func() {
@@ -246,22 +643,54 @@ func (c *Controller) syncMachine(key string) error {
 
 	ctx = log.FromContext(ctx).WithValues("cluster", machine.Spec.ClusterName).WithContext(ctx)
 
-	return c.reconcile(ctx, key, machine)
+	resourceVersion := machine.ResourceVersion
+	err = c.reconcile(ctx, key, machine)
+	if err == nil {
+		c.processed.set(key, resourceVersion)
+	}
+	return err
+}
+
+// ReconcileOnce performs a single reconcile of the named machine, it reads the
+// machine from the apiserver rather than the informer cache, so that it could
+// drive the controller deterministically, e.g. in integration tests.
+func (c *Controller) ReconcileOnce(ctx context.Context, name string) error {
+	machine, err := c.platformClient.Machines().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	ctx = log.FromContext(ctx).WithValues("machine", name, "cluster", machine.Spec.ClusterName).WithContext(ctx)
+	ctx = withReconcileID(ctx)
+
+	err = c.reconcile(ctx, name, machine)
+	if _, ok := requeueDelay(err); ok {
+		return nil
+	}
+	return err
 }
 
 func (c *Controller) reconcile(ctx context.Context, key string, machine *platformv1.Machine) error {
-	var err error
+	machine, err := c.migrateFinalizer(ctx, machine)
+	if err != nil {
+		return err
+	}
+	c.logProviderMetadata(ctx, machine.Spec.Type)
+	// the machine being deleted is cleaned up whatever its phase is, rather
+	// than updated by the provider uselessly
+	if machine.DeletionTimestamp != nil {
+		return c.delete(ctx, key, machine)
+	}
+
 	switch machine.Status.Phase {
 	case platformv1.MachineInitializing:
 		err = c.onCreate(ctx, machine)
 	case platformv1.MachineRunning, platformv1.MachineFailed, platformv1.MachineUpgrading:
 		err = c.onUpdate(ctx, machine)
 	case platformv1.MachineTerminating:
-		log.FromContext(ctx).Info("Machine has been terminated. Attempting to cleanup resources")
-		err = c.deleter.Delete(ctx, key)
-		if err == nil {
-			log.FromContext(ctx).Info("Machine has been successfully deleted")
-		}
+		err = c.delete(ctx, key, machine)
 	default:
 		log.FromContext(ctx).Info("unknown machine phase", "status.phase", machine.Status.Phase)
 	}
@@ -269,54 +698,534 @@ func (c *Controller) reconcile(ctx context.Context, key string, machine *platfor
 	return err
 }
 
+// delete cleans up the resources of the machine being deleted.
+func (c *Controller) delete(ctx context.Context, key string, machine *platformv1.Machine) error {
+	log.FromContext(ctx).Info("Machine has been terminated. Attempting to cleanup resources")
+	result, err := c.deleter.Delete(ctx, key)
+	c.recordDeletion(machine, result, err)
+	if err == nil {
+		log.FromContext(ctx).Info("Machine has been successfully deleted", "result", result)
+	}
+	return err
+}
+
+// logProviderMetadata logs the metadata of provider when it's first used.
+func (c *Controller) logProviderMetadata(ctx context.Context, name string) {
+	if _, logged := c.loggedProviders.LoadOrStore(name, true); logged {
+		return
+	}
+	metadata, err := machineprovider.GetProviderMetadata(name)
+	if err != nil {
+		c.loggedProviders.Delete(name)
+		return
+	}
+	log.FromContext(ctx).Info("Using machine provider", "provider", metadata.Name,
+		"version", metadata.Version, "features", metadata.Features)
+}
+
+// migrateFinalizer replaces the finalizer of earlier releases with the current
+// finalizer token, so that the machine could be cleaned by the deleter.
+func (c *Controller) migrateFinalizer(ctx context.Context, machine *platformv1.Machine) (*platformv1.Machine, error) {
+	if c.finalizerToken == "" {
+		return machine, nil
+	}
+
+	migrated := false
+	finalizers := make([]platformv1.FinalizerName, 0, len(machine.Spec.Finalizers))
+	for _, finalizer := range machine.Spec.Finalizers {
+		if c.isLegacyFinalizer(finalizer) {
+			migrated = true
+			continue
+		}
+		finalizers = append(finalizers, finalizer)
+	}
+	if !migrated {
+		return machine, nil
+	}
+	hasToken := false
+	for _, finalizer := range finalizers {
+		if finalizer == c.finalizerToken {
+			hasToken = true
+			break
+		}
+	}
+	if !hasToken {
+		finalizers = append(finalizers, c.finalizerToken)
+	}
+
+	machine = machine.DeepCopy()
+	machine.Spec.Finalizers = finalizers
+	log.FromContext(ctx).Info("Migrate machine finalizer", "finalizers", finalizers)
+	return c.platformClient.Machines().Update(ctx, machine, metav1.UpdateOptions{})
+}
+
+func legacyFinalizers(tokens []string) []platformv1.FinalizerName {
+	finalizers := make([]platformv1.FinalizerName, 0, len(tokens))
+	for _, token := range tokens {
+		finalizers = append(finalizers, platformv1.FinalizerName(token))
+	}
+	return finalizers
+}
+
+func (c *Controller) isLegacyFinalizer(finalizer platformv1.FinalizerName) bool {
+	for _, legacy := range c.legacyFinalizers {
+		if finalizer == legacy && finalizer != c.finalizerToken {
+			return true
+		}
+	}
+	return false
+}
+
+// onCreate performs a single step of the provider OnCreate, and returns
+// errRequeue while the machine is still initializing, so that the worker is
+// freed between steps.
 func (c *Controller) onCreate(ctx context.Context, machine *platformv1.Machine) error {
+	if version := c.createSteps.get(machine.Name); version != "" && version == machine.ResourceVersion {
+		// the step has been done, wait for the informer to catch up
+		return nil
+	}
+	if machine.Spec.ClusterName == "" {
+		return c.failClusterNameMissing(ctx, machine)
+	}
+	if machine.Spec.Paused {
+		return c.pause(ctx, machine)
+	}
+	removeCondition(machine, conditionTypePaused)
 	provider, err := machineprovider.GetProvider(machine.Spec.Type)
 	if err != nil {
 		return err
 	}
-	cluster, err := clusterprovider.GetV1ClusterByName(ctx, c.platformClient, machine.Spec.ClusterName, clusterprovider.AdminUsername)
+	cluster, err := c.getCluster(ctx, c.platformClient, machine.Spec.ClusterName, clusterprovider.AdminUsername)
 	if err != nil {
+		return c.handleClusterError(ctx, machine, err)
+	}
+	if isClusterProvisioning(cluster) {
+		return c.waitForCluster(ctx, machine, cluster, conditionTypeWaitingForCluster)
+	}
+	removeCondition(machine, conditionTypeWaitingForCluster)
+	removeCondition(machine, conditionTypeClusterAvailable)
+	if err := c.guardProvision(ctx, machine, cluster); err != nil {
+		return err
+	}
+	if err := c.preCheck(ctx, provider, machine, cluster); err != nil {
+		if errors.Is(err, errPreCheckFailed) {
+			// the machine has been failed, there is nothing to retry
+			return nil
+		}
 		return err
 	}
 
-	for machine.Status.Phase == platformv1.MachineInitializing {
-		err = provider.OnCreate(ctx, machine, cluster)
-		if err != nil {
-			// Update status, ignore failure
-			_, _ = c.platformClient.Machines().Update(ctx, machine, metav1.UpdateOptions{})
+	fromVersion := machine.ResourceVersion
+	machine, err = c.callProvider(ctx, providerOperationCreate, machine, func(ctx context.Context, machine *platformv1.Machine) error {
+		if c.progress != nil {
+			ctx = machineprovider.WithStepReporter(ctx, c.progress.reporter(machine.Name))
+		}
+		return provider.OnCreate(ctx, machine, cluster)
+	})
+	setInstallLog(machine, err)
+	if errors.Is(err, machineprovider.ErrJoinTokenExpired) {
+		return c.failJoinTokenExpired(ctx, machine, err)
+	}
+	if terminal, ok := machineprovider.IsTerminal(err); ok {
+		return c.failTerminal(ctx, machine, terminal)
+	}
+	if err != nil {
+		// Update status, ignore failure
+		_, _ = c.platformClient.Machines().Update(ctx, machine, metav1.UpdateOptions{})
+		return err
+	}
+	// the connectivity prerequisites reported by a failed step are met now
+	removeCondition(machine, machineprovider.ConditionTypeConnectivity)
+	if machine.Status.Phase != platformv1.MachineInitializing {
+		recordProviderVersion(machine)
+		recordKubeletConfig(machine)
+		recordCluster(machine)
+		c.ageLimit.recordProvisionedTime(machine)
+	}
+	setReadyCondition(machine)
+	c.reconcileStats.record(machine)
+	machine, err = c.platformClient.Machines().Update(ctx, machine, metav1.UpdateOptions{})
+	if err != nil {
+		return err
+	}
+	if machine.Status.Phase != platformv1.MachineInitializing {
+		c.recordLifecycle(machine, platformv1.MachineInitializing, "")
+		observeTimeToRunning(machine)
+		c.createSteps.forget(machine.Name)
+		return nil
+	}
+	c.createSteps.set(machine.Name, fromVersion)
+
+	return errRequeue
+}
+
+// pause holds the provisioning of machine until spec.paused is unset, the
+// provider isn't called meanwhile.
+func (c *Controller) pause(ctx context.Context, machine *platformv1.Machine) error {
+	log.FromContext(ctx).Info("Machine provisioning is paused")
+
+	if machine.GetCondition(conditionTypePaused) == nil {
+		machine = machine.DeepCopy()
+		machine.SetCondition(platformv1.MachineCondition{
+			Type:    conditionTypePaused,
+			Status:  platformv1.ConditionTrue,
+			Reason:  conditionTypePaused,
+			Message: "provisioning is paused by spec.paused",
+		})
+		if _, err := c.platformClient.Machines().Update(ctx, machine, metav1.UpdateOptions{}); err != nil {
 			return err
 		}
-		machine, err = c.platformClient.Machines().Update(ctx, machine, metav1.UpdateOptions{})
-		if err != nil {
+	}
+	return errPaused
+}
+
+// isClusterProvisioning returns true if the cluster isn't ready for machines yet.
+func isClusterProvisioning(cluster *typesv1.Cluster) bool {
+	return cluster.Status.Phase == platformv1.ClusterInitializing ||
+		cluster.Status.Phase == platformv1.ClusterWaiting
+}
+
+// waitForCluster marks the machine waiting for its cluster with the condition,
+// rather than calling the provider while the cluster isn't stable.
+func (c *Controller) waitForCluster(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster, conditionType string) error {
+	message := fmt.Sprintf("cluster %s is %s", cluster.Name, cluster.Status.Phase)
+	log.FromContext(ctx).Info("Machine is waiting for cluster", "clusterPhase", cluster.Status.Phase)
+
+	if condition := machine.GetCondition(conditionType); condition == nil || condition.Message != message {
+		machine = machine.DeepCopy()
+		machine.SetCondition(platformv1.MachineCondition{
+			Type:    conditionType,
+			Status:  platformv1.ConditionTrue,
+			Reason:  conditionType,
+			Message: message,
+		})
+		if _, err := c.platformClient.Machines().Update(ctx, machine, metav1.UpdateOptions{}); err != nil {
 			return err
 		}
 	}
+	return errWaitingForCluster
+}
 
-	return err
+// removeCondition removes the condition of the type from machine status.
+func removeCondition(machine *platformv1.Machine, conditionType string) {
+	if machine.GetCondition(conditionType) == nil {
+		return
+	}
+	var conditions []platformv1.MachineCondition
+	for _, condition := range machine.Status.Conditions {
+		if condition.Type != conditionType {
+			conditions = append(conditions, condition)
+		}
+	}
+	machine.Status.Conditions = conditions
+}
+
+// setInstallLog stores the tail of the install log attached to err, which is
+// capped to maxInstallLogSize bytes. The log is removed once provisioning
+// makes progress again.
+func setInstallLog(machine *platformv1.Machine, err error) {
+	var logErr *machineprovider.LogError
+	if !errors.As(err, &logErr) {
+		if err == nil {
+			delete(machine.Annotations, platformv1.MachineInstallLogAnno)
+		}
+		return
+	}
+	if machine.Annotations == nil {
+		machine.Annotations = make(map[string]string)
+	}
+	machine.Annotations[platformv1.MachineInstallLogAnno] = tailLog(logErr.Log, maxInstallLogSize)
+}
+
+// tailLog returns the last size bytes of log, without splitting a rune.
+func tailLog(log string, size int) string {
+	if len(log) <= size {
+		return log
+	}
+	i := len(log) - size
+	for i < len(log) && !utf8.RuneStart(log[i]) {
+		i++
+	}
+	return log[i:]
 }
 
 func (c *Controller) onUpdate(ctx context.Context, machine *platformv1.Machine) error {
+	if machine.Spec.ClusterName == "" {
+		// the machine has been failed on create, there is nothing to check
+		return nil
+	}
 	provider, err := machineprovider.GetProvider(machine.Spec.Type)
 	if err != nil {
 		return err
 	}
+	if from, ok := migrationSource(machine); ok {
+		return c.migrate(ctx, provider, machine, from)
+	}
 
-	cluster, err := clusterprovider.GetV1ClusterByName(ctx, c.platformClient, machine.Spec.ClusterName, clusterprovider.AdminUsername)
+	cluster, err := c.getCluster(ctx, c.platformClient, machine.Spec.ClusterName, clusterprovider.AdminUsername)
 	if err != nil {
+		return c.handleClusterError(ctx, machine, err)
+	}
+
+	if cluster.Status.Phase == platformv1.ClusterUpgrading {
+		return c.waitForCluster(ctx, machine, cluster, conditionTypeDeferredForClusterUpgrade)
+	}
+	if _, ok := machine.Annotations[platformv1.MachineRebootRequestedAnno]; ok && machine.Status.Phase != platformv1.MachineUpgrading {
+		return c.reboot(ctx, provider, machine, cluster)
+	}
+	if recreated, err := c.enforceMaxAge(ctx, machine); recreated || err != nil {
 		return err
 	}
 
-	err = provider.OnUpdate(ctx, machine, cluster)
-	machine = provider.OnHealthCheck(ctx, machine, cluster)
+	oldMachine := machine.DeepCopy()
+	removeCondition(machine, conditionTypeDeferredForClusterUpgrade)
+	removeCondition(machine, conditionTypeClusterAvailable)
+	oldPhase := machine.Status.Phase
+	oldHealthCondition := machine.GetCondition(machineprovider.ConditionTypeHealthCheck)
+	oldHealth := healthStatus(machine)
+	if _, ok := machine.Annotations[platformv1.MachineClusterAnno]; !ok {
+		// machines provisioned before the cluster was recorded
+		recordCluster(machine)
+	}
+	setClusterOwner(machine, cluster.Cluster)
+	// the provider isn't called while waiting for the node or after it failed
+	// terminally, but the health check still runs
+	waitErr := c.waitForNode(ctx, machine, cluster)
+	if waitErr == nil && !isTerminallyFailed(machine) {
+		_, forceResync := machine.Annotations[platformv1.MachineForceResyncAnno]
+		restartKubelet := kubeletRestartRequired(machine)
+		done := true
+		machine, err = c.callProvider(ctx, providerOperationUpdate, machine, func(ctx context.Context, machine *platformv1.Machine) error {
+			var err error
+			done, err = providerUpdate(ctx, provider, machine, cluster, machineprovider.UpdateOptions{
+				ForceResync:    forceResync,
+				KubeletRestart: restartKubelet,
+			})
+			return err
+		})
+		if err == nil && done && forceResync {
+			log.FromContext(ctx).Info("Machine has been forcibly resynced")
+			delete(machine.Annotations, platformv1.MachineForceResyncAnno)
+		}
+		if err == nil && done && restartKubelet {
+			kubeletRestarted(ctx, machine)
+		}
+		if err == nil {
+			waitErr = setConverging(ctx, machine, done)
+		}
+		if err == nil && waitErr == nil {
+			waitErr = c.checkKubeletRestart(ctx, machine, cluster)
+		}
+	}
+	if errors.Is(err, machineprovider.ErrRecreateRequired) {
+		return c.recreate(ctx, machine, err)
+	}
+	if terminal, ok := machineprovider.IsTerminal(err); ok {
+		return c.failTerminal(ctx, machine, terminal)
+	}
+	checkProviderVersionDrift(machine)
+	healthCtx, checkedNode := machineprovider.WithNodeReporter(ctx)
+	machine, reachable := c.probeHealth(healthCtx, provider, machine, cluster, false)
+	if reachable {
+		c.syncNodeInfo(ctx, machine, cluster, checkedNode())
+		c.syncNodeHealthCondition(ctx, machine, cluster)
+	}
+	if condition := machine.GetCondition(machineprovider.ConditionTypeHealthCheck); condition != nil && condition.Status != platformv1.ConditionTrue {
+		c.logHealthError(ctx, string(machine.UID), condition.Message)
+		c.recordHealthCheckFailure(ctx, machine, condition)
+	} else {
+		c.healthErrors.reset(string(machine.UID))
+	}
+	c.recordHealthTransition(ctx, machine, oldPhase, oldHealthCondition)
+	setLastHealthError(machine)
+	setReadyCondition(machine)
 	if err != nil {
 		// Update status, ignore failure
 		_, _ = c.platformClient.Machines().UpdateStatus(ctx, machine, metav1.UpdateOptions{})
 		return err
 	}
-	_, err = c.platformClient.Machines().Update(ctx, machine, metav1.UpdateOptions{})
+	if isMeaningfulChange(oldMachine, machine) {
+		c.reconcileStats.record(machine)
+	} else {
+		c.coalesceProbeTime(oldMachine, machine)
+	}
+	if c.healthConditionPatch == machineconfig.HealthConditionPatchApply {
+		oldMachine, err = c.applyHealthCondition(ctx, oldMachine, machine)
+		if err != nil {
+			return err
+		}
+	}
+	_, err = c.patchMachine(ctx, oldMachine, machine)
 	if err != nil {
 		return err
 	}
+	c.recordLifecycle(machine, oldPhase, oldHealth)
+
+	return waitErr
+}
 
+// patchMachine patches the changes between old and new machine,
+// nothing is sent to the apiserver if the patch is empty. The patch is
+// preconditioned on the resourceVersion of old, so that it conflicts rather
+// than overwrites the changes made since old was read.
+func (c *Controller) patchMachine(ctx context.Context, old, new *platformv1.Machine) (*platformv1.Machine, error) {
+	patchBytes, err := strategicpatch.GetPatchBytesForType(old, new, platformv1.Machine{})
+	if err != nil {
+		return nil, err
+	}
+	if strategicpatch.IsEmptyPatch(patchBytes) {
+		return new, nil
+	}
+	patchBytes, err = withResourceVersion(patchBytes, old.ResourceVersion)
+	if err != nil {
+		return nil, err
+	}
+	return c.platformClient.Machines().Patch(ctx, new.Name, types.StrategicMergePatchType, patchBytes, metav1.PatchOptions{})
+}
+
+// withResourceVersion sets metadata.resourceVersion of the patch to
+// resourceVersion, which the apiserver checks as a precondition.
+func withResourceVersion(patchBytes []byte, resourceVersion string) ([]byte, error) {
+	patch := make(map[string]interface{})
+	if err := json.Unmarshal(patchBytes, &patch); err != nil {
+		return nil, err
+	}
+	metadata, _ := patch["metadata"].(map[string]interface{})
+	if metadata == nil {
+		metadata = make(map[string]interface{})
+		patch["metadata"] = metadata
+	}
+	metadata["resourceVersion"] = resourceVersion
+	return json.Marshal(patch)
+}
+
+// recreate moves the machine back to Initializing, so that the provider
+// initializes it again from the first create handler.
+func (c *Controller) recreate(ctx context.Context, machine *platformv1.Machine, reason error) error {
+	log.FromContext(ctx).Info("Machine requires recreation", "reason", reason.Error())
+
+	oldPhase, oldHealth := machine.Status.Phase, healthStatus(machine)
+	machine.Status.Phase = platformv1.MachineInitializing
+	machine.Status.Conditions = nil
+	machine.SetCondition(platformv1.MachineCondition{
+		Type:    machineprovider.ConditionTypeRecreate,
+		Status:  platformv1.ConditionTrue,
+		Reason:  machineprovider.ReasonRecreateRequired,
+		Message: reason.Error(),
+	})
+	machine.Status.Reason = machineprovider.ReasonRecreateRequired
+	machine.Status.Message = reason.Error()
+	if _, err := c.platformClient.Machines().UpdateStatus(ctx, machine, metav1.UpdateOptions{}); err != nil {
+		return err
+	}
+	c.recordLifecycle(machine, oldPhase, oldHealth)
+	return nil
+}
+
+// failJoinTokenExpired marks the machine Failed when its join token has
+// expired during initialization, rather than retrying the join forever.
+func (c *Controller) failJoinTokenExpired(ctx context.Context, machine *platformv1.Machine, reason error) error {
+	log.FromContext(ctx).Info("Machine join token has expired", "reason", reason.Error())
+
+	machine.Status.Phase = platformv1.MachineFailed
+	machine.SetCondition(platformv1.MachineCondition{
+		Type:    machineprovider.ConditionTypeJoinTokenValid,
+		Status:  platformv1.ConditionFalse,
+		Reason:  machineprovider.ReasonJoinTokenExpired,
+		Message: reason.Error(),
+	})
+	machine.Status.Reason = machineprovider.ReasonJoinTokenExpired
+	machine.Status.Message = reason.Error()
+	if _, err := c.platformClient.Machines().Update(ctx, machine, metav1.UpdateOptions{}); err != nil {
+		return err
+	}
+	c.recordLifecycle(machine, platformv1.MachineInitializing, "")
+	return nil
+}
+
+// failClusterNameMissing marks the machine Failed when it doesn't belong to
+// any cluster, rather than retrying to get the cluster forever.
+func (c *Controller) failClusterNameMissing(ctx context.Context, machine *platformv1.Machine) error {
+	log.FromContext(ctx).Info("Machine cluster name is missing")
+
+	machine = machine.DeepCopy()
+	machine.Status.Phase = platformv1.MachineFailed
+	machine.SetCondition(platformv1.MachineCondition{
+		Type:    conditionTypeClusterName,
+		Status:  platformv1.ConditionFalse,
+		Reason:  reasonClusterNameMissing,
+		Message: messageClusterNameMissing,
+	})
+	if _, err := c.platformClient.Machines().Update(ctx, machine, metav1.UpdateOptions{}); err != nil {
+		return err
+	}
+	c.recordLifecycle(machine, platformv1.MachineInitializing, "")
 	return nil
 }
+
+// callProvider calls the provider operation fn with the provider timeout.
+// fn works on a copy of the machine, which is returned once fn finishes.
+// If fn doesn't finish in time, it's left running in background and the
+// original machine is returned with a provider timeout condition.
+// Conditions reported by the provider are merged into the returned machine.
+// The operation is counted in flight for the cluster of machine.
+func (c *Controller) callProvider(ctx context.Context, operation string, machine *platformv1.Machine, fn func(context.Context, *platformv1.Machine) error) (*platformv1.Machine, error) {
+	ctx, reported := machineprovider.WithConditionReporter(ctx)
+	machine, err := c.callProviderWithTimeout(ctx, machine, trackProviderOperation(machine.Spec.ClusterName, operation, fn))
+	for _, condition := range reported() {
+		machine.SetCondition(condition)
+	}
+	if err != nil {
+		err = &providerError{err: err}
+	}
+	return machine, err
+}
+
+func (c *Controller) callProviderWithTimeout(ctx context.Context, machine *platformv1.Machine, fn func(context.Context, *platformv1.Machine) error) (*platformv1.Machine, error) {
+	if c.providerTimeout <= 0 {
+		return machine, fn(ctx, machine)
+	}
+
+	// the provider is never called again for the machine before the call
+	// which timed out returns
+	uid := string(machine.UID)
+	if !c.providerCalls.acquire(uid) {
+		return machine, errProviderCallRunning
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.providerTimeout)
+	defer cancel()
+
+	type result struct {
+		machine *platformv1.Machine
+		err     error
+	}
+	resultCh := make(chan result, 1)
+	go func(machine *platformv1.Machine) {
+		defer runtime.HandleCrash()
+		defer c.providerCalls.release(uid)
+		err := fn(ctx, machine)
+		resultCh <- result{machine: machine, err: err}
+	}(machine.DeepCopy())
+
+	select {
+	case r := <-resultCh:
+		if condition := r.machine.GetCondition(conditionTypeProviderTimeout); condition != nil && condition.Status != platformv1.ConditionTrue {
+			r.machine.SetCondition(platformv1.MachineCondition{
+				Type:   conditionTypeProviderTimeout,
+				Status: platformv1.ConditionTrue,
+			})
+		}
+		return r.machine, r.err
+	case <-ctx.Done():
+		err := fmt.Errorf("%w after %s", errProviderTimeout, c.providerTimeout)
+		log.FromContext(ctx).Error(err, "Machine provider hung")
+		machine.SetCondition(platformv1.MachineCondition{
+			Type:    conditionTypeProviderTimeout,
+			Status:  platformv1.ConditionFalse,
+			Reason:  reasonProviderTimeout,
+			Message: err.Error(),
+		})
+		return machine, err
+	}
+}
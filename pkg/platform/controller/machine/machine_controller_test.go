@@ -19,14 +19,72 @@
 package machine
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"tkestack.io/tke/api/client/clientset/versioned/fake"
+	platformversionedclient "tkestack.io/tke/api/client/clientset/versioned/typed/platform/v1"
+	fakeplatformv1 "tkestack.io/tke/api/client/clientset/versioned/typed/platform/v1/fake"
+	"tkestack.io/tke/api/client/informers/externalversions"
+	platformv1lister "tkestack.io/tke/api/client/listers/platform/v1"
 	platformv1 "tkestack.io/tke/api/platform/v1"
+	machineconfig "tkestack.io/tke/pkg/platform/controller/machine/config"
+	"tkestack.io/tke/pkg/platform/controller/machine/deletion"
 	machineprovider "tkestack.io/tke/pkg/platform/provider/machine"
+	typesv1 "tkestack.io/tke/pkg/platform/types/v1"
+	"tkestack.io/tke/pkg/util/log"
 )
 
+// fakeProvider is a machine provider whose controller hooks could be replaced by tests.
+type fakeProvider struct {
+	machineprovider.DelegateProvider
+
+	onUpdate      func(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster, options machineprovider.UpdateOptions) error
+	onHealthCheck func(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster) *platformv1.Machine
+}
+
+func (p *fakeProvider) OnUpdate(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster, options machineprovider.UpdateOptions) error {
+	if p.onUpdate != nil {
+		return p.onUpdate(ctx, machine, cluster, options)
+	}
+	return nil
+}
+
+func (p *fakeProvider) OnHealthCheck(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster, options machineprovider.HealthCheckOptions) *platformv1.Machine {
+	if p.onHealthCheck != nil {
+		return p.onHealthCheck(ctx, machine, cluster)
+	}
+	return machine
+}
+
+// registerFakeProvider registers provider with a unique name and returns the name.
+func registerFakeProvider(t *testing.T, provider machineprovider.Provider) string {
+	name := fmt.Sprintf("Fake-%s", t.Name())
+	machineprovider.Register(name, provider)
+	return name
+}
+
+func fakeGetCluster(ctx context.Context, platformClient platformversionedclient.PlatformV1Interface, name, username string) (*typesv1.Cluster, error) {
+	return &typesv1.Cluster{Cluster: &platformv1.Cluster{ObjectMeta: v1.ObjectMeta{Name: name}}}, nil
+}
+
 func newMachineForTest(resourcesVersion string, spec *platformv1.MachineSpec, phase platformv1.MachinePhase, conditions []platformv1.MachineCondition) *platformv1.Machine {
 	mc := &platformv1.Machine{
 		ObjectMeta: v1.ObjectMeta{ResourceVersion: resourcesVersion},
@@ -88,6 +146,18 @@ func TestController_needsUpdate(t *testing.T) {
 			},
 			want: true,
 		},
+		{
+			name: "change pool",
+			args: args{
+				old: newMachineForTest("old", nil, platformv1.MachineRunning, nil),
+				new: func() *platformv1.Machine {
+					machine := newMachineForTest("new", nil, platformv1.MachineRunning, nil)
+					machine.Labels = map[string]string{platformv1.MachinePoolLabel: "gpu"}
+					return machine
+				}(),
+			},
+			want: true,
+		},
 		{
 			name: "Initializing to Running",
 			args: args{
@@ -217,3 +287,1314 @@ func TestController_needsUpdate(t *testing.T) {
 		})
 	}
 }
+
+func TestController_onUpdateRecreate(t *testing.T) {
+	providerName := registerFakeProvider(t, &fakeProvider{
+		onUpdate: func(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster, options machineprovider.UpdateOptions) error {
+			return fmt.Errorf("%w: kubelet flags changed", machineprovider.ErrRecreateRequired)
+		},
+	})
+	machine := newMachineForTest("1", nil, platformv1.MachineRunning, nil)
+	machine.Name = "machine"
+	machine.Spec.Type = providerName
+	c := newControllerForTest(machine)
+	c.getCluster = fakeGetCluster
+
+	if err := c.onUpdate(context.Background(), machine.DeepCopy()); err != nil {
+		t.Fatalf("onUpdate() error = %v", err)
+	}
+
+	got, err := c.platformClient.Machines().Get(context.Background(), machine.Name, v1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status.Phase != platformv1.MachineInitializing {
+		t.Errorf("phase = %s, want %s", got.Status.Phase, platformv1.MachineInitializing)
+	}
+	condition := got.GetCondition(machineprovider.ConditionTypeRecreate)
+	if condition == nil || condition.Reason != machineprovider.ReasonRecreateRequired {
+		t.Errorf("recreate condition = %+v, want reason %s", condition, machineprovider.ReasonRecreateRequired)
+	}
+	if got.GetCondition(machineprovider.ConditionTypeHealthCheck) != nil {
+		t.Errorf("health check condition should be cleared on recreation")
+	}
+}
+
+func TestController_callProviderTimeout(t *testing.T) {
+	release := make(chan struct{})
+	var calls int32
+	providerName := registerFakeProvider(t, &fakeProvider{
+		onUpdate: func(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster, options machineprovider.UpdateOptions) error {
+			atomic.AddInt32(&calls, 1)
+			// hang and ignore the context
+			<-release
+			return nil
+		},
+	})
+	machine := newMachineForTest("1", nil, platformv1.MachineRunning, nil)
+	machine.Name = "machine"
+	machine.UID = "uid"
+	machine.Spec.Type = providerName
+	c := newControllerForTest(machine)
+	c.getCluster = fakeGetCluster
+	c.providerTimeout = 10 * time.Millisecond
+	c.providerCalls = newSyncingKeys()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- c.onUpdate(context.Background(), machine.DeepCopy())
+	}()
+	select {
+	case err := <-done:
+		if !errors.Is(err, errProviderTimeout) {
+			t.Fatalf("onUpdate() error = %v, want %v", err, errProviderTimeout)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("onUpdate() is blocked by hanging provider")
+	}
+
+	got, err := c.platformClient.Machines().Get(context.Background(), machine.Name, v1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	condition := got.GetCondition(conditionTypeProviderTimeout)
+	if condition == nil || condition.Status != platformv1.ConditionFalse || condition.Reason != reasonProviderTimeout {
+		t.Errorf("provider timeout condition = %+v", condition)
+	}
+
+	err = c.onUpdate(context.Background(), got.DeepCopy())
+	if delay, ok := requeueDelay(err); !errors.Is(err, errProviderCallRunning) || !ok || delay != providerCallRetryPeriod {
+		t.Errorf("onUpdate() error = %v, want %v requeued after %s", err, errProviderCallRunning, providerCallRetryPeriod)
+	}
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("provider is called %d times while the call which timed out is running, want 1", got)
+	}
+
+	close(release)
+	if err := wait.PollImmediate(time.Millisecond, time.Second, func() (bool, error) {
+		if !c.providerCalls.acquire(string(machine.UID)) {
+			return false, nil
+		}
+		c.providerCalls.release(string(machine.UID))
+		return true, nil
+	}); err != nil {
+		t.Errorf("provider call of machine is still tracked after it returns")
+	}
+}
+
+func TestController_dedicatedPool(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	informerFactory := externalversions.NewSharedInformerFactory(client, 0)
+	c := NewController(client.PlatformV1(), nil, informerFactory.Platform().V1().Machines(),
+		machineconfig.MachineControllerConfiguration{
+			BucketRateLimiterLimit:          10,
+			BucketRateLimiterBurst:          100,
+			DedicatedPoolSelector:           "pool=gpu",
+			ConcurrentDedicatedMachineSyncs: 1,
+		}, platformv1.MachineFinalize)
+	defer c.queue.ShutDown()
+	defer c.dedicatedQueue.ShutDown()
+
+	dedicated := newMachineForTest("1", nil, platformv1.MachinePhase("Unknown"), nil)
+	dedicated.Name = "dedicated"
+	dedicated.Annotations = map[string]string{"pool": "gpu"}
+	general := newMachineForTest("1", nil, platformv1.MachinePhase("Unknown"), nil)
+	general.Name = "general"
+	c.enqueue(dedicated)
+	c.enqueue(general)
+
+	if c.dedicatedQueue.Len() != 1 || c.queue.Len() != 1 {
+		t.Fatalf("dedicated queue len = %d, general queue len = %d, want 1 and 1", c.dedicatedQueue.Len(), c.queue.Len())
+	}
+	key, _ := c.dedicatedQueue.Get()
+	c.dedicatedQueue.Done(key)
+	if key != dedicated.Name {
+		t.Errorf("dedicated queue got %v, want %s", key, dedicated.Name)
+	}
+	key, _ = c.queue.Get()
+	c.queue.Done(key)
+	if key != general.Name {
+		t.Errorf("general queue got %v, want %s", key, general.Name)
+	}
+}
+
+func TestController_dedicatedPoolSyncingKey(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	informerFactory := externalversions.NewSharedInformerFactory(client, 0)
+	c := NewController(client.PlatformV1(), nil, informerFactory.Platform().V1().Machines(),
+		machineconfig.MachineControllerConfiguration{
+			BucketRateLimiterLimit:          10,
+			BucketRateLimiterBurst:          100,
+			DedicatedPoolSelector:           "pool=gpu",
+			ConcurrentDedicatedMachineSyncs: 1,
+		}, platformv1.MachineFinalize)
+	defer c.queue.ShutDown()
+	defer c.dedicatedQueue.ShutDown()
+
+	// the machine is annotated while a general worker syncs it
+	machine := newMachineForTest("1", nil, platformv1.MachinePhase("Unknown"), nil)
+	machine.Name = "machine"
+	machine.Annotations = map[string]string{"pool": "gpu"}
+	if !c.syncing.acquire(machine.Name) {
+		t.Fatal("acquire() = false for an idle machine")
+	}
+	c.enqueue(machine)
+
+	c.processNextWorkItem(c.dedicatedQueue)
+	if c.dedicatedQueue.Len() != 0 {
+		t.Errorf("dedicated queue len = %d, want the machine held back while it's synced", c.dedicatedQueue.Len())
+	}
+	c.syncing.release(machine.Name)
+	key, _ := c.dedicatedQueue.Get()
+	c.dedicatedQueue.Done(key)
+	if key != machine.Name {
+		t.Errorf("dedicated queue got %v, want %s requeued", key, machine.Name)
+	}
+}
+
+func TestNewControllerNodeNetworkCheck(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	informerFactory := externalversions.NewSharedInformerFactory(client, 0)
+	c := NewController(client.PlatformV1(), nil, informerFactory.Platform().V1().Machines(),
+		machineconfig.MachineControllerConfiguration{
+			BucketRateLimiterLimit:      10,
+			BucketRateLimiterBurst:      100,
+			NodeRequirePodCIDR:          true,
+			NodeRequireNetworkAvailable: true,
+		}, platformv1.MachineFinalize)
+	defer c.queue.ShutDown()
+
+	want := machineprovider.NodeNetworkCheck{PodCIDR: true, NetworkAvailable: true}
+	if c.healthCheckOptions.NodeNetworkCheck != want {
+		t.Errorf("node network check = %+v, want %+v from configuration", c.healthCheckOptions.NodeNetworkCheck, want)
+	}
+}
+
+func TestController_onUpdateSettlesWithoutRecordedState(t *testing.T) {
+	providerName := registerFakeProvider(t, &fakeProvider{
+		onHealthCheck: func(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster) *platformv1.Machine {
+			return machine
+		},
+	})
+	// a machine provisioned before the controller recorded its cluster,
+	// kubelet configuration and Ready condition
+	machine := newMachineForTest("1", nil, platformv1.MachineRunning, nil)
+	machine.Name = "machine"
+	machine.Spec.Type = providerName
+	c := newControllerForTest(machine)
+	c.getCluster = fakeGetCluster
+	fakeClient := c.platformClient.(*fakeplatformv1.FakePlatformV1)
+	ctx := context.Background()
+
+	for i := 1; i <= 3; i++ {
+		got, err := c.platformClient.Machines().Get(ctx, machine.Name, v1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		// the fake clientset doesn't bump the resourceVersion on writes
+		got.ResourceVersion = strconv.Itoa(i)
+		fakeClient.ClearActions()
+		if err := c.onUpdate(ctx, got); err != nil {
+			t.Fatalf("onUpdate() %d error = %v", i, err)
+		}
+		writes := 0
+		for _, action := range fakeClient.Actions() {
+			if action.GetVerb() == "update" || action.GetVerb() == "patch" {
+				writes++
+			}
+		}
+		if i > 1 && writes != 0 {
+			t.Errorf("onUpdate() %d wrote the machine %d times, want settled after the first one", i, writes)
+		}
+	}
+}
+
+func TestController_onUpdateSkipEmptyPatch(t *testing.T) {
+	healthy := true
+	providerName := registerFakeProvider(t, &fakeProvider{
+		onHealthCheck: func(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster) *platformv1.Machine {
+			if !healthy {
+				machine.Status.Phase = platformv1.MachineFailed
+			}
+			return machine
+		},
+	})
+	machine := newMachineForTest("1", nil, platformv1.MachineRunning, nil)
+	machine.Name = "machine"
+	machine.Spec.Type = providerName
+	setReadyCondition(machine)
+	recordCluster(machine)
+	c := newControllerForTest(machine)
+	c.getCluster = fakeGetCluster
+	fakeClient := c.platformClient.(*fakeplatformv1.FakePlatformV1)
+
+	countPatches := func() int {
+		count := 0
+		for _, action := range fakeClient.Actions() {
+			if action.GetVerb() == "patch" {
+				count++
+			}
+		}
+		return count
+	}
+
+	if err := c.onUpdate(context.Background(), machine.DeepCopy()); err != nil {
+		t.Fatalf("onUpdate() error = %v", err)
+	}
+	if count := countPatches(); count != 0 {
+		t.Errorf("patches = %d when health status is unchanged, want 0", count)
+	}
+
+	healthy = false
+	if err := c.onUpdate(context.Background(), machine.DeepCopy()); err != nil {
+		t.Fatalf("onUpdate() error = %v", err)
+	}
+	if count := countPatches(); count != 1 {
+		t.Errorf("patches = %d when health status is changed, want 1", count)
+	}
+	got, err := c.platformClient.Machines().Get(context.Background(), machine.Name, v1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status.Phase != platformv1.MachineFailed {
+		t.Errorf("phase = %s, want %s", got.Status.Phase, platformv1.MachineFailed)
+	}
+}
+
+func TestController_onUpdateForceResync(t *testing.T) {
+	var forced bool
+	providerName := registerFakeProvider(t, &fakeProvider{
+		onUpdate: func(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster, options machineprovider.UpdateOptions) error {
+			forced = options.ForceResync
+			return nil
+		},
+	})
+	machine := newMachineForTest("1", nil, platformv1.MachineRunning, nil)
+	machine.Name = "machine"
+	machine.Spec.Type = providerName
+	machine.Annotations = map[string]string{platformv1.MachineForceResyncAnno: "true"}
+	c := newControllerForTest(machine)
+	c.getCluster = fakeGetCluster
+
+	if err := c.onUpdate(context.Background(), machine.DeepCopy()); err != nil {
+		t.Fatalf("onUpdate() error = %v", err)
+	}
+	if !forced {
+		t.Errorf("provider is not requested to force resync")
+	}
+	got, err := c.platformClient.Machines().Get(context.Background(), machine.Name, v1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := got.Annotations[platformv1.MachineForceResyncAnno]; ok {
+		t.Errorf("force resync annotation is not cleared")
+	}
+
+	if err := c.onUpdate(context.Background(), got); err != nil {
+		t.Fatalf("onUpdate() error = %v", err)
+	}
+	if forced {
+		t.Errorf("provider is requested to force resync without annotation")
+	}
+}
+
+// delayRecordingQueue records the delay of items added by AddAfter.
+type delayRecordingQueue struct {
+	workqueue.RateLimitingInterface
+	delays map[interface{}]time.Duration
+}
+
+func (q *delayRecordingQueue) AddAfter(item interface{}, duration time.Duration) {
+	q.delays[item] = duration
+}
+
+func TestController_resyncByPhase(t *testing.T) {
+	failed := newMachineForTest("1", nil, platformv1.MachineFailed, nil)
+	failed.Name = "failed"
+	running := newMachineForTest("1", nil, platformv1.MachineRunning, nil)
+	running.Name = "running"
+	terminating := newMachineForTest("1", nil, platformv1.MachineTerminating, nil)
+	terminating.Name = "terminating"
+	c := newControllerForTest(failed, running, terminating)
+	c.phaseResyncPeriods = map[string]time.Duration{
+		string(platformv1.MachineFailed):  30 * time.Second,
+		string(platformv1.MachineRunning): 5 * time.Minute,
+	}
+	queue := &delayRecordingQueue{delays: map[interface{}]time.Duration{}}
+
+	for _, name := range []string{failed.Name, running.Name, terminating.Name} {
+		c.resyncByPhase(queue, name)
+	}
+
+	if queue.delays[failed.Name] >= queue.delays[running.Name] {
+		t.Errorf("failed machine requeued after %s, running machine after %s, want failed sooner",
+			queue.delays[failed.Name], queue.delays[running.Name])
+	}
+	if _, ok := queue.delays[terminating.Name]; ok {
+		t.Errorf("machine without phase resync period should not be requeued")
+	}
+}
+
+func TestController_processNextWorkItemRetryAfter(t *testing.T) {
+	providerName := registerFakeProvider(t, &fakeProvider{
+		onUpdate: func(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster, options machineprovider.UpdateOptions) error {
+			machine.Status.Message = "updated"
+			return nil
+		},
+	})
+	machine := newMachineForTest("1", nil, platformv1.MachineRunning, nil)
+	machine.Name = "machine"
+	machine.Spec.Type = providerName
+	c := newControllerForTest(machine)
+	c.log = log.WithName("MachineController")
+	c.getCluster = fakeGetCluster
+	client := fake.NewSimpleClientset(machine)
+	client.PrependReactor("patch", "machines", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewTooManyRequests("throttled", 7)
+	})
+	c.platformClient = client.PlatformV1()
+	queue := &delayRecordingQueue{
+		RateLimitingInterface: workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+		delays:                map[interface{}]time.Duration{},
+	}
+	defer queue.ShutDown()
+	queue.Add(machine.Name)
+
+	c.processNextWorkItem(queue)
+	if delay, ok := queue.delays[machine.Name]; !ok || delay != 7*time.Second {
+		t.Errorf("machine requeued after %s, want the Retry-After 7s", delay)
+	}
+}
+
+func TestController_ReconcileOnce(t *testing.T) {
+	install := func(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster) error {
+		return nil
+	}
+	providerName := registerFakeProvider(t, &fakeProvider{
+		DelegateProvider: machineprovider.DelegateProvider{
+			CreateHandlers: []machineprovider.Handler{install},
+		},
+	})
+	machine := newMachineForTest("1", nil, platformv1.MachineInitializing, []platformv1.MachineCondition{})
+	machine.Name = "machine"
+	machine.Spec.Type = providerName
+	c := newControllerForTest(machine)
+	c.getCluster = fakeGetCluster
+
+	if err := c.ReconcileOnce(context.Background(), machine.Name); err != nil {
+		t.Fatalf("ReconcileOnce() error = %v", err)
+	}
+	got, err := c.platformClient.Machines().Get(context.Background(), machine.Name, v1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status.Phase != platformv1.MachineRunning {
+		t.Errorf("phase = %s, want %s", got.Status.Phase, platformv1.MachineRunning)
+	}
+
+	if err := c.ReconcileOnce(context.Background(), "not-exist"); err != nil {
+		t.Errorf("ReconcileOnce() error = %v for deleted machine", err)
+	}
+}
+
+func TestController_onCreateBlockedPorts(t *testing.T) {
+	blocked := true
+	checkConnectivity := func(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster) error {
+		if !blocked {
+			return nil
+		}
+		err := errors.New("dial tcp 127.0.0.1:10250: i/o timeout")
+		machineprovider.ReportBlockedPorts(ctx, err, machineprovider.RequiredPort{
+			Port:    10250,
+			Purpose: "kubelet API",
+			From:    "control plane",
+		})
+		return err
+	}
+	providerName := registerFakeProvider(t, &fakeProvider{
+		DelegateProvider: machineprovider.DelegateProvider{
+			CreateHandlers: []machineprovider.Handler{checkConnectivity},
+		},
+	})
+	machine := newMachineForTest("1", nil, platformv1.MachineInitializing, []platformv1.MachineCondition{})
+	machine.Name = "machine"
+	machine.Spec.Type = providerName
+	c := newControllerForTest(machine)
+	c.getCluster = fakeGetCluster
+	get := func() *platformv1.Machine {
+		got, err := c.platformClient.Machines().Get(context.Background(), machine.Name, v1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return got
+	}
+
+	if err := c.onCreate(context.Background(), machine.DeepCopy()); err == nil {
+		t.Fatalf("onCreate() error = nil, want the connectivity error")
+	}
+	condition := get().GetCondition(machineprovider.ConditionTypeConnectivity)
+	if condition == nil || condition.Status != platformv1.ConditionFalse || condition.Reason != machineprovider.ReasonPortsBlocked {
+		t.Fatalf("connectivity condition = %+v, want False with reason %s", condition, machineprovider.ReasonPortsBlocked)
+	}
+	if want := "ports must be open: 10250/TCP (kubelet API) from control plane"; !strings.HasPrefix(condition.Message, want) {
+		t.Errorf("connectivity message = %q, want prefix %q", condition.Message, want)
+	}
+
+	// the firewall is opened
+	blocked = false
+	if err := c.onCreate(context.Background(), get()); err != nil {
+		t.Fatalf("onCreate() error = %v", err)
+	}
+	if condition := get().GetCondition(machineprovider.ConditionTypeConnectivity); condition != nil {
+		t.Errorf("connectivity condition = %+v, want it removed once the ports are open", condition)
+	}
+}
+
+func TestController_migrateFinalizer(t *testing.T) {
+	machine := newMachineForTest("1", nil, platformv1.MachineTerminating, nil)
+	machine.Name = "machine"
+	machine.Spec.Finalizers = []platformv1.FinalizerName{"legacy"}
+	c := newControllerForTest(machine)
+	c.finalizerToken = platformv1.MachineFinalize
+	c.legacyFinalizers = legacyFinalizers([]string{"legacy"})
+
+	if _, err := c.migrateFinalizer(context.Background(), machine); err != nil {
+		t.Fatalf("migrateFinalizer() error = %v", err)
+	}
+	got, err := c.platformClient.Machines().Get(context.Background(), machine.Name, v1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []platformv1.FinalizerName{platformv1.MachineFinalize}
+	if !reflect.DeepEqual(got.Spec.Finalizers, want) {
+		t.Errorf("finalizers = %v, want %v", got.Spec.Finalizers, want)
+	}
+}
+
+func TestController_onCreateInstallLog(t *testing.T) {
+	installLog := strings.Repeat("a", maxInstallLogSize) + "last line of install output"
+	install := func(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster) error {
+		return machineprovider.WithLog(errors.New("install failed"), installLog)
+	}
+	providerName := registerFakeProvider(t, &fakeProvider{
+		DelegateProvider: machineprovider.DelegateProvider{
+			CreateHandlers: []machineprovider.Handler{install},
+		},
+	})
+	machine := newMachineForTest("1", nil, platformv1.MachineInitializing, []platformv1.MachineCondition{})
+	machine.Name = "machine"
+	machine.Spec.Type = providerName
+	c := newControllerForTest(machine)
+	c.getCluster = fakeGetCluster
+
+	if err := c.onCreate(context.Background(), machine); err == nil {
+		t.Fatal("onCreate() error = nil, want install failure")
+	}
+	got, err := c.platformClient.Machines().Get(context.Background(), machine.Name, v1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	stored := got.Annotations[platformv1.MachineInstallLogAnno]
+	if len(stored) != maxInstallLogSize {
+		t.Errorf("install log size = %d, want %d", len(stored), maxInstallLogSize)
+	}
+	if !strings.HasSuffix(stored, "last line of install output") {
+		t.Errorf("install log %q does not keep the tail of output", stored[len(stored)-64:])
+	}
+}
+
+func TestController_onCreateJoinTokenExpired(t *testing.T) {
+	join := func(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster) error {
+		return fmt.Errorf("kubeadm join: %w", machineprovider.ErrJoinTokenExpired)
+	}
+	providerName := registerFakeProvider(t, &fakeProvider{
+		DelegateProvider: machineprovider.DelegateProvider{
+			CreateHandlers: []machineprovider.Handler{join},
+		},
+	})
+	machine := newMachineForTest("1", nil, platformv1.MachineInitializing, []platformv1.MachineCondition{})
+	machine.Name = "machine"
+	machine.Spec.Type = providerName
+	c := newControllerForTest(machine)
+	c.getCluster = fakeGetCluster
+
+	if err := c.onCreate(context.Background(), machine); err != nil {
+		t.Fatalf("onCreate() error = %v, want the machine failed without retry", err)
+	}
+	got, err := c.platformClient.Machines().Get(context.Background(), machine.Name, v1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status.Phase != platformv1.MachineFailed || got.Status.Reason != machineprovider.ReasonJoinTokenExpired {
+		t.Errorf("phase = %s, reason = %s, want %s with reason %s", got.Status.Phase, got.Status.Reason,
+			platformv1.MachineFailed, machineprovider.ReasonJoinTokenExpired)
+	}
+	condition := got.GetCondition(machineprovider.ConditionTypeJoinTokenValid)
+	if condition == nil || condition.Status != platformv1.ConditionFalse {
+		t.Errorf("join token condition = %+v, want false", condition)
+	}
+}
+
+func TestController_onCreateClusterNameMissing(t *testing.T) {
+	machine := newMachineForTest("1", nil, platformv1.MachineInitializing, []platformv1.MachineCondition{})
+	machine.Name = "machine"
+	machine.Spec.ClusterName = ""
+	c := newControllerForTest(machine)
+	c.getCluster = func(ctx context.Context, platformClient platformversionedclient.PlatformV1Interface, name, username string) (*typesv1.Cluster, error) {
+		t.Fatalf("cluster should not be got without cluster name")
+		return nil, nil
+	}
+
+	if err := c.onCreate(context.Background(), machine); err != nil {
+		t.Fatalf("onCreate() error = %v, want the machine failed without retry", err)
+	}
+	got, err := c.platformClient.Machines().Get(context.Background(), machine.Name, v1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status.Phase != platformv1.MachineFailed || got.Status.Message != messageClusterNameMissing {
+		t.Errorf("phase = %s, message = %q, want %s with message %q", got.Status.Phase, got.Status.Message,
+			platformv1.MachineFailed, messageClusterNameMissing)
+	}
+	if err := c.onUpdate(context.Background(), got); err != nil {
+		t.Errorf("onUpdate() error = %v, want failed machine without cluster name ignored", err)
+	}
+}
+
+func TestController_onCreateStepPerReconcile(t *testing.T) {
+	steps := 0
+	step1 := func(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster) error {
+		steps++
+		return nil
+	}
+	step2 := func(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster) error {
+		steps++
+		return nil
+	}
+	step3 := func(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster) error {
+		steps++
+		return nil
+	}
+	providerName := registerFakeProvider(t, &fakeProvider{
+		DelegateProvider: machineprovider.DelegateProvider{
+			CreateHandlers: []machineprovider.Handler{step1, step2, step3},
+		},
+	})
+	machine := newMachineForTest("1", nil, platformv1.MachineInitializing, []platformv1.MachineCondition{})
+	machine.Name = "machine"
+	machine.Spec.Type = providerName
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	_ = indexer.Add(machine)
+	client := fake.NewSimpleClientset(machine)
+	// bump resource version as the apiserver does
+	version := 1
+	client.PrependReactor("update", "machines", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		version++
+		action.(k8stesting.UpdateAction).GetObject().(*platformv1.Machine).ResourceVersion = strconv.Itoa(version)
+		return false, nil, nil
+	})
+	c := &Controller{
+		lister:         platformv1lister.NewMachineLister(indexer),
+		platformClient: client.PlatformV1(),
+		log:            log.WithName("MachineController"),
+		getCluster:     fakeGetCluster,
+		createSteps:    newProcessedVersions(),
+		clientsetFor: func(cluster *typesv1.Cluster) (kubernetes.Interface, error) {
+			return kubefake.NewSimpleClientset(), nil
+		},
+	}
+	queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	defer queue.ShutDown()
+	syncCache := func() {
+		got, err := client.PlatformV1().Machines().Get(context.Background(), machine.Name, v1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = indexer.Update(got)
+	}
+
+	queue.Add(machine.Name)
+	for i := 1; i <= 3; i++ {
+		c.processNextWorkItem(queue)
+		if steps != i {
+			t.Fatalf("steps = %d after %d reconciles, want one step per reconcile", steps, i)
+		}
+		if i == 3 {
+			break
+		}
+		if queue.Len() != 1 {
+			t.Fatalf("queue length = %d after step %d, want requeued", queue.Len(), i)
+		}
+		// the requeued machine is stale in cache, the step is not repeated
+		c.processNextWorkItem(queue)
+		if steps != i {
+			t.Fatalf("steps = %d, want step %d not repeated on stale cache", steps, i)
+		}
+		syncCache()
+		queue.Add(machine.Name)
+	}
+
+	got, err := client.PlatformV1().Machines().Get(context.Background(), machine.Name, v1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status.Phase != platformv1.MachineRunning {
+		t.Errorf("phase = %s, want %s", got.Status.Phase, platformv1.MachineRunning)
+	}
+	if queue.Len() != 0 {
+		t.Errorf("queue length = %d, want no requeue once running", queue.Len())
+	}
+}
+
+func TestController_onCreateStepsByInformerUpdates(t *testing.T) {
+	steps := 0
+	step1 := func(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster) error {
+		steps++
+		return nil
+	}
+	step2 := func(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster) error {
+		steps++
+		return nil
+	}
+	providerName := registerFakeProvider(t, &fakeProvider{
+		DelegateProvider: machineprovider.DelegateProvider{
+			CreateHandlers: []machineprovider.Handler{step1, step2},
+		},
+	})
+	machine := newMachineForTest("1", nil, platformv1.MachineInitializing, []platformv1.MachineCondition{})
+	machine.Name = "machine"
+	machine.Spec.Type = providerName
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	_ = indexer.Add(machine)
+	client := fake.NewSimpleClientset(machine)
+	// bump resource version as the apiserver does
+	version := 1
+	client.PrependReactor("update", "machines", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		version++
+		action.(k8stesting.UpdateAction).GetObject().(*platformv1.Machine).ResourceVersion = strconv.Itoa(version)
+		return false, nil, nil
+	})
+	queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	defer queue.ShutDown()
+	c := &Controller{
+		queue:          queue,
+		lister:         platformv1lister.NewMachineLister(indexer),
+		platformClient: client.PlatformV1(),
+		log:            log.WithName("MachineController"),
+		getCluster:     fakeGetCluster,
+		createSteps:    newProcessedVersions(),
+		clientsetFor: func(cluster *typesv1.Cluster) (kubernetes.Interface, error) {
+			return kubefake.NewSimpleClientset(), nil
+		},
+	}
+
+	queue.Add(machine.Name)
+	for i := 1; i <= 2; i++ {
+		c.processNextWorkItem(queue)
+		if steps != i {
+			t.Fatalf("steps = %d after step %d, want %d", steps, i, i)
+		}
+		if i == 2 {
+			break
+		}
+		// the immediate requeue reads the stale cache
+		c.processNextWorkItem(queue)
+		if queue.Len() != 0 {
+			t.Fatalf("queue length = %d, want the next step waiting for the informer", queue.Len())
+		}
+
+		// the informer delivers the write of the step
+		old, err := c.lister.Get(machine.Name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := client.PlatformV1().Machines().Get(context.Background(), machine.Name, v1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if last := got.Status.Conditions[len(got.Status.Conditions)-1]; last.Type != platformv1.MachineConditionReady || last.Status != platformv1.ConditionFalse {
+			t.Fatalf("last condition = %+v, want Ready=False written by the step", last)
+		}
+		_ = indexer.Update(got)
+		c.updateMachine(old, got)
+		if queue.Len() != 1 {
+			t.Fatalf("queue length = %d after the update of step %d, want the next step enqueued", queue.Len(), i)
+		}
+	}
+}
+
+func TestController_providerConditions(t *testing.T) {
+	installDriver := func(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster) error {
+		machineprovider.ReportCondition(ctx, platformv1.MachineCondition{
+			Type:   "GPUDriverInstalled",
+			Status: platformv1.ConditionTrue,
+		})
+		return nil
+	}
+	providerName := registerFakeProvider(t, &fakeProvider{
+		DelegateProvider: machineprovider.DelegateProvider{
+			CreateHandlers: []machineprovider.Handler{installDriver},
+		},
+	})
+	machine := newMachineForTest("1", nil, platformv1.MachineInitializing, []platformv1.MachineCondition{})
+	machine.Name = "machine"
+	machine.Spec.Type = providerName
+	c := newControllerForTest(machine)
+	c.getCluster = fakeGetCluster
+
+	if err := c.onCreate(context.Background(), machine); err != nil {
+		t.Fatalf("onCreate() error = %v", err)
+	}
+	got, err := c.platformClient.Machines().Get(context.Background(), machine.Name, v1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	condition := got.GetCondition("GPUDriverInstalled")
+	if condition == nil || condition.Status != platformv1.ConditionTrue {
+		t.Errorf("provider condition = %+v, want merged into machine status", condition)
+	}
+	if got.Status.Phase != platformv1.MachineRunning {
+		t.Errorf("phase = %s, want %s", got.Status.Phase, platformv1.MachineRunning)
+	}
+}
+
+func TestController_onCreateWaitingForCluster(t *testing.T) {
+	installed := false
+	install := func(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster) error {
+		installed = true
+		return nil
+	}
+	providerName := registerFakeProvider(t, &fakeProvider{
+		DelegateProvider: machineprovider.DelegateProvider{
+			CreateHandlers: []machineprovider.Handler{install},
+		},
+	})
+	machine := newMachineForTest("1", nil, platformv1.MachineInitializing, []platformv1.MachineCondition{})
+	machine.Name = "machine"
+	machine.Spec.Type = providerName
+	c := newControllerForTest(machine)
+	clusterPhase := platformv1.ClusterInitializing
+	c.getCluster = func(ctx context.Context, platformClient platformversionedclient.PlatformV1Interface, name, username string) (*typesv1.Cluster, error) {
+		cluster, _ := fakeGetCluster(ctx, platformClient, name, username)
+		cluster.Status.Phase = clusterPhase
+		return cluster, nil
+	}
+
+	if err := c.onCreate(context.Background(), machine); !errors.Is(err, errWaitingForCluster) {
+		t.Fatalf("onCreate() error = %v, want %v", err, errWaitingForCluster)
+	}
+	if installed {
+		t.Errorf("provider should not be called while cluster is provisioning")
+	}
+	got, err := c.platformClient.Machines().Get(context.Background(), machine.Name, v1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status.Phase != platformv1.MachineInitializing {
+		t.Errorf("phase = %s, want %s", got.Status.Phase, platformv1.MachineInitializing)
+	}
+	if condition := got.GetCondition(conditionTypeWaitingForCluster); condition == nil {
+		t.Errorf("machine has no %s condition", conditionTypeWaitingForCluster)
+	}
+
+	queue := &delayRecordingQueue{
+		RateLimitingInterface: workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+		delays:                map[interface{}]time.Duration{},
+	}
+	defer queue.ShutDown()
+	c.log = log.WithName("MachineController")
+	queue.Add(machine.Name)
+	c.processNextWorkItem(queue)
+	if queue.delays[machine.Name] != clusterWaitPeriod {
+		t.Errorf("machine requeued after %s, want %s", queue.delays[machine.Name], clusterWaitPeriod)
+	}
+
+	clusterPhase = platformv1.ClusterRunning
+	if err := c.onCreate(context.Background(), got); err != nil {
+		t.Fatalf("onCreate() error = %v after cluster is running", err)
+	}
+	got, err = c.platformClient.Machines().Get(context.Background(), machine.Name, v1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status.Phase != platformv1.MachineRunning || got.GetCondition(conditionTypeWaitingForCluster) != nil {
+		t.Errorf("phase = %s, conditions = %+v, want running without waiting condition", got.Status.Phase, got.Status.Conditions)
+	}
+}
+
+// recordingDeleter records the machines deleted.
+type recordingDeleter struct {
+	deleted []string
+}
+
+func (d *recordingDeleter) Delete(ctx context.Context, name string) (deletion.DeletionResult, error) {
+	d.deleted = append(d.deleted, name)
+	return deletion.DeletionResult{}, nil
+}
+
+func TestController_reconcileDeleting(t *testing.T) {
+	updates := 0
+	providerName := registerFakeProvider(t, &fakeProvider{
+		onUpdate: func(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster, options machineprovider.UpdateOptions) error {
+			updates++
+			return nil
+		},
+	})
+	machine := newMachineForTest("1", nil, platformv1.MachineRunning, nil)
+	machine.Name = "machine"
+	machine.Spec.Type = providerName
+	now := v1.Now()
+	machine.DeletionTimestamp = &now
+	deleter := &recordingDeleter{}
+	c := newControllerForTest(machine)
+	c.getCluster = fakeGetCluster
+	c.deleter = deleter
+
+	if err := c.reconcile(context.Background(), machine.Name, machine); err != nil {
+		t.Fatalf("reconcile() error = %v", err)
+	}
+	if updates != 0 {
+		t.Errorf("provider updated the machine being deleted %d times, want 0", updates)
+	}
+	if len(deleter.deleted) != 1 || deleter.deleted[0] != machine.Name {
+		t.Errorf("deleted machines = %v, want [%s]", deleter.deleted, machine.Name)
+	}
+}
+
+func TestController_onCreatePaused(t *testing.T) {
+	installed := false
+	install := func(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster) error {
+		installed = true
+		return nil
+	}
+	providerName := registerFakeProvider(t, &fakeProvider{
+		DelegateProvider: machineprovider.DelegateProvider{
+			CreateHandlers: []machineprovider.Handler{install},
+		},
+	})
+	machine := newMachineForTest("1", nil, platformv1.MachineInitializing, []platformv1.MachineCondition{})
+	machine.Name = "machine"
+	machine.Spec.Type = providerName
+	machine.Spec.Paused = true
+	c := newControllerForTest(machine)
+	c.getCluster = fakeGetCluster
+
+	if err := c.onCreate(context.Background(), machine); !errors.Is(err, errPaused) {
+		t.Fatalf("onCreate() error = %v, want %v", err, errPaused)
+	}
+	if installed {
+		t.Errorf("provider should not be called while machine is paused")
+	}
+	got, err := c.platformClient.Machines().Get(context.Background(), machine.Name, v1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if condition := got.GetCondition(conditionTypePaused); condition == nil || condition.Status != platformv1.ConditionTrue {
+		t.Errorf("paused condition = %+v, want true", condition)
+	}
+	if delay, ok := requeueDelay(errPaused); !ok || delay != pausePollPeriod {
+		t.Errorf("paused machine requeued after %s, want %s", delay, pausePollPeriod)
+	}
+
+	got.Spec.Paused = false
+	if err := c.onCreate(context.Background(), got); err != nil {
+		t.Fatalf("onCreate() error = %v after unpaused", err)
+	}
+	if !installed {
+		t.Errorf("provider should be called after machine is unpaused")
+	}
+	got, err = c.platformClient.Machines().Get(context.Background(), machine.Name, v1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status.Phase != platformv1.MachineRunning || got.GetCondition(conditionTypePaused) != nil {
+		t.Errorf("phase = %s, conditions = %+v, want running without paused condition", got.Status.Phase, got.Status.Conditions)
+	}
+}
+
+func TestController_onUpdateDeferredForClusterUpgrade(t *testing.T) {
+	updates := 0
+	providerName := registerFakeProvider(t, &fakeProvider{
+		onUpdate: func(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster, options machineprovider.UpdateOptions) error {
+			updates++
+			return nil
+		},
+		onHealthCheck: func(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster) *platformv1.Machine {
+			return machine
+		},
+	})
+	machine := newMachineForTest("1", nil, platformv1.MachineRunning, nil)
+	machine.Name = "machine"
+	machine.Spec.Type = providerName
+	c := newControllerForTest(machine)
+	clusterPhase := platformv1.ClusterUpgrading
+	c.getCluster = func(ctx context.Context, platformClient platformversionedclient.PlatformV1Interface, name, username string) (*typesv1.Cluster, error) {
+		cluster, _ := fakeGetCluster(ctx, platformClient, name, username)
+		cluster.Status.Phase = clusterPhase
+		return cluster, nil
+	}
+
+	if err := c.onUpdate(context.Background(), machine.DeepCopy()); !errors.Is(err, errWaitingForCluster) {
+		t.Fatalf("onUpdate() error = %v, want %v", err, errWaitingForCluster)
+	}
+	if updates != 0 {
+		t.Errorf("provider OnUpdate called %d times during cluster upgrade, want deferred", updates)
+	}
+	got, err := c.platformClient.Machines().Get(context.Background(), machine.Name, v1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.GetCondition(conditionTypeDeferredForClusterUpgrade) == nil {
+		t.Errorf("machine has no %s condition", conditionTypeDeferredForClusterUpgrade)
+	}
+
+	clusterPhase = platformv1.ClusterRunning
+	if err := c.onUpdate(context.Background(), got); err != nil {
+		t.Fatalf("onUpdate() error = %v after cluster upgrade", err)
+	}
+	if updates != 1 {
+		t.Errorf("provider OnUpdate called %d times after cluster upgrade, want 1", updates)
+	}
+	got, err = c.platformClient.Machines().Get(context.Background(), machine.Name, v1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.GetCondition(conditionTypeDeferredForClusterUpgrade) != nil {
+		t.Errorf("%s condition should be removed after cluster upgrade", conditionTypeDeferredForClusterUpgrade)
+	}
+}
+
+func TestController_reconcileStats(t *testing.T) {
+	phase := platformv1.MachineRunning
+	providerName := registerFakeProvider(t, &fakeProvider{
+		onHealthCheck: func(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster) *platformv1.Machine {
+			machine.Status.Phase = phase
+			machine.SetCondition(platformv1.MachineCondition{
+				Type:          machineprovider.ConditionTypeHealthCheck,
+				Status:        platformv1.ConditionTrue,
+				LastProbeTime: v1.Now(),
+			})
+			return machine
+		},
+	})
+	machine := newMachineForTest("1", nil, platformv1.MachineRunning, nil)
+	machine.Name = "machine"
+	machine.Spec.Type = providerName
+	setReadyCondition(machine)
+	recordCluster(machine)
+	c := newControllerForTest(machine)
+	c.getCluster = fakeGetCluster
+	now := time.Now()
+	c.reconcileStats = newReconcileStats(time.Minute)
+	c.reconcileStats.now = func() time.Time { return now }
+
+	reconcile := func() *platformv1.Machine {
+		t.Helper()
+		got, err := c.platformClient.Machines().Get(context.Background(), machine.Name, v1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := c.onUpdate(context.Background(), got); err != nil {
+			t.Fatalf("onUpdate() error = %v", err)
+		}
+		got, err = c.platformClient.Machines().Get(context.Background(), machine.Name, v1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return got
+	}
+	countOf := func(machine *platformv1.Machine) int32 {
+		return machine.Status.ReconcileCount
+	}
+
+	for i := 0; i < 2; i++ {
+		now = now.Add(time.Second)
+		if got := countOf(reconcile()); got != 0 {
+			t.Errorf("reconcile count = %d after no-op resync, want none", got)
+		}
+	}
+
+	phase = platformv1.MachineFailed
+	if got := countOf(reconcile()); got != 1 {
+		t.Errorf("reconcile count = %d after phase changed, want 1", got)
+	}
+
+	now = now.Add(time.Second)
+	phase = platformv1.MachineRunning
+	got := reconcile()
+	if count := countOf(got); count != 1 {
+		t.Errorf("reconcile count = %d within interval, want 1 until interval passes", count)
+	}
+	if got.Status.Phase != platformv1.MachineRunning {
+		t.Errorf("phase = %s, want %s", got.Status.Phase, platformv1.MachineRunning)
+	}
+
+	now = now.Add(time.Minute)
+	phase = platformv1.MachineFailed
+	got = reconcile()
+	if count := countOf(got); count != 3 {
+		t.Errorf("reconcile count = %d after interval, want 3", count)
+	}
+	if last := got.Status.LastReconcileTime; last == nil || !last.Time.Equal(now.Truncate(time.Second)) {
+		t.Errorf("last reconcile time = %v, want %v", last, now.Truncate(time.Second))
+	}
+}
+
+func TestController_syncNodeInfo(t *testing.T) {
+	var node *corev1.Node
+	providerName := registerFakeProvider(t, &fakeProvider{
+		onHealthCheck: func(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster) *platformv1.Machine {
+			machineprovider.ReportNode(ctx, node)
+			return machine
+		},
+	})
+	machine := newMachineForTest("1", nil, platformv1.MachineRunning, nil)
+	machine.Name = "machine"
+	machine.Spec.Type = providerName
+	c := newControllerForTest(machine)
+	c.getCluster = fakeGetCluster
+	node = &corev1.Node{
+		ObjectMeta: v1.ObjectMeta{Name: machine.Spec.IP},
+		Status: corev1.NodeStatus{
+			NodeInfo: corev1.NodeSystemInfo{
+				KubeletVersion: "v1.20.6",
+				OSImage:        "CentOS Linux 7 (Core)",
+				KernelVersion:  "3.10.0-1160.el7.x86_64",
+			},
+		},
+	}
+	client := kubefake.NewSimpleClientset(node)
+	c.clientsetFor = func(cluster *typesv1.Cluster) (kubernetes.Interface, error) {
+		return client, nil
+	}
+
+	if err := c.onUpdate(context.Background(), machine.DeepCopy()); err != nil {
+		t.Fatalf("onUpdate() error = %v", err)
+	}
+	for _, action := range client.Actions() {
+		if action.GetResource().Resource == "nodes" && (action.GetVerb() == "get" || action.GetVerb() == "list") {
+			t.Errorf("node is got again by %s after the health check", action.GetVerb())
+		}
+	}
+	got, err := c.platformClient.Machines().Get(context.Background(), machine.Name, v1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status.MachineInfo.KubeletVersion != "v1.20.6" {
+		t.Errorf("kubelet version = %q, want %q", got.Status.MachineInfo.KubeletVersion, "v1.20.6")
+	}
+	if got.Status.MachineInfo.OSImage != "CentOS Linux 7 (Core)" {
+		t.Errorf("os image = %q, want %q", got.Status.MachineInfo.OSImage, "CentOS Linux 7 (Core)")
+	}
+	if got.Status.MachineInfo.KernelVersion != "3.10.0-1160.el7.x86_64" {
+		t.Errorf("kernel version = %q, want %q", got.Status.MachineInfo.KernelVersion, "3.10.0-1160.el7.x86_64")
+	}
+}
+
+func TestController_syncNodeInfoNameLabel(t *testing.T) {
+	machine := newMachineForTest("1", nil, platformv1.MachineRunning, nil)
+	machine.Name = "machine"
+	node := &corev1.Node{ObjectMeta: v1.ObjectMeta{Name: machine.Spec.IP}}
+	client := kubefake.NewSimpleClientset(node)
+	c := newControllerForTest(machine)
+	c.clientsetFor = func(cluster *typesv1.Cluster) (kubernetes.Interface, error) {
+		return client, nil
+	}
+	label := func() string {
+		got, err := client.CoreV1().Nodes().Get(context.Background(), node.Name, v1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return got.Labels[platformv1.MachineNameLabel]
+	}
+
+	c.syncNodeInfo(context.Background(), machine, &typesv1.Cluster{}, nil)
+	if got := label(); got != machine.Name {
+		t.Errorf("machine name label = %q, want %q", got, machine.Name)
+	}
+
+	removed, _ := client.CoreV1().Nodes().Get(context.Background(), node.Name, v1.GetOptions{})
+	delete(removed.Labels, platformv1.MachineNameLabel)
+	if _, err := client.CoreV1().Nodes().Update(context.Background(), removed, v1.UpdateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	c.syncNodeInfo(context.Background(), machine, &typesv1.Cluster{}, nil)
+	if got := label(); got != machine.Name {
+		t.Errorf("machine name label = %q after removed, want restored", got)
+	}
+
+	// the node is missing
+	c.clientsetFor = func(cluster *typesv1.Cluster) (kubernetes.Interface, error) {
+		return kubefake.NewSimpleClientset(), nil
+	}
+	c.syncNodeInfo(context.Background(), machine, &typesv1.Cluster{}, nil)
+}
+
+func TestController_syncNodeInfoSinglePatch(t *testing.T) {
+	machine := newMachineForTest("1", nil, platformv1.MachineRunning, nil)
+	machine.Name = "machine"
+	machine.Labels = map[string]string{platformv1.MachinePoolLabel: "gpu"}
+	machine.Annotations = map[string]string{"billing.example.com/team": "infra"}
+	node := &corev1.Node{ObjectMeta: v1.ObjectMeta{Name: machine.Spec.IP}}
+	client := kubefake.NewSimpleClientset(node)
+	c := newControllerForTest(machine)
+	c.nodeAnnotationPrefixes = []string{"billing.example.com/"}
+	c.clientsetFor = func(cluster *typesv1.Cluster) (kubernetes.Interface, error) {
+		return client, nil
+	}
+
+	c.syncNodeInfo(context.Background(), machine, &typesv1.Cluster{}, node)
+	patches := 0
+	for _, action := range client.Actions() {
+		if action.GetVerb() == "patch" {
+			patches++
+		}
+	}
+	if patches != 1 {
+		t.Errorf("node is patched %d times, want the changes merged into one patch", patches)
+	}
+	got, err := client.CoreV1().Nodes().Get(context.Background(), node.Name, v1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Labels[platformv1.MachineNameLabel] != machine.Name || got.Labels[platformv1.MachinePoolLabel] != "gpu" {
+		t.Errorf("node labels = %v, want machine name and pool labels", got.Labels)
+	}
+	if got.Annotations["billing.example.com/team"] != "infra" {
+		t.Errorf("node annotations = %v, want the machine annotation mirrored", got.Annotations)
+	}
+
+	client.ClearActions()
+	c.syncNodeInfo(context.Background(), machine, &typesv1.Cluster{}, got)
+	for _, action := range client.Actions() {
+		if action.GetVerb() == "patch" {
+			t.Errorf("node is patched again without changes")
+		}
+	}
+}
+
+func TestController_onCreateNodeAlreadyReady(t *testing.T) {
+	installed := false
+	install := func(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster) error {
+		installed = true
+		return nil
+	}
+	providerName := registerFakeProvider(t, &fakeProvider{
+		DelegateProvider: machineprovider.DelegateProvider{
+			CreateHandlers: []machineprovider.Handler{install},
+		},
+	})
+	machine := newMachineForTest("1", nil, platformv1.MachineInitializing, []platformv1.MachineCondition{})
+	machine.Name = "machine"
+	machine.Spec.Type = providerName
+	c := newControllerForTest(machine)
+	c.getCluster = fakeGetCluster
+	node := &corev1.Node{
+		ObjectMeta: v1.ObjectMeta{Name: machine.Spec.IP},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}},
+		},
+	}
+	c.clientsetFor = func(cluster *typesv1.Cluster) (kubernetes.Interface, error) {
+		return kubefake.NewSimpleClientset(node), nil
+	}
+
+	if err := c.onCreate(context.Background(), machine.DeepCopy()); !errors.Is(err, errNodeAlreadyReady) {
+		t.Fatalf("onCreate() error = %v, want %v", err, errNodeAlreadyReady)
+	}
+	if installed {
+		t.Errorf("provider should not provision a machine whose node is ready")
+	}
+	got, err := c.platformClient.Machines().Get(context.Background(), machine.Name, v1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if condition := got.GetCondition(conditionTypeProvisionGuard); condition == nil || condition.Reason != reasonNodeAlreadyReady {
+		t.Errorf("condition %s = %+v, want reason %s", conditionTypeProvisionGuard, condition, reasonNodeAlreadyReady)
+	}
+
+	// A pending create step means provisioning is in progress, e.g. the
+	// node is joined by a previous step.
+	pending := machine.DeepCopy()
+	pending.SetCondition(platformv1.MachineCondition{
+		Type:   machineprovider.Handler(install).Name(),
+		Status: platformv1.ConditionUnknown,
+		Reason: machineprovider.ReasonWaiting,
+	})
+	if err := c.onCreate(context.Background(), pending); err != nil {
+		t.Fatalf("onCreate() error = %v with pending create step", err)
+	}
+	if !installed {
+		t.Errorf("provider should continue provisioning with pending create step")
+	}
+}
+
+func TestController_onUpdateMovePool(t *testing.T) {
+	providerName := registerFakeProvider(t, &fakeProvider{
+		onHealthCheck: func(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster) *platformv1.Machine {
+			return machine
+		},
+	})
+	machine := newMachineForTest("1", nil, platformv1.MachineRunning, nil)
+	machine.Name = "machine"
+	machine.Spec.Type = providerName
+	machine.Labels = map[string]string{platformv1.MachinePoolLabel: "cpu"}
+	c := newControllerForTest(machine)
+	c.getCluster = fakeGetCluster
+	client := kubefake.NewSimpleClientset(&corev1.Node{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   machine.Spec.IP,
+			Labels: map[string]string{platformv1.MachinePoolLabel: "cpu", "other": "kept"},
+		},
+	})
+	c.clientsetFor = func(cluster *typesv1.Cluster) (kubernetes.Interface, error) {
+		return client, nil
+	}
+	nodeLabels := func() map[string]string {
+		t.Helper()
+		node, err := client.CoreV1().Nodes().Get(context.Background(), machine.Spec.IP, v1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return node.Labels
+	}
+
+	machine.Labels[platformv1.MachinePoolLabel] = "gpu"
+	if err := c.onUpdate(context.Background(), machine.DeepCopy()); err != nil {
+		t.Fatalf("onUpdate() error = %v", err)
+	}
+	if labels := nodeLabels(); labels[platformv1.MachinePoolLabel] != "gpu" || labels["other"] != "kept" {
+		t.Errorf("node labels = %v, want moved to pool gpu", labels)
+	}
+
+	delete(machine.Labels, platformv1.MachinePoolLabel)
+	if err := c.onUpdate(context.Background(), machine.DeepCopy()); err != nil {
+		t.Fatalf("onUpdate() error = %v", err)
+	}
+	if labels := nodeLabels(); labels[platformv1.MachinePoolLabel] != "" || labels["other"] != "kept" {
+		t.Errorf("node labels = %v, want pool label removed", labels)
+	}
+}
+
+func TestController_patchMachineResourceVersion(t *testing.T) {
+	machine := newMachineForTest("1", nil, platformv1.MachineRunning, nil)
+	machine.Name = "machine"
+	c := newControllerForTest(machine)
+	fakeClient := c.platformClient.(*fakeplatformv1.FakePlatformV1)
+	fakeClient.PrependReactor("patch", "machines", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		patch := action.(k8stesting.PatchAction).GetPatch()
+		if !strings.Contains(string(patch), `"resourceVersion":"1"`) {
+			t.Errorf("patch = %s, want preconditioned on resourceVersion 1", patch)
+		}
+		// the machine is changed since it was read
+		return true, nil, apierrors.NewConflict(platformv1.Resource("machines"), machine.Name, errors.New("the object has been modified"))
+	})
+
+	updated := machine.DeepCopy()
+	updated.Status.Phase = platformv1.MachineFailed
+	if _, err := c.patchMachine(context.Background(), machine, updated); !apierrors.IsConflict(err) {
+		t.Errorf("patchMachine() error = %v, want conflict", err)
+	}
+}
@@ -0,0 +1,69 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/cache"
+
+	"tkestack.io/tke/api/platform"
+	platformv1 "tkestack.io/tke/api/platform/v1"
+	"tkestack.io/tke/pkg/util/log"
+)
+
+// asMachine returns the object of machine informer as a v1 machine. Machines
+// of the internal or another served version are converted, so that the event
+// handlers don't panic if the informer is backed by a newer version.
+func asMachine(obj interface{}) (*platformv1.Machine, error) {
+	switch obj := obj.(type) {
+	case *platformv1.Machine:
+		return obj, nil
+	case cache.DeletedFinalStateUnknown:
+		return asMachine(obj.Obj)
+	case *platform.Machine:
+		machine := &platformv1.Machine{}
+		if err := platformv1.Convert_platform_Machine_To_v1_Machine(obj, machine, nil); err != nil {
+			return nil, err
+		}
+		return machine, nil
+	case *unstructured.Unstructured:
+		gvk := obj.GroupVersionKind()
+		if gvk.Group != platformv1.GroupName || gvk.Kind != "Machine" {
+			return nil, fmt.Errorf("unexpected object kind %s", gvk)
+		}
+		// fields unknown to v1 are dropped, the others are reconciled as is
+		machine := &platformv1.Machine{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredContent(), machine); err != nil {
+			return nil, fmt.Errorf("failed to convert machine of %s: %v", gvk.Version, err)
+		}
+		machine.SetGroupVersionKind(platformv1.SchemeGroupVersion.WithKind("Machine"))
+		return machine, nil
+	default:
+		return nil, fmt.Errorf("unexpected object type %T", obj)
+	}
+}
+
+// warnUnexpectedObject logs the object of machine informer which can't be
+// handled as a machine.
+func warnUnexpectedObject(handler string, err error) {
+	log.Warn("Ignore unexpected object of machine informer", log.String("handler", handler), log.Err(err))
+}
@@ -0,0 +1,112 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	"tkestack.io/tke/api/platform"
+	platformv1 "tkestack.io/tke/api/platform/v1"
+	"tkestack.io/tke/pkg/util/log"
+)
+
+func TestAsMachine(t *testing.T) {
+	machine := newMachineForTest("1", nil, platformv1.MachineRunning, nil)
+	machine.Name = "machine"
+	newer := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": platformv1.GroupName + "/v2",
+		"kind":       "Machine",
+		"metadata":   map[string]interface{}{"name": "machine"},
+		"spec":       map[string]interface{}{"ip": "10.0.0.1", "type": "Baremetal", "newField": "ignored"},
+		"status":     map[string]interface{}{"phase": "Running"},
+	}}
+	tests := []struct {
+		name    string
+		obj     interface{}
+		wantIP  string
+		wantErr bool
+	}{
+		{name: "v1", obj: machine, wantIP: machine.Spec.IP},
+		{name: "tombstone", obj: cache.DeletedFinalStateUnknown{Key: "machine", Obj: machine}, wantIP: machine.Spec.IP},
+		{name: "internal", obj: &platform.Machine{ObjectMeta: v1.ObjectMeta{Name: "machine"}, Spec: platform.MachineSpec{IP: "10.0.0.2"}}, wantIP: "10.0.0.2"},
+		{name: "newer version", obj: newer, wantIP: "10.0.0.1"},
+		{name: "other kind", obj: &unstructured.Unstructured{Object: map[string]interface{}{"apiVersion": "v1", "kind": "Node"}}, wantErr: true},
+		{name: "unexpected type", obj: &corev1.Pod{}, wantErr: true},
+		{name: "nil", obj: nil, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := asMachine(tt.obj)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("asMachine() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && (got.Name != "machine" || got.Spec.IP != tt.wantIP) {
+				t.Errorf("asMachine() = %s/%s, want machine/%s", got.Name, got.Spec.IP, tt.wantIP)
+			}
+		})
+	}
+}
+
+func TestController_unexpectedObject(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "log")
+	opts := log.NewOptions()
+	opts.OutputPaths = []string{output}
+	opts.DisableColor = true
+	log.Init(opts)
+	defer log.Init(log.NewOptions())
+
+	c := &Controller{
+		queue:     workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+		log:       log.WithName("MachineController"),
+		processed: newProcessedVersions(),
+	}
+	defer c.queue.ShutDown()
+	machine := newMachineForTest("1", nil, platformv1.MachineRunning, nil)
+	machine.Name = "machine"
+	unexpected := &corev1.Pod{ObjectMeta: v1.ObjectMeta{Name: "machine"}}
+
+	c.addMachine(unexpected)
+	c.updateMachine(machine, unexpected)
+	c.updateMachine(unexpected, machine)
+	c.deleteMachine(unexpected)
+	if c.queue.Len() != 0 {
+		t.Errorf("queue length = %d, want unexpected objects ignored", c.queue.Len())
+	}
+
+	log.Flush()
+	data, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Count(string(data), "Ignore unexpected object of machine informer"); got != 4 {
+		t.Errorf("logged %d warnings, want 4 in:\n%s", got, data)
+	}
+	if !strings.Contains(string(data), "warn") {
+		t.Errorf("log %s, want warnings", data)
+	}
+}
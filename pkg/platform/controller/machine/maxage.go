@@ -0,0 +1,115 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/labels"
+
+	platformv1 "tkestack.io/tke/api/platform/v1"
+	machineprovider "tkestack.io/tke/pkg/platform/provider/machine"
+	"tkestack.io/tke/pkg/util/log"
+)
+
+// ageLimit forces running machines older than maxAge to be provisioned
+// again, for immutable infrastructure. The age is counted from the last
+// provisioning of machine, or its creation if it's not recorded.
+type ageLimit struct {
+	maxAge time.Duration
+	now    func() time.Time
+}
+
+// newAgeLimit returns nil if maxAge is zero, which doesn't limit the age.
+func newAgeLimit(maxAge time.Duration) *ageLimit {
+	if maxAge <= 0 {
+		return nil
+	}
+	return &ageLimit{maxAge: maxAge, now: time.Now}
+}
+
+// recordProvisionedTime records the time of machine provisioned.
+func (l *ageLimit) recordProvisionedTime(machine *platformv1.Machine) {
+	now := time.Now
+	if l != nil {
+		now = l.now
+	}
+	if machine.Annotations == nil {
+		machine.Annotations = make(map[string]string)
+	}
+	machine.Annotations[platformv1.MachineProvisionedTimeAnno] = now().UTC().Format(time.RFC3339)
+}
+
+// expired returns true if the machine is older than the max age.
+func (l *ageLimit) expired(machine *platformv1.Machine) bool {
+	if l == nil {
+		return false
+	}
+	provisioned := machine.CreationTimestamp.Time
+	if value, ok := machine.Annotations[platformv1.MachineProvisionedTimeAnno]; ok {
+		if t, err := time.Parse(time.RFC3339, value); err == nil {
+			provisioned = t
+		}
+	}
+	return l.now().Sub(provisioned) > l.maxAge
+}
+
+// enforceMaxAge recreates the running machine older than the max age, and
+// returns true if it does. Machines of a pool are recreated one at a time,
+// the expired machine waits while any other machine of its pool is
+// unavailable, unless either is exempt from the pool coordination.
+func (c *Controller) enforceMaxAge(ctx context.Context, machine *platformv1.Machine) (bool, error) {
+	if machine.Status.Phase != platformv1.MachineRunning || !c.ageLimit.expired(machine) {
+		return false, nil
+	}
+	if pool, ok := machine.Labels[platformv1.MachinePoolLabel]; ok && !isCoordinationExempt(machine) {
+		unavailable, err := c.poolUnavailable(pool, machine.Name)
+		if err != nil {
+			return false, err
+		}
+		if unavailable > 0 {
+			log.FromContext(ctx).Info("Machine is past its max age, waiting for the pool to be available", "pool", pool, "unavailable", unavailable)
+			return false, nil
+		}
+	}
+	reason := fmt.Errorf("%w: machine is older than the max age %s", machineprovider.ErrRecreateRequired, c.ageLimit.maxAge)
+	return true, c.recreate(ctx, machine, reason)
+}
+
+// poolUnavailable returns the number of machines of the pool other than the
+// named one and the exempt ones which aren't Running.
+func (c *Controller) poolUnavailable(pool, name string) (int, error) {
+	selector := labels.SelectorFromSet(labels.Set{platformv1.MachinePoolLabel: pool})
+	machines, err := c.lister.List(selector)
+	if err != nil {
+		return 0, err
+	}
+	unavailable := 0
+	for _, machine := range machines {
+		if machine.Name == name || isCoordinationExempt(machine) {
+			continue
+		}
+		if !machine.DeletionTimestamp.IsZero() || machine.Status.Phase != platformv1.MachineRunning {
+			unavailable++
+		}
+	}
+	return unavailable, nil
+}
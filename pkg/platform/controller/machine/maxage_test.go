@@ -0,0 +1,145 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	platformv1 "tkestack.io/tke/api/platform/v1"
+	machineprovider "tkestack.io/tke/pkg/platform/provider/machine"
+	typesv1 "tkestack.io/tke/pkg/platform/types/v1"
+)
+
+func TestController_onUpdateMaxAge(t *testing.T) {
+	now := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	newMachine := func(name, pool string, phase platformv1.MachinePhase, provisioned time.Time) *platformv1.Machine {
+		machine := newMachineForTest("1", nil, phase, nil)
+		machine.Name = name
+		machine.CreationTimestamp = v1.NewTime(now.Add(-365 * 24 * time.Hour))
+		machine.Annotations = map[string]string{
+			platformv1.MachineProvisionedTimeAnno: provisioned.Format(time.RFC3339),
+		}
+		if pool != "" {
+			machine.Labels = map[string]string{platformv1.MachinePoolLabel: pool}
+		}
+		return machine
+	}
+	exempt := func(machine *platformv1.Machine) *platformv1.Machine {
+		machine.Annotations[platformv1.MachineCoordinationExemptAnno] = "true"
+		return machine
+	}
+	tests := []struct {
+		name         string
+		machine      *platformv1.Machine
+		others       []*platformv1.Machine
+		wantRecreate bool
+	}{
+		{
+			name:         "past max age",
+			machine:      newMachine("machine", "", platformv1.MachineRunning, now.Add(-31*24*time.Hour)),
+			wantRecreate: true,
+		},
+		{
+			name:    "within max age though created long ago",
+			machine: newMachine("machine", "", platformv1.MachineRunning, now.Add(-29*24*time.Hour)),
+		},
+		{
+			name:    "pool has unavailable machine",
+			machine: newMachine("machine", "pool", platformv1.MachineRunning, now.Add(-31*24*time.Hour)),
+			others:  []*platformv1.Machine{newMachine("other", "pool", platformv1.MachineInitializing, now)},
+		},
+		{
+			name:         "exempt from pool coordination",
+			machine:      exempt(newMachine("machine", "pool", platformv1.MachineRunning, now.Add(-31*24*time.Hour))),
+			others:       []*platformv1.Machine{newMachine("other", "pool", platformv1.MachineInitializing, now)},
+			wantRecreate: true,
+		},
+		{
+			name:         "unavailable machine of pool is exempt",
+			machine:      newMachine("machine", "pool", platformv1.MachineRunning, now.Add(-31*24*time.Hour)),
+			others:       []*platformv1.Machine{exempt(newMachine("canary", "pool", platformv1.MachineInitializing, now))},
+			wantRecreate: true,
+		},
+		{
+			name:         "pool is available",
+			machine:      newMachine("machine", "pool", platformv1.MachineRunning, now.Add(-31*24*time.Hour)),
+			others:       []*platformv1.Machine{newMachine("other", "pool", platformv1.MachineRunning, now)},
+			wantRecreate: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updates := 0
+			providerName := registerFakeProvider(t, &fakeProvider{
+				onUpdate: func(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster, options machineprovider.UpdateOptions) error {
+					updates++
+					return nil
+				},
+			})
+			tt.machine.Spec.Type = providerName
+			c := newControllerForTest(append(tt.others, tt.machine)...)
+			c.getCluster = fakeGetCluster
+			c.ageLimit = newAgeLimit(30 * 24 * time.Hour)
+			c.ageLimit.now = func() time.Time { return now }
+
+			if err := c.onUpdate(context.Background(), tt.machine.DeepCopy()); err != nil {
+				t.Fatalf("onUpdate() error = %v", err)
+			}
+			got, err := c.platformClient.Machines().Get(context.Background(), tt.machine.Name, v1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			recreated := got.Status.Phase == platformv1.MachineInitializing && got.GetCondition(machineprovider.ConditionTypeRecreate) != nil
+			if recreated != tt.wantRecreate {
+				t.Errorf("phase = %s, conditions = %+v, want recreated %v", got.Status.Phase, got.Status.Conditions, tt.wantRecreate)
+			}
+			if recreated == (updates == 1) {
+				t.Errorf("OnUpdate called %d times, want called only if not recreated", updates)
+			}
+		})
+	}
+}
+
+func TestAgeLimit_recordProvisionedTime(t *testing.T) {
+	now := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	l := newAgeLimit(time.Hour)
+	l.now = func() time.Time { return now }
+	machine := newMachineForTest("1", nil, platformv1.MachineRunning, nil)
+	machine.CreationTimestamp = v1.NewTime(now.Add(-2 * time.Hour))
+	if !l.expired(machine) {
+		t.Errorf("expired() = false, want the age counted from creation")
+	}
+
+	l.recordProvisionedTime(machine)
+	if l.expired(machine) {
+		t.Errorf("expired() = true, want the age counted from provisioned time")
+	}
+	l.now = func() time.Time { return now.Add(2 * time.Hour) }
+	if !l.expired(machine) {
+		t.Errorf("expired() = false, want expired an hour after provisioned")
+	}
+
+	if (*ageLimit)(nil).expired(machine) {
+		t.Errorf("expired() of no limit = true, want never expired")
+	}
+}
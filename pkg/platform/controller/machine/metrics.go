@@ -0,0 +1,173 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	platformv1 "tkestack.io/tke/api/platform/v1"
+	machineprovider "tkestack.io/tke/pkg/platform/provider/machine"
+)
+
+const metricsSubsystem = "machine_controller"
+
+var (
+	// healthChecksRunning is the number of machine health checks in flight,
+	// a value which keeps growing indicates leaked health checks.
+	healthChecksRunning = prometheus.NewGauge(prometheus.GaugeOpts{
+		Subsystem: metricsSubsystem,
+		Name:      "health_checks_running",
+		Help:      "Number of machine health checks currently running.",
+	})
+	healthChecksStarted = prometheus.NewCounter(prometheus.CounterOpts{
+		Subsystem: metricsSubsystem,
+		Name:      "health_checks_started_total",
+		Help:      "Total number of machine health checks started.",
+	})
+	healthChecksStopped = prometheus.NewCounter(prometheus.CounterOpts{
+		Subsystem: metricsSubsystem,
+		Name:      "health_checks_stopped_total",
+		Help:      "Total number of machine health checks stopped.",
+	})
+	// machineCondition is 1 if the condition of the machine is true, else 0.
+	machineCondition = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: metricsSubsystem,
+		Name:      "machine_condition",
+		Help:      "Whether the condition of the machine is true (1) or not (0).",
+	}, []string{"machine", "cluster", "condition"})
+	// cacheSyncDuration is the time taken to sync machine caches on start.
+	cacheSyncDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Subsystem: metricsSubsystem,
+		Name:      "cache_sync_duration_seconds",
+		Help:      "Time taken to sync machine caches on start, including retries.",
+		Buckets:   prometheus.ExponentialBuckets(0.1, 2, 12),
+	})
+	// providerOperationsInFlight is the number of machines mid provider
+	// operation per cluster, to see where provisioning is bottlenecked.
+	providerOperationsInFlight = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: metricsSubsystem,
+		Name:      "provider_operations_in_flight",
+		Help:      "Number of machines currently in provider operations per cluster.",
+	}, []string{"cluster", "operation"})
+	// lifecycleRecordsDropped counts the lifecycle records dropped as the
+	// stream is full.
+	lifecycleRecordsDropped = prometheus.NewCounter(prometheus.CounterOpts{
+		Subsystem: metricsSubsystem,
+		Name:      "lifecycle_records_dropped_total",
+		Help:      "Total number of machine lifecycle records dropped as the stream is full.",
+	})
+	// machineRetries counts the retries of failed machines by error category.
+	machineRetries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: metricsSubsystem,
+		Name:      "retries_total",
+		Help:      "Total number of retries of failed machines by error category.",
+	}, []string{"category"})
+	// machineRetriesExhausted counts the failed machines which aren't
+	// retried as the retry budget of the error category is exhausted.
+	machineRetriesExhausted = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: metricsSubsystem,
+		Name:      "retry_budget_exhausted_total",
+		Help:      "Total number of failed machines not retried as the retry budget of the error category is exhausted.",
+	}, []string{"category"})
+	// timeToRunning is the time taken for machines to be Running since they
+	// are created or recreated, which reveals the provisioning latency.
+	timeToRunning = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Subsystem: metricsSubsystem,
+		Name:      "time_to_running_seconds",
+		Help:      "Time taken for machines to be Running since created or recreated, per provider type.",
+		Buckets:   prometheus.ExponentialBuckets(30, 2, 10),
+	}, []string{"type"})
+)
+
+// Labels of provider operations.
+const (
+	providerOperationCreate = "OnCreate"
+	providerOperationUpdate = "OnUpdate"
+)
+
+const (
+	conditionMetricProvisioning = "Provisioning"
+	conditionMetricReady        = "Ready"
+)
+
+func init() {
+	prometheus.MustRegister(healthChecksRunning, healthChecksStarted, healthChecksStopped, machineCondition, cacheSyncDuration, providerOperationsInFlight, lifecycleRecordsDropped, timeToRunning,
+		machineRetries, machineRetriesExhausted)
+}
+
+// exportConditionMetrics exports the key conditions of the machine, which
+// are the health check condition and whether it's provisioning or ready.
+func exportConditionMetrics(machine *platformv1.Machine) {
+	healthy := false
+	if condition := machine.GetCondition(machineprovider.ConditionTypeHealthCheck); condition != nil {
+		healthy = condition.Status == platformv1.ConditionTrue
+	}
+	conditions := map[string]bool{
+		machineprovider.ConditionTypeHealthCheck: healthy,
+		conditionMetricProvisioning:              machine.Status.Phase == platformv1.MachineInitializing,
+		conditionMetricReady:                     machine.Status.Phase == platformv1.MachineRunning,
+	}
+	for condition, value := range conditions {
+		machineCondition.WithLabelValues(machine.Name, machine.Spec.ClusterName, condition).Set(boolToFloat64(value))
+	}
+}
+
+// observeTimeToRunning observes the time taken for the machine just Running
+// to be provisioned, since it's recreated if it is, else since it's created.
+func observeTimeToRunning(machine *platformv1.Machine) {
+	if machine.Status.Phase != platformv1.MachineRunning {
+		return
+	}
+	start := machine.CreationTimestamp
+	if condition := machine.GetCondition(machineprovider.ConditionTypeRecreate); condition != nil && !condition.LastTransitionTime.IsZero() {
+		start = condition.LastTransitionTime
+	}
+	if start.IsZero() {
+		return
+	}
+	timeToRunning.WithLabelValues(machine.Spec.Type).Observe(time.Since(start.Time).Seconds())
+}
+
+// deleteConditionMetrics removes the exported conditions of the machine.
+func deleteConditionMetrics(machine *platformv1.Machine) {
+	for _, condition := range []string{machineprovider.ConditionTypeHealthCheck, conditionMetricProvisioning, conditionMetricReady} {
+		machineCondition.DeleteLabelValues(machine.Name, machine.Spec.ClusterName, condition)
+	}
+}
+
+func boolToFloat64(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// trackProviderOperation wraps the provider operation fn to count it in
+// flight until it returns, even if it's left running after timeout.
+func trackProviderOperation(cluster, operation string, fn func(context.Context, *platformv1.Machine) error) func(context.Context, *platformv1.Machine) error {
+	return func(ctx context.Context, machine *platformv1.Machine) error {
+		gauge := providerOperationsInFlight.WithLabelValues(cluster, operation)
+		gauge.Inc()
+		defer gauge.Dec()
+		return fn(ctx, machine)
+	}
+}
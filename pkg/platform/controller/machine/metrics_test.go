@@ -0,0 +1,223 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	platformv1 "tkestack.io/tke/api/platform/v1"
+	machineprovider "tkestack.io/tke/pkg/platform/provider/machine"
+	typesv1 "tkestack.io/tke/pkg/platform/types/v1"
+)
+
+func TestRunHealthCheck_metrics(t *testing.T) {
+	running := testutil.ToFloat64(healthChecksRunning)
+	started := testutil.ToFloat64(healthChecksStarted)
+	stopped := testutil.ToFloat64(healthChecksStopped)
+
+	var runningDuringCheck float64
+	provider := &fakeProvider{
+		onHealthCheck: func(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster) *platformv1.Machine {
+			runningDuringCheck = testutil.ToFloat64(healthChecksRunning)
+			return machine
+		},
+	}
+	machine := newMachineForTest("1", nil, platformv1.MachineRunning, nil)
+	runHealthCheck(context.Background(), provider, machine, &typesv1.Cluster{}, machineprovider.HealthCheckOptions{})
+
+	if runningDuringCheck != running+1 {
+		t.Errorf("running health checks = %v during check, want %v", runningDuringCheck, running+1)
+	}
+	if got := testutil.ToFloat64(healthChecksRunning); got != running {
+		t.Errorf("running health checks = %v after check, want %v", got, running)
+	}
+	if got := testutil.ToFloat64(healthChecksStarted); got != started+1 {
+		t.Errorf("started health checks = %v, want %v", got, started+1)
+	}
+	if got := testutil.ToFloat64(healthChecksStopped); got != stopped+1 {
+		t.Errorf("stopped health checks = %v, want %v", got, stopped+1)
+	}
+}
+
+func TestExportConditionMetrics(t *testing.T) {
+	machine := newMachineForTest("1", nil, platformv1.MachineRunning, nil)
+	machine.Name = "machine-metrics"
+	healthCheck := func() float64 {
+		return testutil.ToFloat64(machineCondition.WithLabelValues(machine.Name, machine.Spec.ClusterName, machineprovider.ConditionTypeHealthCheck))
+	}
+
+	exportConditionMetrics(machine)
+	if got := healthCheck(); got != 1 {
+		t.Errorf("health check condition metric = %v, want 1", got)
+	}
+
+	machine.Status.Phase = platformv1.MachineFailed
+	machine.SetCondition(platformv1.MachineCondition{
+		Type:   machineprovider.ConditionTypeHealthCheck,
+		Status: platformv1.ConditionFalse,
+	})
+	exportConditionMetrics(machine)
+	if got := healthCheck(); got != 0 {
+		t.Errorf("health check condition metric = %v after condition flips, want 0", got)
+	}
+	if got := testutil.ToFloat64(machineCondition.WithLabelValues(machine.Name, machine.Spec.ClusterName, conditionMetricReady)); got != 0 {
+		t.Errorf("ready condition metric = %v for failed machine, want 0", got)
+	}
+
+	count := testutil.CollectAndCount(machineCondition)
+	deleteConditionMetrics(machine)
+	if got := testutil.CollectAndCount(machineCondition); got != count-3 {
+		t.Errorf("condition metrics count = %d after machine is deleted, want %d", got, count-3)
+	}
+}
+
+func TestController_callProviderOperationsInFlight(t *testing.T) {
+	inFlight := func(cluster, operation string) float64 {
+		return testutil.ToFloat64(providerOperationsInFlight.WithLabelValues(cluster, operation))
+	}
+	c := &Controller{}
+	release := make(chan struct{})
+	var started, done sync.WaitGroup
+	call := func(cluster, operation string) {
+		machine := newMachineForTest("1", nil, platformv1.MachineInitializing, nil)
+		machine.Spec.ClusterName = cluster
+		started.Add(1)
+		done.Add(1)
+		go func() {
+			defer done.Done()
+			_, _ = c.callProvider(context.Background(), operation, machine, func(ctx context.Context, machine *platformv1.Machine) error {
+				started.Done()
+				<-release
+				return nil
+			})
+		}()
+	}
+	call("cls-inflight-a", providerOperationCreate)
+	call("cls-inflight-a", providerOperationCreate)
+	call("cls-inflight-b", providerOperationUpdate)
+	started.Wait()
+
+	if got := inFlight("cls-inflight-a", providerOperationCreate); got != 2 {
+		t.Errorf("OnCreate in flight of cluster a = %v, want 2", got)
+	}
+	if got := inFlight("cls-inflight-b", providerOperationUpdate); got != 1 {
+		t.Errorf("OnUpdate in flight of cluster b = %v, want 1", got)
+	}
+	if got := inFlight("cls-inflight-b", providerOperationCreate); got != 0 {
+		t.Errorf("OnCreate in flight of cluster b = %v, want 0", got)
+	}
+
+	close(release)
+	done.Wait()
+	if got := inFlight("cls-inflight-a", providerOperationCreate) + inFlight("cls-inflight-b", providerOperationUpdate); got != 0 {
+		t.Errorf("operations in flight = %v after they return, want 0", got)
+	}
+}
+
+func TestController_callProviderHungOperationInFlight(t *testing.T) {
+	c := &Controller{providerTimeout: 10 * time.Millisecond}
+	machine := newMachineForTest("1", nil, platformv1.MachineRunning, nil)
+	machine.Spec.ClusterName = "cls-inflight-hung"
+	release := make(chan struct{})
+	returned := make(chan struct{})
+	_, err := c.callProvider(context.Background(), providerOperationUpdate, machine, func(ctx context.Context, machine *platformv1.Machine) error {
+		defer close(returned)
+		<-release
+		return nil
+	})
+	if err == nil {
+		t.Fatalf("callProvider() want timeout error")
+	}
+	gauge := providerOperationsInFlight.WithLabelValues(machine.Spec.ClusterName, providerOperationUpdate)
+	if got := testutil.ToFloat64(gauge); got != 1 {
+		t.Errorf("operations in flight = %v while the operation hangs, want 1", got)
+	}
+	close(release)
+	<-returned
+	if err := wait.PollImmediate(time.Millisecond, time.Second, func() (bool, error) {
+		return testutil.ToFloat64(gauge) == 0, nil
+	}); err != nil {
+		t.Errorf("operations in flight = %v after the operation returns, want 0", testutil.ToFloat64(gauge))
+	}
+}
+
+func TestController_onCreateTimeToRunning(t *testing.T) {
+	install := func(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster) error {
+		return nil
+	}
+	join := func(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster) error {
+		return nil
+	}
+	providerName := registerFakeProvider(t, &fakeProvider{
+		DelegateProvider: machineprovider.DelegateProvider{
+			CreateHandlers: []machineprovider.Handler{install, join},
+		},
+	})
+	machine := newMachineForTest("1", nil, platformv1.MachineInitializing, []platformv1.MachineCondition{})
+	machine.Name = "machine"
+	machine.Spec.Type = providerName
+	machine.CreationTimestamp = v1.NewTime(time.Now().Add(-5 * time.Minute))
+	c := newControllerForTest(machine)
+	c.getCluster = fakeGetCluster
+	observed := func() *dto.Histogram {
+		metric := &dto.Metric{}
+		if err := timeToRunning.WithLabelValues(providerName).(prometheus.Histogram).Write(metric); err != nil {
+			t.Fatal(err)
+		}
+		return metric.GetHistogram()
+	}
+	latest := func() *platformv1.Machine {
+		got, err := c.platformClient.Machines().Get(context.Background(), machine.Name, v1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return got
+	}
+
+	_ = c.onCreate(context.Background(), latest())
+	if got := latest().Status.Phase; got != platformv1.MachineInitializing {
+		t.Fatalf("phase = %s after first step, want %s", got, platformv1.MachineInitializing)
+	}
+	if count := observed().GetSampleCount(); count != 0 {
+		t.Errorf("time to running observed %d times while initializing, want none", count)
+	}
+
+	if err := c.onCreate(context.Background(), latest()); err != nil {
+		t.Fatalf("onCreate() error = %v", err)
+	}
+	if got := latest().Status.Phase; got != platformv1.MachineRunning {
+		t.Fatalf("phase = %s after last step, want %s", got, platformv1.MachineRunning)
+	}
+	histogram := observed()
+	if histogram.GetSampleCount() != 1 {
+		t.Fatalf("time to running observed %d times, want once on first Running transition", histogram.GetSampleCount())
+	}
+	if sum := histogram.GetSampleSum(); sum < 300 || sum > 360 {
+		t.Errorf("time to running = %.0fs, want about 300s since creation", sum)
+	}
+}
@@ -0,0 +1,144 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	platformv1 "tkestack.io/tke/api/platform/v1"
+	clusterapi "tkestack.io/tke/pkg/platform/apiserver/cluster"
+	clusterprovider "tkestack.io/tke/pkg/platform/provider/cluster"
+	machineprovider "tkestack.io/tke/pkg/platform/provider/machine"
+	typesv1 "tkestack.io/tke/pkg/platform/types/v1"
+	"tkestack.io/tke/pkg/util/log"
+)
+
+const (
+	conditionTypeMigration = "Migration"
+
+	reasonMigrated              = "Migrated"
+	reasonMigrationNotConfirmed = "MigrationNotConfirmed"
+)
+
+// recordCluster records the cluster which the machine is provisioned into.
+func recordCluster(machine *platformv1.Machine) {
+	metav1.SetMetaDataAnnotation(&machine.ObjectMeta, platformv1.MachineClusterAnno, machine.Spec.ClusterName)
+}
+
+// migrationSource returns the cluster which the machine is provisioned into
+// if the cluster name of machine has been changed since.
+func migrationSource(machine *platformv1.Machine) (string, bool) {
+	from, ok := machine.Annotations[platformv1.MachineClusterAnno]
+	if !ok || from == machine.Spec.ClusterName {
+		return "", false
+	}
+	return from, true
+}
+
+// migrate moves the machine to the cluster of its changed cluster name. The
+// machine is removed from the cluster it's provisioned into, and provisioned
+// again into the new one from Initializing. Nothing is done unless the move
+// is confirmed by the migrate-to annotation.
+func (c *Controller) migrate(ctx context.Context, provider machineprovider.Provider, machine *platformv1.Machine, from string) error {
+	logger := log.FromContext(ctx).WithValues("from", from, "to", machine.Spec.ClusterName)
+	if machine.Annotations[platformv1.MachineMigrateToAnno] != machine.Spec.ClusterName {
+		message := fmt.Sprintf("cluster name is changed from %s without annotation %s=%s", from, platformv1.MachineMigrateToAnno, machine.Spec.ClusterName)
+		if condition := machine.GetCondition(conditionTypeMigration); condition != nil &&
+			condition.Status == platformv1.ConditionFalse && condition.Reason == reasonMigrationNotConfirmed && condition.Message == message {
+			return nil
+		}
+		logger.Info("Machine cluster name is changed without confirmation, skip migration")
+		machine.SetCondition(platformv1.MachineCondition{
+			Type:    conditionTypeMigration,
+			Status:  platformv1.ConditionFalse,
+			Reason:  reasonMigrationNotConfirmed,
+			Message: message,
+		})
+		_, err := c.platformClient.Machines().UpdateStatus(ctx, machine, metav1.UpdateOptions{})
+		return err
+	}
+
+	cluster, err := c.getCluster(ctx, c.platformClient, from, clusterprovider.AdminUsername)
+	if err != nil {
+		return err
+	}
+	if err := c.removeFromCluster(ctx, provider, machine, cluster); err != nil {
+		return err
+	}
+	logger.Info("Machine is removed from the old cluster, provision it into the new one")
+
+	oldPhase, oldHealth := machine.Status.Phase, healthStatus(machine)
+	message := fmt.Sprintf("machine is migrated from cluster %s", from)
+	machine.Status.Phase = platformv1.MachineInitializing
+	machine.Status.Conditions = nil
+	machine.SetCondition(platformv1.MachineCondition{
+		Type:    machineprovider.ConditionTypeRecreate,
+		Status:  platformv1.ConditionTrue,
+		Reason:  reasonMigrated,
+		Message: message,
+	})
+	machine.Status.Reason = reasonMigrated
+	machine.Status.Message = message
+	recordCluster(machine)
+	// the machine isn't owned by the old cluster anymore
+	removeClusterOwners(machine, "")
+	delete(machine.Annotations, platformv1.MachineMigrateToAnno)
+	// the node name was resolved in the old cluster
+	machine.Status.NodeName = ""
+	if _, err := c.platformClient.Machines().Update(ctx, machine, metav1.UpdateOptions{}); err != nil {
+		return err
+	}
+	c.recordLifecycle(machine, oldPhase, oldHealth)
+	return nil
+}
+
+// removeFromCluster drains the node of machine, cleans the machine up by the
+// provider and deletes the node from the cluster.
+func (c *Controller) removeFromCluster(ctx context.Context, provider machineprovider.Provider, machine *platformv1.Machine, cluster *typesv1.Cluster) error {
+	client, err := c.clientsetFor(cluster)
+	if err != nil {
+		return err
+	}
+	node, err := getNode(ctx, client, machine)
+	switch {
+	case apierrors.IsNotFound(err):
+		node = nil
+	case err != nil:
+		return err
+	default:
+		if err := clusterapi.DrainNodeWithGracePeriod(ctx, client, node, drainGracePeriodSeconds(machine)); err != nil {
+			return err
+		}
+	}
+	if err := provider.OnDelete(ctx, machine, cluster); err != nil {
+		return err
+	}
+	if node == nil {
+		return nil
+	}
+	err = client.CoreV1().Nodes().Delete(ctx, node.Name, metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
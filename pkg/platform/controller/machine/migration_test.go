@@ -0,0 +1,133 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	fakeplatformv1 "tkestack.io/tke/api/client/clientset/versioned/typed/platform/v1/fake"
+	platformv1 "tkestack.io/tke/api/platform/v1"
+	machineprovider "tkestack.io/tke/pkg/platform/provider/machine"
+	typesv1 "tkestack.io/tke/pkg/platform/types/v1"
+)
+
+func TestController_onUpdateMigration(t *testing.T) {
+	tests := []struct {
+		name        string
+		confirmed   bool
+		wantMigrate bool
+	}{
+		{name: "confirmed", confirmed: true, wantMigrate: true},
+		{name: "not confirmed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deleted := false
+			providerName := registerFakeProvider(t, &fakeProvider{
+				DelegateProvider: machineprovider.DelegateProvider{
+					DeleteHandlers: []machineprovider.Handler{
+						func(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster) error {
+							if cluster.Name != "global" {
+								t.Errorf("machine is cleaned up in cluster %s, want global", cluster.Name)
+							}
+							deleted = true
+							return nil
+						},
+					},
+				},
+			})
+			machine := newMachineForTest("1", nil, platformv1.MachineRunning, nil)
+			machine.Name = "machine"
+			machine.Spec.Type = providerName
+			recordCluster(machine)
+			machine.Spec.ClusterName = "new"
+			if tt.confirmed {
+				machine.Annotations[platformv1.MachineMigrateToAnno] = "new"
+			}
+			c := newControllerForTest(machine)
+			c.getCluster = fakeGetCluster
+			node := &corev1.Node{ObjectMeta: v1.ObjectMeta{Name: machine.Spec.IP}}
+			client := kubefake.NewSimpleClientset(node)
+			c.clientsetFor = func(cluster *typesv1.Cluster) (kubernetes.Interface, error) {
+				return client, nil
+			}
+
+			if err := c.onUpdate(context.Background(), machine.DeepCopy()); err != nil {
+				t.Fatalf("onUpdate() error = %v", err)
+			}
+			got, err := c.platformClient.Machines().Get(context.Background(), machine.Name, v1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = client.CoreV1().Nodes().Get(context.Background(), node.Name, v1.GetOptions{})
+			if nodeDeleted := apierrors.IsNotFound(err); nodeDeleted != tt.wantMigrate {
+				t.Errorf("node deleted = %v, want %v", nodeDeleted, tt.wantMigrate)
+			}
+			if deleted != tt.wantMigrate {
+				t.Errorf("provider cleaned up = %v, want %v", deleted, tt.wantMigrate)
+			}
+
+			if !tt.wantMigrate {
+				if got.Status.Phase != platformv1.MachineRunning {
+					t.Errorf("phase = %s, want %s", got.Status.Phase, platformv1.MachineRunning)
+				}
+				condition := got.GetCondition(conditionTypeMigration)
+				if condition == nil || condition.Reason != reasonMigrationNotConfirmed {
+					t.Errorf("migration condition = %+v, want not confirmed", condition)
+				}
+				if cluster := got.Annotations[platformv1.MachineClusterAnno]; cluster != "global" {
+					t.Errorf("recorded cluster = %q, want global", cluster)
+				}
+
+				// the fake clientset doesn't bump the resourceVersion on writes
+				got.ResourceVersion = "2"
+				fakeClient := c.platformClient.(*fakeplatformv1.FakePlatformV1)
+				fakeClient.ClearActions()
+				if err := c.onUpdate(context.Background(), got); err != nil {
+					t.Fatalf("onUpdate() error = %v", err)
+				}
+				for _, action := range fakeClient.Actions() {
+					if action.GetVerb() == "update" || action.GetVerb() == "patch" {
+						t.Errorf("second reconcile wrote %s %s, want no write for the unchanged condition", action.GetVerb(), action.GetSubresource())
+					}
+				}
+				return
+			}
+			if got.Status.Phase != platformv1.MachineInitializing {
+				t.Errorf("phase = %s, want %s to provision into the new cluster", got.Status.Phase, platformv1.MachineInitializing)
+			}
+			condition := got.GetCondition(machineprovider.ConditionTypeRecreate)
+			if condition == nil || condition.Reason != reasonMigrated {
+				t.Errorf("recreate condition = %+v, want migrated", condition)
+			}
+			if cluster := got.Annotations[platformv1.MachineClusterAnno]; cluster != "new" {
+				t.Errorf("recorded cluster = %q, want new", cluster)
+			}
+			if _, ok := got.Annotations[platformv1.MachineMigrateToAnno]; ok {
+				t.Errorf("annotation %s should be removed after migration", platformv1.MachineMigrateToAnno)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"context"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	platformv1 "tkestack.io/tke/api/platform/v1"
+	"tkestack.io/tke/pkg/util/log"
+)
+
+// nodeAnnotationChanges returns the changes to mirror the machine annotations
+// with any of prefixes onto its node, e.g. billing tags. The node annotations
+// with the prefixes are owned by the machine, those missing from the machine
+// are removed from the node, so the prefixes should be dedicated to the
+// mirrored annotations.
+func nodeAnnotationChanges(ctx context.Context, machine *platformv1.Machine, node *corev1.Node, prefixes []string) map[string]interface{} {
+	if len(prefixes) == 0 {
+		return nil
+	}
+	changes := map[string]interface{}{}
+	for key, value := range machine.Annotations {
+		if hasAnyPrefix(key, prefixes) {
+			if current, ok := node.Annotations[key]; !ok || current != value {
+				changes[key] = value
+			}
+		}
+	}
+	for key := range node.Annotations {
+		if _, ok := machine.Annotations[key]; !ok && hasAnyPrefix(key, prefixes) {
+			changes[key] = nil
+		}
+	}
+	if len(changes) != 0 {
+		log.FromContext(ctx).Info("Mirror machine annotations to node", "node", node.Name, "changes", len(changes))
+	}
+	return changes
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,106 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+
+	"tkestack.io/tke/api/client/clientset/versioned/fake"
+	"tkestack.io/tke/api/client/informers/externalversions"
+	platformv1 "tkestack.io/tke/api/platform/v1"
+	machineconfig "tkestack.io/tke/pkg/platform/controller/machine/config"
+	typesv1 "tkestack.io/tke/pkg/platform/types/v1"
+)
+
+func TestNodeAnnotationChanges(t *testing.T) {
+	prefixes := []string{"billing.example.com/", "owner.example.com/"}
+	node := &corev1.Node{
+		ObjectMeta: v1.ObjectMeta{
+			Name: "10.0.0.1",
+			Annotations: map[string]string{
+				"billing.example.com/team":     "old-team",
+				"billing.example.com/expiry":   "2021-01-01",
+				"node.alpha.kubernetes.io/ttl": "0",
+			},
+		},
+	}
+	machine := newMachineForTest("1", nil, platformv1.MachineRunning, nil)
+	machine.Annotations = map[string]string{
+		"billing.example.com/team":               "new-team",
+		"owner.example.com/contact":              "ops",
+		platformv1.MachineCoordinationExemptAnno: "true",
+	}
+
+	got := nodeAnnotationChanges(context.Background(), machine, node, prefixes)
+	want := map[string]interface{}{
+		"billing.example.com/team":   "new-team",
+		"billing.example.com/expiry": nil,
+		"owner.example.com/contact":  "ops",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("nodeAnnotationChanges() = %v, want %v", got, want)
+	}
+
+	node.Annotations = map[string]string{
+		"billing.example.com/team":     "new-team",
+		"owner.example.com/contact":    "ops",
+		"node.alpha.kubernetes.io/ttl": "0",
+	}
+	if got := nodeAnnotationChanges(context.Background(), machine, node, prefixes); len(got) != 0 {
+		t.Errorf("nodeAnnotationChanges() = %v for mirrored annotations, want none", got)
+	}
+	if got := nodeAnnotationChanges(context.Background(), machine, node, nil); len(got) != 0 {
+		t.Errorf("nodeAnnotationChanges() without prefixes = %v, want none", got)
+	}
+}
+
+func TestController_syncNodeInfoAnnotationPrefixes(t *testing.T) {
+	machine := newMachineForTest("1", nil, platformv1.MachineRunning, nil)
+	machine.Name = "machine"
+	machine.Annotations = map[string]string{"billing.example.com/team": "infra"}
+	client := fake.NewSimpleClientset(machine)
+	informerFactory := externalversions.NewSharedInformerFactory(client, 0)
+	c := NewController(client.PlatformV1(), nil, informerFactory.Platform().V1().Machines(),
+		machineconfig.MachineControllerConfiguration{
+			BucketRateLimiterLimit: 10,
+			BucketRateLimiterBurst: 100,
+			NodeAnnotationPrefixes: []string{"billing.example.com/"},
+		}, platformv1.MachineFinalize)
+	defer c.queue.ShutDown()
+	nodeClient := kubefake.NewSimpleClientset(&corev1.Node{ObjectMeta: v1.ObjectMeta{Name: machine.Spec.IP}})
+	c.clientsetFor = func(cluster *typesv1.Cluster) (kubernetes.Interface, error) {
+		return nodeClient, nil
+	}
+
+	c.syncNodeInfo(context.Background(), machine, &typesv1.Cluster{}, nil)
+	node, err := nodeClient.CoreV1().Nodes().Get(context.Background(), machine.Spec.IP, v1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := node.Annotations["billing.example.com/team"]; got != "infra" {
+		t.Errorf("node annotation = %q, want the machine annotation mirrored by the configured prefix", got)
+	}
+}
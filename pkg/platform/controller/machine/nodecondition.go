@@ -0,0 +1,94 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"context"
+	"encoding/json"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	platformv1 "tkestack.io/tke/api/platform/v1"
+	machineprovider "tkestack.io/tke/pkg/platform/provider/machine"
+	typesv1 "tkestack.io/tke/pkg/platform/types/v1"
+	"tkestack.io/tke/pkg/util/log"
+)
+
+// reasonMachineHealthCheck is the reason of the node health condition if
+// the health check condition of machine has no reason, e.g. when healthy.
+const reasonMachineHealthCheck = "MachineHealthCheck"
+
+// syncNodeHealthCondition mirrors the health check condition of machine onto
+// its node as the configured node condition, for operators who monitor nodes
+// rather than machines. The node status is patched only if the status, reason
+// or message of the condition changes. Failures are only logged, the machine
+// is synced again on next reconcile.
+func (c *Controller) syncNodeHealthCondition(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster) {
+	if c.nodeHealthCondition == "" {
+		return
+	}
+	health := machine.GetCondition(machineprovider.ConditionTypeHealthCheck)
+	if health == nil {
+		return
+	}
+	client, err := c.clientsetFor(cluster)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "Failed to get cluster clientset for node health condition")
+		return
+	}
+	node, err := getNode(ctx, client, machine)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			log.FromContext(ctx).Error(err, "Failed to get node of machine for node health condition")
+		}
+		return
+	}
+
+	condition := corev1.NodeCondition{
+		Type:               c.nodeHealthCondition,
+		Status:             corev1.ConditionStatus(health.Status),
+		LastHeartbeatTime:  metav1.Now(),
+		LastTransitionTime: health.LastTransitionTime,
+		Reason:             health.Reason,
+		Message:            health.Message,
+	}
+	if condition.Reason == "" {
+		condition.Reason = reasonMachineHealthCheck
+	}
+	for _, existing := range node.Status.Conditions {
+		if existing.Type == condition.Type && existing.Status == condition.Status &&
+			existing.Reason == condition.Reason && existing.Message == condition.Message {
+			return
+		}
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"status": map[string]interface{}{
+			"conditions": []corev1.NodeCondition{condition},
+		},
+	})
+	if err != nil {
+		log.FromContext(ctx).Error(err, "Failed to marshal node health condition")
+		return
+	}
+	if _, err := client.CoreV1().Nodes().PatchStatus(ctx, node.Name, patch); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to write node health condition of machine")
+	}
+}
@@ -0,0 +1,107 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+
+	platformv1 "tkestack.io/tke/api/platform/v1"
+	machineprovider "tkestack.io/tke/pkg/platform/provider/machine"
+	typesv1 "tkestack.io/tke/pkg/platform/types/v1"
+)
+
+func TestController_syncNodeHealthCondition(t *testing.T) {
+	healthy := true
+	providerName := registerFakeProvider(t, &fakeProvider{
+		onHealthCheck: func(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster) *platformv1.Machine {
+			if healthy {
+				machine.SetCondition(platformv1.MachineCondition{
+					Type:   machineprovider.ConditionTypeHealthCheck,
+					Status: platformv1.ConditionTrue,
+				})
+				return machine
+			}
+			machine.Status.Phase = platformv1.MachineFailed
+			machine.SetCondition(platformv1.MachineCondition{
+				Type:    machineprovider.ConditionTypeHealthCheck,
+				Status:  platformv1.ConditionFalse,
+				Reason:  machineprovider.FailedHealthCheckReason,
+				Message: "kubelet stopped posting node status",
+			})
+			return machine
+		},
+	})
+	machine := newMachineForTest("1", nil, platformv1.MachineRunning, nil)
+	machine.Name = "machine"
+	machine.Spec.Type = providerName
+	c := newControllerForTest(machine)
+	c.getCluster = fakeGetCluster
+	c.nodeHealthCondition = "MachineHealthy"
+	client := kubefake.NewSimpleClientset(&corev1.Node{ObjectMeta: v1.ObjectMeta{Name: machine.Spec.IP}})
+	c.clientsetFor = func(cluster *typesv1.Cluster) (kubernetes.Interface, error) {
+		return client, nil
+	}
+	reconcile := func() (*platformv1.Machine, *corev1.NodeCondition) {
+		current, err := c.platformClient.Machines().Get(context.Background(), machine.Name, v1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := c.onUpdate(context.Background(), current); err != nil {
+			t.Fatalf("onUpdate() error = %v", err)
+		}
+		if current, err = c.platformClient.Machines().Get(context.Background(), machine.Name, v1.GetOptions{}); err != nil {
+			t.Fatal(err)
+		}
+		node, err := client.CoreV1().Nodes().Get(context.Background(), machine.Spec.IP, v1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := range node.Status.Conditions {
+			if node.Status.Conditions[i].Type == c.nodeHealthCondition {
+				return current, &node.Status.Conditions[i]
+			}
+		}
+		return current, nil
+	}
+
+	got, condition := reconcile()
+	if condition == nil || condition.Status != corev1.ConditionTrue || condition.Reason != reasonMachineHealthCheck {
+		t.Fatalf("node condition = %+v, want True mirroring the machine", condition)
+	}
+	if health := got.GetCondition(machineprovider.ConditionTypeHealthCheck); health == nil || health.Status != platformv1.ConditionTrue {
+		t.Errorf("machine health condition = %+v, want True", health)
+	}
+
+	healthy = false
+	got, condition = reconcile()
+	health := got.GetCondition(machineprovider.ConditionTypeHealthCheck)
+	if health == nil || health.Status != platformv1.ConditionFalse {
+		t.Fatalf("machine health condition = %+v, want False", health)
+	}
+	if condition == nil || condition.Status != corev1.ConditionFalse ||
+		condition.Reason != health.Reason || condition.Message != health.Message {
+		t.Errorf("node condition = %+v, want it updated to %+v", condition, health)
+	}
+}
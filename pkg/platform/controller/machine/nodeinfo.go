@@ -0,0 +1,115 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"context"
+	"encoding/json"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+
+	platformv1 "tkestack.io/tke/api/platform/v1"
+	typesv1 "tkestack.io/tke/pkg/platform/types/v1"
+	"tkestack.io/tke/pkg/util/log"
+)
+
+// syncNodeInfo syncs a running machine with its node, the node got by the
+// health check is reused if not nil. Failures are only logged, the machine
+// is synced again on next reconcile.
+func (c *Controller) syncNodeInfo(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster, node *corev1.Node) {
+	if machine.Status.Phase != platformv1.MachineRunning {
+		return
+	}
+	client, err := c.clientsetFor(cluster)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "Failed to get cluster clientset for node info")
+		return
+	}
+	if node == nil {
+		node, err = getNode(ctx, client, machine)
+		if err != nil {
+			if !apierrors.IsNotFound(err) {
+				log.FromContext(ctx).Error(err, "Failed to get node info of machine")
+			}
+			return
+		}
+	}
+	recordNodeInfo(machine, node)
+	c.syncSustainedPressure(machine, node)
+	if err := c.syncPodCount(ctx, client, machine, node); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to count pods on node of machine")
+	}
+	if err := c.syncNodeMetadata(ctx, client, machine, node); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to apply machine labels and annotations to node of machine")
+	}
+}
+
+// recordNodeInfo records the kubelet version, OS image and kernel version
+// reported by the node, so that version skew and the fleet inventory are
+// visible per machine.
+func recordNodeInfo(machine *platformv1.Machine, node *corev1.Node) {
+	if version := node.Status.NodeInfo.KubeletVersion; version != "" {
+		machine.Status.MachineInfo.KubeletVersion = version
+	}
+	if image := node.Status.NodeInfo.OSImage; image != "" {
+		machine.Status.MachineInfo.OSImage = image
+	}
+	if version := node.Status.NodeInfo.KernelVersion; version != "" {
+		machine.Status.MachineInfo.KernelVersion = version
+	}
+}
+
+// syncNodeMetadata applies the name and pool labels and the configured
+// annotations of machine to its node in a single patch, if any changes.
+func (c *Controller) syncNodeMetadata(ctx context.Context, client kubernetes.Interface, machine *platformv1.Machine, node *corev1.Node) error {
+	labels := map[string]interface{}{}
+	nameLabelChanges(machine, node, labels)
+	poolLabelChanges(ctx, machine, node, labels)
+	annotations := nodeAnnotationChanges(ctx, machine, node, c.nodeAnnotationPrefixes)
+	if len(labels) == 0 && len(annotations) == 0 {
+		return nil
+	}
+
+	metadata := map[string]interface{}{}
+	if len(labels) != 0 {
+		metadata["labels"] = labels
+	}
+	if len(annotations) != 0 {
+		metadata["annotations"] = annotations
+	}
+	patch, err := json.Marshal(map[string]interface{}{"metadata": metadata})
+	if err != nil {
+		return err
+	}
+	_, err = client.CoreV1().Nodes().Patch(ctx, node.Name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
+
+// nameLabelChanges adds the name label of machine to changes unless the node
+// has it, so that nodes could be mapped back to machines. The label is
+// restored if removed.
+func nameLabelChanges(machine *platformv1.Machine, node *corev1.Node, changes map[string]interface{}) {
+	if node.Labels[platformv1.MachineNameLabel] != machine.Name {
+		changes[platformv1.MachineNameLabel] = machine.Name
+	}
+}
@@ -0,0 +1,80 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	platformv1 "tkestack.io/tke/api/platform/v1"
+	typesv1 "tkestack.io/tke/pkg/platform/types/v1"
+	"tkestack.io/tke/pkg/util/log"
+)
+
+const (
+	conditionTypeWaitingForNode = "WaitingForNode"
+	reasonNodeNotReady          = "NodeNotReady"
+	reasonNodeNotFound          = "NodeNotFound"
+)
+
+// errWaitingForNode is returned by onUpdate when the provider isn't called as
+// the node of machine isn't ready, the machine is requeued with backoff.
+var errWaitingForNode = errors.New("machine is waiting for its node to be ready")
+
+// waitForNode returns errWaitingForNode and marks the machine by a condition
+// if the updates require a ready node and the node of machine isn't ready,
+// since the provider may hang or fail against an unreachable node.
+func (c *Controller) waitForNode(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster) error {
+	if !c.updateRequiresReadyNode {
+		return nil
+	}
+	client, err := c.clientsetFor(cluster)
+	if err != nil {
+		return err
+	}
+	node, err := getNode(ctx, client, machine)
+	reason := ""
+	var message string
+	switch {
+	case apierrors.IsNotFound(err):
+		reason = reasonNodeNotFound
+		message = fmt.Sprintf("node of machine %s is not found", machine.Spec.IP)
+	case err != nil:
+		return err
+	case !isNodeReady(node):
+		reason = reasonNodeNotReady
+		message = fmt.Sprintf("node %s is not ready", node.Name)
+	}
+	if reason == "" {
+		removeCondition(machine, conditionTypeWaitingForNode)
+		return nil
+	}
+
+	log.FromContext(ctx).Info("Skip updating machine until its node is ready", "reason", reason)
+	machine.SetCondition(platformv1.MachineCondition{
+		Type:    conditionTypeWaitingForNode,
+		Status:  platformv1.ConditionTrue,
+		Reason:  reason,
+		Message: message,
+	})
+	return fmt.Errorf("%w: %s", errWaitingForNode, message)
+}
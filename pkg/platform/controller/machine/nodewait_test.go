@@ -0,0 +1,112 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+
+	platformv1 "tkestack.io/tke/api/platform/v1"
+	machineprovider "tkestack.io/tke/pkg/platform/provider/machine"
+	typesv1 "tkestack.io/tke/pkg/platform/types/v1"
+)
+
+func TestController_onUpdateWaitingForNode(t *testing.T) {
+	updates := 0
+	providerName := registerFakeProvider(t, &fakeProvider{
+		onUpdate: func(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster, options machineprovider.UpdateOptions) error {
+			updates++
+			return nil
+		},
+	})
+	machine := newMachineForTest("1", nil, platformv1.MachineRunning, nil)
+	machine.Name = "machine"
+	machine.Spec.Type = providerName
+	node := &corev1.Node{
+		ObjectMeta: v1.ObjectMeta{Name: machine.Spec.IP},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionFalse}},
+		},
+	}
+	client := kubefake.NewSimpleClientset(node)
+	c := newControllerForTest(machine)
+	c.getCluster = fakeGetCluster
+	c.clientsetFor = func(cluster *typesv1.Cluster) (kubernetes.Interface, error) {
+		return client, nil
+	}
+	c.updateRequiresReadyNode = true
+	ctx := context.Background()
+	latest := func() *platformv1.Machine {
+		got, err := c.platformClient.Machines().Get(ctx, machine.Name, v1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return got
+	}
+
+	err := c.onUpdate(ctx, latest())
+	if !errors.Is(err, errWaitingForNode) {
+		t.Fatalf("onUpdate() error = %v, want %v", err, errWaitingForNode)
+	}
+	if _, ok := requeueDelay(err); ok {
+		t.Errorf("requeueDelay(%v) is fixed, want requeued with backoff", err)
+	}
+	if updates != 0 {
+		t.Errorf("OnUpdate called %d times while node is not ready, want skipped", updates)
+	}
+	if condition := latest().GetCondition(conditionTypeWaitingForNode); condition == nil || condition.Reason != reasonNodeNotReady {
+		t.Errorf("waiting condition = %+v, want reason %s", condition, reasonNodeNotReady)
+	}
+
+	node.Status.Conditions[0].Status = corev1.ConditionTrue
+	if _, err := client.CoreV1().Nodes().UpdateStatus(ctx, node, v1.UpdateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.onUpdate(ctx, latest()); err != nil {
+		t.Fatalf("onUpdate() error = %v once node is ready", err)
+	}
+	if updates != 1 {
+		t.Errorf("OnUpdate called %d times once node is ready, want 1", updates)
+	}
+	if condition := latest().GetCondition(conditionTypeWaitingForNode); condition != nil {
+		t.Errorf("waiting condition = %+v once node is ready, want removed", condition)
+	}
+}
+
+func TestController_waitForNodeNotFound(t *testing.T) {
+	machine := newMachineForTest("1", nil, platformv1.MachineRunning, nil)
+	c := newControllerForTest(machine)
+	if err := c.waitForNode(context.Background(), machine, nil); err != nil {
+		t.Fatalf("waitForNode() error = %v, want nothing checked if disabled", err)
+	}
+
+	c.updateRequiresReadyNode = true
+	if err := c.waitForNode(context.Background(), machine, nil); !errors.Is(err, errWaitingForNode) {
+		t.Fatalf("waitForNode() error = %v, want %v", err, errWaitingForNode)
+	}
+	if condition := machine.GetCondition(conditionTypeWaitingForNode); condition == nil || condition.Reason != reasonNodeNotFound {
+		t.Errorf("waiting condition = %+v, want reason %s", condition, reasonNodeNotFound)
+	}
+}
@@ -0,0 +1,75 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	platformv1 "tkestack.io/tke/api/platform/v1"
+)
+
+var clusterKind = platformv1.SchemeGroupVersion.WithKind("Cluster")
+
+// setClusterOwner adds an owner reference to the cluster of machine if
+// missing, so that the machine is garbage collected once the cluster is
+// deleted. An existing reference to the cluster is left intact, and the ones
+// to other clusters, e.g. the cluster the machine is migrated from, are
+// removed.
+func setClusterOwner(machine *platformv1.Machine, cluster *platformv1.Cluster) {
+	if cluster.UID == "" {
+		return
+	}
+	for _, ref := range machine.OwnerReferences {
+		if isClusterOwner(ref) && ref.UID == cluster.UID {
+			removeClusterOwners(machine, cluster.UID)
+			return
+		}
+	}
+	removeClusterOwners(machine, "")
+	machine.OwnerReferences = append(machine.OwnerReferences, metav1.OwnerReference{
+		APIVersion: clusterKind.GroupVersion().String(),
+		Kind:       clusterKind.Kind,
+		Name:       cluster.Name,
+		UID:        cluster.UID,
+	})
+}
+
+// removeClusterOwners removes the owner references of machine to clusters
+// except the one with the uid.
+func removeClusterOwners(machine *platformv1.Machine, except types.UID) {
+	var refs []metav1.OwnerReference
+	removed := false
+	for _, ref := range machine.OwnerReferences {
+		if isClusterOwner(ref) && ref.UID != except {
+			removed = true
+			continue
+		}
+		refs = append(refs, ref)
+	}
+	if removed {
+		machine.OwnerReferences = refs
+	}
+}
+
+func isClusterOwner(ref metav1.OwnerReference) bool {
+	gv, err := schema.ParseGroupVersion(ref.APIVersion)
+	return err == nil && gv.Group == clusterKind.Group && ref.Kind == clusterKind.Kind
+}
@@ -0,0 +1,88 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	platformversionedclient "tkestack.io/tke/api/client/clientset/versioned/typed/platform/v1"
+	platformv1 "tkestack.io/tke/api/platform/v1"
+	typesv1 "tkestack.io/tke/pkg/platform/types/v1"
+)
+
+func TestController_onUpdateClusterOwner(t *testing.T) {
+	blockOwnerDeletion := true
+	present := v1.OwnerReference{
+		APIVersion:         "platform.tkestack.io/v1",
+		Kind:               "Cluster",
+		Name:               "global",
+		UID:                "cluster",
+		BlockOwnerDeletion: &blockOwnerDeletion,
+	}
+	pool := v1.OwnerReference{APIVersion: "example.com/v1", Kind: "MachinePool", Name: "pool", UID: "pool"}
+	tests := []struct {
+		name   string
+		owners []v1.OwnerReference
+		want   []v1.OwnerReference
+	}{
+		{
+			name: "absent",
+			want: []v1.OwnerReference{{APIVersion: "platform.tkestack.io/v1", Kind: "Cluster", Name: "global", UID: "cluster"}},
+		},
+		{
+			name:   "present",
+			owners: []v1.OwnerReference{pool, present},
+			want:   []v1.OwnerReference{pool, present},
+		},
+		{
+			name:   "owned by the cluster migrated from",
+			owners: []v1.OwnerReference{{APIVersion: "platform.tkestack.io/v1", Kind: "Cluster", Name: "old", UID: "old"}, pool},
+			want:   []v1.OwnerReference{pool, {APIVersion: "platform.tkestack.io/v1", Kind: "Cluster", Name: "global", UID: "cluster"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			providerName := registerFakeProvider(t, &fakeProvider{})
+			machine := newMachineForTest("1", nil, platformv1.MachineRunning, nil)
+			machine.Name = "machine"
+			machine.Spec.Type = providerName
+			machine.OwnerReferences = tt.owners
+			c := newControllerForTest(machine)
+			c.getCluster = func(ctx context.Context, platformClient platformversionedclient.PlatformV1Interface, name, username string) (*typesv1.Cluster, error) {
+				return &typesv1.Cluster{Cluster: &platformv1.Cluster{ObjectMeta: v1.ObjectMeta{Name: name, UID: types.UID("cluster")}}}, nil
+			}
+
+			if err := c.onUpdate(context.Background(), machine.DeepCopy()); err != nil {
+				t.Fatalf("onUpdate() error = %v", err)
+			}
+			got, err := c.platformClient.Machines().Get(context.Background(), machine.Name, v1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got.OwnerReferences, tt.want) {
+				t.Errorf("owner references = %+v, want %+v", got.OwnerReferences, tt.want)
+			}
+		})
+	}
+}
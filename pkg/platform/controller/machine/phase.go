@@ -0,0 +1,131 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	platformv1 "tkestack.io/tke/api/platform/v1"
+	machineprovider "tkestack.io/tke/pkg/platform/provider/machine"
+)
+
+// phaseInput is what the phase of a machine is decided from by health check.
+type phaseInput struct {
+	// Phase is the phase of machine before health check.
+	Phase platformv1.MachinePhase
+	// ProbedPhase is the phase the provider probed the machine into.
+	ProbedPhase platformv1.MachinePhase
+	// Health is the status of the health check condition after probe, empty
+	// if the provider set none.
+	Health platformv1.ConditionStatus
+	// KubeletUnhealthy is true if the kubelet healthz probe failed.
+	KubeletUnhealthy bool
+	// RecoveryStreak is the number of consecutive successful health checks of
+	// the failed machine before this one.
+	RecoveryStreak int
+	// RecoveryThreshold is the number of consecutive successful health checks
+	// for a failed machine to recover, machines recover at once if it's <= 1.
+	RecoveryThreshold int
+}
+
+// phaseDecision is the phase of a machine decided by health check, and why.
+type phaseDecision struct {
+	Phase platformv1.MachinePhase
+	// KubeletFailed is true if the otherwise healthy machine fails by its
+	// kubelet.
+	KubeletFailed bool
+	// Recovering is true if the failed machine passed the health check, but
+	// is held Failed until it passes enough consecutive ones.
+	Recovering bool
+}
+
+// nextPhase decides the phase of a machine after health check. It's pure, so
+// that the decision can be tested without the controller.
+func nextPhase(in phaseInput) phaseDecision {
+	// the health check never changes the phase of machines in other phases,
+	// e.g. Initializing ones are not ready yet rather than failed
+	if !(in.Phase == platformv1.MachineRunning || in.Phase == platformv1.MachineFailed) {
+		return phaseDecision{Phase: in.Phase}
+	}
+	decision := phaseDecision{Phase: in.ProbedPhase}
+	if in.Health == platformv1.ConditionTrue && in.KubeletUnhealthy {
+		decision.Phase = platformv1.MachineFailed
+		decision.KubeletFailed = true
+		return decision
+	}
+	if in.Phase == platformv1.MachineFailed && decision.Phase == platformv1.MachineRunning &&
+		in.RecoveryStreak+1 < in.RecoveryThreshold {
+		decision.Phase = platformv1.MachineFailed
+		decision.Recovering = true
+	}
+
+	return decision
+}
+
+// updatePhase sets the phase of machine decided by nextPhase after health
+// check, along with the health condition explaining a failure by kubelet or a
+// held recovery. previous is the health condition before check, kubeletErr is
+// the error of kubelet probe. The recovery streak is left as is on dry run.
+func (c *Controller) updatePhase(oldPhase platformv1.MachinePhase, previous *platformv1.MachineCondition, machine *platformv1.Machine, kubeletErr error, dryRun bool) {
+	in := phaseInput{
+		Phase:            oldPhase,
+		ProbedPhase:      machine.Status.Phase,
+		KubeletUnhealthy: kubeletErr != nil,
+	}
+	health := machine.GetCondition(machineprovider.ConditionTypeHealthCheck)
+	if health != nil {
+		in.Health = health.Status
+	}
+	if c.recoveryStreaks != nil {
+		in.RecoveryStreak = c.recoveryStreaks.streak(string(machine.UID))
+		in.RecoveryThreshold = c.recoveryStreaks.threshold
+	}
+	decision := nextPhase(in)
+	machine.Status.Phase = decision.Phase
+	if c.recoveryStreaks != nil && !decision.Recovering && !dryRun {
+		c.recoveryStreaks.reset(string(machine.UID))
+	}
+
+	switch {
+	case decision.KubeletFailed:
+		condition := *health
+		condition.Status = platformv1.ConditionFalse
+		condition.Reason = reasonKubeletUnhealthy
+		condition.Message = kubeletErr.Error()
+		condition.LastTransitionTime = metav1.Now()
+		machine.SetCondition(condition)
+	case decision.Recovering:
+		streak := in.RecoveryStreak + 1
+		if !dryRun {
+			streak = c.recoveryStreaks.succeed(string(machine.UID))
+		}
+		condition := platformv1.MachineCondition{
+			Type:    machineprovider.ConditionTypeHealthCheck,
+			Status:  platformv1.ConditionFalse,
+			Reason:  reasonRecovering,
+			Message: fmt.Sprintf("%d of %d consecutive health checks succeeded", streak, c.recoveryStreaks.threshold),
+		}
+		if previous != nil {
+			condition.LastTransitionTime = previous.LastTransitionTime
+		}
+		machine.SetCondition(condition)
+	}
+}
@@ -0,0 +1,88 @@
+//go:build go1.18
+// +build go1.18
+
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"testing"
+
+	platformv1 "tkestack.io/tke/api/platform/v1"
+)
+
+var (
+	fuzzPhases = []platformv1.MachinePhase{
+		platformv1.MachineInitializing,
+		platformv1.MachineRunning,
+		platformv1.MachineFailed,
+		platformv1.MachineUpgrading,
+		platformv1.MachineTerminating,
+	}
+	fuzzConditionStatuses = []platformv1.ConditionStatus{
+		"",
+		platformv1.ConditionTrue,
+		platformv1.ConditionFalse,
+		platformv1.ConditionUnknown,
+	}
+)
+
+func FuzzNextPhase(f *testing.F) {
+	f.Add(uint8(1), uint8(1), uint8(1), false, 0, 0)
+	f.Add(uint8(2), uint8(1), uint8(1), false, 1, 3)
+	f.Add(uint8(1), uint8(1), uint8(1), true, 0, 0)
+	f.Fuzz(func(t *testing.T, phase, probed, health uint8, kubeletUnhealthy bool, streak, threshold int) {
+		in := phaseInput{
+			Phase:             fuzzPhases[int(phase)%len(fuzzPhases)],
+			ProbedPhase:       fuzzPhases[int(probed)%len(fuzzPhases)],
+			Health:            fuzzConditionStatuses[int(health)%len(fuzzConditionStatuses)],
+			KubeletUnhealthy:  kubeletUnhealthy,
+			RecoveryStreak:    streak,
+			RecoveryThreshold: threshold,
+		}
+		got := nextPhase(in)
+
+		if got != nextPhase(in) {
+			t.Fatalf("nextPhase(%+v) isn't deterministic", in)
+		}
+		if got.KubeletFailed && got.Recovering {
+			t.Errorf("nextPhase(%+v) = %+v, both kubelet failed and recovering", in, got)
+		}
+		if (got.KubeletFailed || got.Recovering) && got.Phase != platformv1.MachineFailed {
+			t.Errorf("nextPhase(%+v) = %+v, want failed", in, got)
+		}
+		if !(in.Phase == platformv1.MachineRunning || in.Phase == platformv1.MachineFailed) {
+			if got != (phaseDecision{Phase: in.Phase}) {
+				t.Errorf("nextPhase(%+v) = %+v, want the phase unchanged outside health check", in, got)
+			}
+			return
+		}
+		if got.Phase == platformv1.MachineRunning {
+			if in.ProbedPhase != platformv1.MachineRunning {
+				t.Errorf("nextPhase(%+v) = %+v, running although the probe isn't", in, got)
+			}
+			if in.Health == platformv1.ConditionTrue && in.KubeletUnhealthy {
+				t.Errorf("nextPhase(%+v) = %+v, running with unhealthy kubelet", in, got)
+			}
+			if in.Phase == platformv1.MachineFailed && in.RecoveryStreak+1 < in.RecoveryThreshold {
+				t.Errorf("nextPhase(%+v) = %+v, recovered before threshold", in, got)
+			}
+		}
+	})
+}
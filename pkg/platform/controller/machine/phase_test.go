@@ -0,0 +1,106 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"testing"
+
+	platformv1 "tkestack.io/tke/api/platform/v1"
+)
+
+func TestNextPhase(t *testing.T) {
+	const (
+		running      = platformv1.MachineRunning
+		failed       = platformv1.MachineFailed
+		initializing = platformv1.MachineInitializing
+	)
+	tests := []struct {
+		name string
+		in   phaseInput
+		want phaseDecision
+	}{
+		{
+			name: "healthy running",
+			in:   phaseInput{Phase: running, ProbedPhase: running, Health: platformv1.ConditionTrue},
+			want: phaseDecision{Phase: running},
+		},
+		{
+			name: "unhealthy running",
+			in:   phaseInput{Phase: running, ProbedPhase: failed, Health: platformv1.ConditionFalse},
+			want: phaseDecision{Phase: failed},
+		},
+		{
+			name: "unknown health keeps probed phase",
+			in:   phaseInput{Phase: running, ProbedPhase: running, Health: platformv1.ConditionUnknown, KubeletUnhealthy: true},
+			want: phaseDecision{Phase: running},
+		},
+		{
+			name: "healthy but kubelet unhealthy",
+			in:   phaseInput{Phase: running, ProbedPhase: running, Health: platformv1.ConditionTrue, KubeletUnhealthy: true},
+			want: phaseDecision{Phase: failed, KubeletFailed: true},
+		},
+		{
+			name: "unhealthy with kubelet unhealthy",
+			in:   phaseInput{Phase: running, ProbedPhase: failed, Health: platformv1.ConditionFalse, KubeletUnhealthy: true},
+			want: phaseDecision{Phase: failed},
+		},
+		{
+			name: "failed recovers at once without threshold",
+			in:   phaseInput{Phase: failed, ProbedPhase: running, Health: platformv1.ConditionTrue},
+			want: phaseDecision{Phase: running},
+		},
+		{
+			name: "failed held before threshold",
+			in:   phaseInput{Phase: failed, ProbedPhase: running, Health: platformv1.ConditionTrue, RecoveryStreak: 1, RecoveryThreshold: 3},
+			want: phaseDecision{Phase: failed, Recovering: true},
+		},
+		{
+			name: "failed recovers at threshold",
+			in:   phaseInput{Phase: failed, ProbedPhase: running, Health: platformv1.ConditionTrue, RecoveryStreak: 2, RecoveryThreshold: 3},
+			want: phaseDecision{Phase: running},
+		},
+		{
+			name: "failed stays failed",
+			in:   phaseInput{Phase: failed, ProbedPhase: failed, Health: platformv1.ConditionFalse, RecoveryStreak: 2, RecoveryThreshold: 3},
+			want: phaseDecision{Phase: failed},
+		},
+		{
+			name: "failed with kubelet unhealthy isn't recovering",
+			in:   phaseInput{Phase: failed, ProbedPhase: running, Health: platformv1.ConditionTrue, KubeletUnhealthy: true, RecoveryThreshold: 3},
+			want: phaseDecision{Phase: failed, KubeletFailed: true},
+		},
+		{
+			name: "provider phase without health condition",
+			in:   phaseInput{Phase: running, ProbedPhase: failed},
+			want: phaseDecision{Phase: failed},
+		},
+		{
+			name: "initializing isn't decided by health check",
+			in:   phaseInput{Phase: initializing, ProbedPhase: failed, Health: platformv1.ConditionFalse, KubeletUnhealthy: true},
+			want: phaseDecision{Phase: initializing},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nextPhase(tt.in); got != tt.want {
+				t.Errorf("nextPhase(%+v) = %+v, want %+v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/kubernetes"
+
+	platformv1 "tkestack.io/tke/api/platform/v1"
+)
+
+// podCountInterval is the min interval to count the pods on the node of a
+// machine, since listing pods is much heavier than getting the node.
+const podCountInterval = 5 * time.Minute
+
+// podCounter records when the pods on the node of machines were counted, by
+// the UID of machines, so that a machine recreated with the same name is
+// counted afresh.
+type podCounter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	now      func() time.Time
+	counted  map[string]time.Time
+}
+
+func newPodCounter(interval time.Duration) *podCounter {
+	return &podCounter{
+		interval: interval,
+		now:      time.Now,
+		counted:  make(map[string]time.Time),
+	}
+}
+
+// due returns true if the pods of the machine haven't been counted within
+// the interval, and records they are counted now.
+func (p *podCounter) due(uid string) bool {
+	if p == nil {
+		return false
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	if last, ok := p.counted[uid]; ok && now.Sub(last) < p.interval {
+		return false
+	}
+	p.counted[uid] = now
+	return true
+}
+
+func (p *podCounter) forget(uid string) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.counted, uid)
+}
+
+// syncPodCount records the number of pods on the node of machine which
+// haven't terminated in the machine status, so that the density of
+// nodes is visible to plan drains. The pods are listed from the apiserver
+// cache at most once per interval.
+func (c *Controller) syncPodCount(ctx context.Context, client kubernetes.Interface, machine *platformv1.Machine, node *corev1.Node) error {
+	if !c.podCounter.due(string(machine.UID)) {
+		return nil
+	}
+	pods, err := client.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+		FieldSelector:   fields.OneTermEqualSelector("spec.nodeName", node.Name).String(),
+		ResourceVersion: "0",
+	})
+	if err != nil {
+		c.podCounter.forget(string(machine.UID))
+		return err
+	}
+	var count int32
+	for _, pod := range pods.Items {
+		if pod.Spec.NodeName != node.Name ||
+			pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		count++
+	}
+	machine.Status.PodCount = count
+	return nil
+}
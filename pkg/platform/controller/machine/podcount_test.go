@@ -0,0 +1,79 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	platformv1 "tkestack.io/tke/api/platform/v1"
+	typesv1 "tkestack.io/tke/pkg/platform/types/v1"
+)
+
+func TestController_syncPodCount(t *testing.T) {
+	machine := newMachineForTest("1", nil, platformv1.MachineRunning, nil)
+	machine.Name = "machine"
+	c := newControllerForTest(machine)
+	now := time.Now()
+	c.podCounter = newPodCounter(podCountInterval)
+	c.podCounter.now = func() time.Time { return now }
+
+	pod := func(name, nodeName string, phase corev1.PodPhase) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: v1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       corev1.PodSpec{NodeName: nodeName},
+			Status:     corev1.PodStatus{Phase: phase},
+		}
+	}
+	client := kubefake.NewSimpleClientset(
+		&corev1.Node{ObjectMeta: v1.ObjectMeta{Name: machine.Spec.IP}},
+		pod("running", machine.Spec.IP, corev1.PodRunning),
+		pod("pending", machine.Spec.IP, corev1.PodPending),
+		pod("succeeded", machine.Spec.IP, corev1.PodSucceeded),
+		pod("other", "10.0.0.2", corev1.PodRunning),
+	)
+	c.clientsetFor = func(cluster *typesv1.Cluster) (kubernetes.Interface, error) {
+		return client, nil
+	}
+	countOf := func() int32 {
+		c.syncNodeInfo(context.Background(), machine, &typesv1.Cluster{}, nil)
+		return machine.Status.PodCount
+	}
+
+	if got := countOf(); got != 2 {
+		t.Errorf("pod count = %d, want 2", got)
+	}
+
+	if _, err := client.CoreV1().Pods("default").Create(context.Background(), pod("new", machine.Spec.IP, corev1.PodRunning), v1.CreateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if got := countOf(); got != 2 {
+		t.Errorf("pod count = %d within interval, want 2 until interval passes", got)
+	}
+
+	now = now.Add(podCountInterval)
+	if got := countOf(); got != 3 {
+		t.Errorf("pod count = %d after interval, want 3", got)
+	}
+}
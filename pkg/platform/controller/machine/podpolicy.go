@@ -0,0 +1,183 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"context"
+	"encoding/json"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/kubernetes"
+
+	platformv1 "tkestack.io/tke/api/platform/v1"
+	"tkestack.io/tke/pkg/platform/apiserver/cluster/drain"
+	machineconfig "tkestack.io/tke/pkg/platform/controller/machine/config"
+	machineprovider "tkestack.io/tke/pkg/platform/provider/machine"
+	typesv1 "tkestack.io/tke/pkg/platform/types/v1"
+	"tkestack.io/tke/pkg/util/log"
+)
+
+// applyFailedPodPolicy applies the failed pod policy to the node of machine
+// by its health. The node of a failed machine is tainted so that no more pods
+// are scheduled to it, or cordoned instead by the cordon policy, and its pods
+// are evicted as well by the evict policy when the machine changes to Failed
+// from oldPhase. The taint is removed and the node is uncordoned once the
+// machine is healthy again. Failures are only logged, the taint and cordon
+// are applied again on next health check.
+func (c *Controller) applyFailedPodPolicy(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster, oldPhase platformv1.MachinePhase) {
+	if c.failedPodPolicy == "" || c.failedPodPolicy == machineconfig.FailedMachinePodPolicyNone {
+		return
+	}
+	condition := machine.GetCondition(machineprovider.ConditionTypeHealthCheck)
+	if condition == nil || condition.Status == platformv1.ConditionUnknown {
+		return
+	}
+	failed := condition.Status == platformv1.ConditionFalse
+
+	client, err := c.clientsetFor(cluster)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "Failed to get cluster clientset for failed pod policy")
+		return
+	}
+	node, err := getNode(ctx, client, machine)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			log.FromContext(ctx).Error(err, "Failed to get node for failed pod policy")
+		}
+		return
+	}
+	cordon := c.failedPodPolicy == machineconfig.FailedMachinePodPolicyCordon
+	if err := setFailedTaint(ctx, client, node, failed && !cordon); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to taint node of machine", "node", node.Name)
+		return
+	}
+	if err := setFailedCordon(ctx, client, node, failed && cordon); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to cordon node of machine", "node", node.Name)
+		return
+	}
+	// the pods are evicted once, rather than on every health check while
+	// the machine stays Failed
+	newlyFailed := oldPhase != platformv1.MachineFailed && machine.Status.Phase == platformv1.MachineFailed
+	if failed && newlyFailed && c.failedPodPolicy == machineconfig.FailedMachinePodPolicyEvict {
+		if err := evictNodePods(ctx, client, node, drainGracePeriodSeconds(machine)); err != nil {
+			log.FromContext(ctx).Error(err, "Failed to evict pods of failed machine", "node", node.Name)
+		}
+	}
+}
+
+// setFailedTaint adds or removes the failed taint of node if required. The
+// taints are replaced as a whole by the patch, which is preconditioned on the
+// resourceVersion of node so that other changes of taints aren't lost.
+func setFailedTaint(ctx context.Context, client kubernetes.Interface, node *corev1.Node, tainted bool) error {
+	var taints []corev1.Taint
+	exists := false
+	for _, taint := range node.Spec.Taints {
+		if taint.Key == platformv1.MachineFailedTaintKey {
+			exists = true
+			continue
+		}
+		taints = append(taints, taint)
+	}
+	if exists == tainted {
+		return nil
+	}
+	if tainted {
+		now := metav1.Now()
+		taints = append(taints, corev1.Taint{
+			Key:       platformv1.MachineFailedTaintKey,
+			Effect:    corev1.TaintEffectNoSchedule,
+			TimeAdded: &now,
+		})
+		log.FromContext(ctx).Info("Taint node of failed machine", "node", node.Name)
+	} else {
+		log.FromContext(ctx).Info("Remove taint from node of recovered machine", "node", node.Name)
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{"resourceVersion": node.ResourceVersion},
+		"spec":     map[string]interface{}{"taints": taints},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = client.CoreV1().Nodes().Patch(ctx, node.Name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
+
+// setFailedCordon cordons or uncordons node if required. Only the node
+// cordoned by itself is uncordoned, a node which is cordoned otherwise, e.g.
+// by an operator, is left as is.
+func setFailedCordon(ctx context.Context, client kubernetes.Interface, node *corev1.Node, cordoned bool) error {
+	_, exists := node.Annotations[platformv1.MachineFailedCordonAnno]
+	if exists == cordoned || (cordoned && node.Spec.Unschedulable) {
+		return nil
+	}
+	annotation := interface{}(nil)
+	if cordoned {
+		annotation = "true"
+		log.FromContext(ctx).Info("Cordon node of failed machine", "node", node.Name)
+	} else {
+		log.FromContext(ctx).Info("Uncordon node of recovered machine", "node", node.Name)
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]interface{}{platformv1.MachineFailedCordonAnno: annotation},
+		},
+		"spec": map[string]interface{}{"unschedulable": cordoned},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = client.CoreV1().Nodes().Patch(ctx, node.Name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
+
+// evictNodePods evicts the pods on node except those of daemon sets, without
+// waiting for them to terminate.
+func evictNodePods(ctx context.Context, client kubernetes.Interface, node *corev1.Node, gracePeriodSeconds int) error {
+	helper := &drain.Helper{
+		Client:              client,
+		Force:               true,
+		GracePeriodSeconds:  gracePeriodSeconds,
+		IgnoreAllDaemonSets: true,
+		DeleteLocalData:     true,
+	}
+	list, errs := helper.GetPodsForDeletion(ctx, node.Name)
+	if errs != nil {
+		return utilerrors.NewAggregate(errs)
+	}
+	policyGroupVersion, err := drain.CheckEvictionSupport(client)
+	if err != nil {
+		return err
+	}
+	for _, pod := range list.Pods() {
+		if len(policyGroupVersion) > 0 {
+			err = helper.EvictPod(ctx, pod, policyGroupVersion)
+		} else {
+			err = helper.DeletePod(ctx, pod)
+		}
+		if err != nil && !apierrors.IsNotFound(err) {
+			errs = append(errs, err)
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
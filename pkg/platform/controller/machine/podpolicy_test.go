@@ -0,0 +1,164 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+
+	platformv1 "tkestack.io/tke/api/platform/v1"
+	machineconfig "tkestack.io/tke/pkg/platform/controller/machine/config"
+	machineprovider "tkestack.io/tke/pkg/platform/provider/machine"
+	typesv1 "tkestack.io/tke/pkg/platform/types/v1"
+)
+
+func TestController_applyFailedPodPolicy(t *testing.T) {
+	tests := []struct {
+		policy     string
+		wantTaint  bool
+		wantCordon bool
+		wantPod    bool
+	}{
+		{policy: machineconfig.FailedMachinePodPolicyNone, wantPod: true},
+		{policy: machineconfig.FailedMachinePodPolicyTaint, wantTaint: true, wantPod: true},
+		{policy: machineconfig.FailedMachinePodPolicyEvict, wantTaint: true},
+		{policy: machineconfig.FailedMachinePodPolicyCordon, wantCordon: true, wantPod: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.policy, func(t *testing.T) {
+			machine := newMachineForTest("1", nil, platformv1.MachineFailed, []platformv1.MachineCondition{{
+				Type:   machineprovider.ConditionTypeHealthCheck,
+				Status: platformv1.ConditionFalse,
+			}})
+			node := &corev1.Node{ObjectMeta: v1.ObjectMeta{Name: machine.Spec.IP}}
+			pod := &corev1.Pod{
+				ObjectMeta: v1.ObjectMeta{Name: "pod", Namespace: "default"},
+				Spec:       corev1.PodSpec{NodeName: node.Name},
+			}
+			client := kubefake.NewSimpleClientset(node, pod)
+			c := &Controller{
+				failedPodPolicy: tt.policy,
+				clientsetFor: func(cluster *typesv1.Cluster) (kubernetes.Interface, error) {
+					return client, nil
+				},
+			}
+			getNode := func() *corev1.Node {
+				got, err := client.CoreV1().Nodes().Get(context.Background(), node.Name, v1.GetOptions{})
+				if err != nil {
+					t.Fatal(err)
+				}
+				return got
+			}
+			tainted := func() bool {
+				for _, taint := range getNode().Spec.Taints {
+					if taint.Key == platformv1.MachineFailedTaintKey {
+						return true
+					}
+				}
+				return false
+			}
+
+			c.applyFailedPodPolicy(context.Background(), machine, &typesv1.Cluster{}, platformv1.MachineRunning)
+			if got := tainted(); got != tt.wantTaint {
+				t.Errorf("node tainted = %v, want %v", got, tt.wantTaint)
+			}
+			if got := getNode().Spec.Unschedulable; got != tt.wantCordon {
+				t.Errorf("node cordoned = %v, want %v", got, tt.wantCordon)
+			}
+			_, err := client.CoreV1().Pods(pod.Namespace).Get(context.Background(), pod.Name, v1.GetOptions{})
+			if got := err == nil; got != tt.wantPod {
+				t.Errorf("pod exists = %v, want %v", got, tt.wantPod)
+			}
+
+			// pods scheduled before the taint aren't evicted again while the
+			// machine stays Failed
+			if !tt.wantPod {
+				if _, err := client.CoreV1().Pods(pod.Namespace).Create(context.Background(), pod, v1.CreateOptions{}); err != nil {
+					t.Fatal(err)
+				}
+			}
+			c.applyFailedPodPolicy(context.Background(), machine, &typesv1.Cluster{}, platformv1.MachineFailed)
+			if _, err := client.CoreV1().Pods(pod.Namespace).Get(context.Background(), pod.Name, v1.GetOptions{}); err != nil {
+				t.Errorf("pod is evicted again while machine stays failed: %v", err)
+			}
+			if got := tainted(); got != tt.wantTaint {
+				t.Errorf("node tainted = %v while machine stays failed, want %v", got, tt.wantTaint)
+			}
+
+			machine.Status.Phase = platformv1.MachineRunning
+			machine.SetCondition(platformv1.MachineCondition{
+				Type:   machineprovider.ConditionTypeHealthCheck,
+				Status: platformv1.ConditionTrue,
+			})
+			c.applyFailedPodPolicy(context.Background(), machine, &typesv1.Cluster{}, platformv1.MachineFailed)
+			if tainted() {
+				t.Errorf("node should be untainted after machine recovered")
+			}
+			if got := getNode(); got.Spec.Unschedulable {
+				t.Errorf("node should be uncordoned after machine recovered")
+			} else if _, ok := got.Annotations[platformv1.MachineFailedCordonAnno]; ok {
+				t.Errorf("annotation %s should be removed after machine recovered", platformv1.MachineFailedCordonAnno)
+			}
+			for _, action := range client.Actions() {
+				if action.GetVerb() == "update" && action.GetResource().Resource == "nodes" {
+					t.Errorf("node is updated as a whole, want patched")
+				}
+			}
+		})
+	}
+}
+
+func TestController_applyFailedPodPolicyKeepsOperatorCordon(t *testing.T) {
+	machine := newMachineForTest("1", nil, platformv1.MachineFailed, []platformv1.MachineCondition{{
+		Type:   machineprovider.ConditionTypeHealthCheck,
+		Status: platformv1.ConditionFalse,
+	}})
+	node := &corev1.Node{
+		ObjectMeta: v1.ObjectMeta{Name: machine.Spec.IP},
+		Spec:       corev1.NodeSpec{Unschedulable: true},
+	}
+	client := kubefake.NewSimpleClientset(node)
+	c := &Controller{
+		failedPodPolicy: machineconfig.FailedMachinePodPolicyCordon,
+		clientsetFor: func(cluster *typesv1.Cluster) (kubernetes.Interface, error) {
+			return client, nil
+		},
+	}
+
+	c.applyFailedPodPolicy(context.Background(), machine, &typesv1.Cluster{}, platformv1.MachineRunning)
+	machine.Status.Phase = platformv1.MachineRunning
+	machine.SetCondition(platformv1.MachineCondition{
+		Type:   machineprovider.ConditionTypeHealthCheck,
+		Status: platformv1.ConditionTrue,
+	})
+	c.applyFailedPodPolicy(context.Background(), machine, &typesv1.Cluster{}, platformv1.MachineFailed)
+
+	got, err := client.CoreV1().Nodes().Get(context.Background(), node.Name, v1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.Spec.Unschedulable {
+		t.Errorf("node cordoned by operator should not be uncordoned after machine recovered")
+	}
+}
@@ -0,0 +1,53 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+
+	platformv1 "tkestack.io/tke/api/platform/v1"
+	"tkestack.io/tke/pkg/util/log"
+)
+
+// poolLabelChanges adds the pool label of machine to changes if its node has
+// another one, so that the node follows the machine moving between pools.
+// The label is removed from the node if the machine leaves all pools.
+func poolLabelChanges(ctx context.Context, machine *platformv1.Machine, node *corev1.Node, changes map[string]interface{}) {
+	pool, ok := machine.Labels[platformv1.MachinePoolLabel]
+	current, exists := node.Labels[platformv1.MachinePoolLabel]
+	if ok == exists && pool == current {
+		return
+	}
+
+	var value interface{}
+	if ok {
+		value = pool
+	}
+	changes[platformv1.MachinePoolLabel] = value
+	log.FromContext(ctx).Info("Move node between pools", "node", node.Name, "from", current, "to", pool)
+}
+
+// isCoordinationExempt returns true if the machine is excluded from the
+// coordination of its pool, e.g. a canary, which neither waits for nor
+// holds the other machines of the pool.
+func isCoordinationExempt(machine *platformv1.Machine) bool {
+	return machine.Annotations[platformv1.MachineCoordinationExemptAnno] == "true"
+}
@@ -0,0 +1,73 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"context"
+	"errors"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	platformv1 "tkestack.io/tke/api/platform/v1"
+	machineprovider "tkestack.io/tke/pkg/platform/provider/machine"
+	typesv1 "tkestack.io/tke/pkg/platform/types/v1"
+	"tkestack.io/tke/pkg/util/log"
+)
+
+const (
+	conditionTypePreCheck = "PreCheck"
+	reasonPreCheckFailed  = "PreCheckFailed"
+)
+
+// errPreCheckFailed is returned by preCheck when the machine has been failed.
+var errPreCheckFailed = errors.New("machine pre-check failed")
+
+// preCheck runs the pre-check of provider once before the first create step,
+// the passed check is recorded by a condition so that it's not repeated on
+// later steps. The machine failing the check is marked Failed at once with
+// the error, rather than failing deep in the create steps.
+func (c *Controller) preCheck(ctx context.Context, provider machineprovider.Provider, machine *platformv1.Machine, cluster *typesv1.Cluster) error {
+	checker, ok := provider.(machineprovider.PreChecker)
+	if !ok || machine.GetCondition(conditionTypePreCheck) != nil {
+		return nil
+	}
+	err := checker.PreCheck(ctx, machine, cluster)
+	if err == nil {
+		machine.SetCondition(platformv1.MachineCondition{
+			Type:   conditionTypePreCheck,
+			Status: platformv1.ConditionTrue,
+		})
+		return nil
+	}
+
+	log.FromContext(ctx).Info("Machine failed the pre-check", "reason", err.Error())
+	machine = machine.DeepCopy()
+	machine.Status.Phase = platformv1.MachineFailed
+	machine.SetCondition(platformv1.MachineCondition{
+		Type:    conditionTypePreCheck,
+		Status:  platformv1.ConditionFalse,
+		Reason:  reasonPreCheckFailed,
+		Message: err.Error(),
+	})
+	if _, err := c.platformClient.Machines().Update(ctx, machine, metav1.UpdateOptions{}); err != nil {
+		return err
+	}
+	c.recordLifecycle(machine, platformv1.MachineInitializing, "")
+	return errPreCheckFailed
+}
@@ -0,0 +1,97 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	platformv1 "tkestack.io/tke/api/platform/v1"
+	machineprovider "tkestack.io/tke/pkg/platform/provider/machine"
+	typesv1 "tkestack.io/tke/pkg/platform/types/v1"
+)
+
+type preCheckProvider struct {
+	fakeProvider
+	err error
+}
+
+func (p *preCheckProvider) PreCheck(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster) error {
+	return p.err
+}
+
+func TestController_onCreatePreCheck(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantPhase  platformv1.MachinePhase
+		wantStatus platformv1.ConditionStatus
+		wantCreate bool
+	}{
+		{"passed", nil, platformv1.MachineRunning, platformv1.ConditionTrue, true},
+		{"failed", errors.New("disk space of / is 1GiB, at least 10GiB is required"), platformv1.MachineFailed, platformv1.ConditionFalse, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			created := false
+			install := func(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster) error {
+				created = true
+				return nil
+			}
+			providerName := registerFakeProvider(t, &preCheckProvider{
+				fakeProvider: fakeProvider{
+					DelegateProvider: machineprovider.DelegateProvider{
+						CreateHandlers: []machineprovider.Handler{install},
+					},
+				},
+				err: tt.err,
+			})
+			machine := newMachineForTest("1", nil, platformv1.MachineInitializing, []platformv1.MachineCondition{})
+			machine.Name = "machine"
+			machine.Spec.Type = providerName
+			c := newControllerForTest(machine)
+			c.getCluster = fakeGetCluster
+			c.createSteps = newProcessedVersions()
+
+			if err := c.onCreate(context.Background(), machine); err != nil {
+				t.Fatalf("onCreate() error = %v", err)
+			}
+			if created != tt.wantCreate {
+				t.Errorf("OnCreate called = %v, want %v", created, tt.wantCreate)
+			}
+			got, err := c.platformClient.Machines().Get(context.Background(), machine.Name, v1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Status.Phase != tt.wantPhase {
+				t.Errorf("phase = %s, want %s", got.Status.Phase, tt.wantPhase)
+			}
+			condition := got.GetCondition(conditionTypePreCheck)
+			if condition == nil || condition.Status != tt.wantStatus {
+				t.Fatalf("pre-check condition = %+v, want status %s", condition, tt.wantStatus)
+			}
+			if tt.err != nil && (condition.Reason != reasonPreCheckFailed || condition.Message != tt.err.Error()) {
+				t.Errorf("pre-check condition = %+v, want the error of pre-check", condition)
+			}
+		})
+	}
+}
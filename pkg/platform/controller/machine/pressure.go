@@ -0,0 +1,120 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+
+	platformv1 "tkestack.io/tke/api/platform/v1"
+)
+
+const (
+	conditionTypeSustainedPressure = "SustainedPressure"
+	reasonNodePressure             = "NodePressure"
+)
+
+// pressureConditions are the node conditions reporting resource pressure.
+var pressureConditions = []corev1.NodeConditionType{
+	corev1.NodeMemoryPressure,
+	corev1.NodeDiskPressure,
+	corev1.NodePIDPressure,
+}
+
+// pressureTracker counts the consecutive probes in which the node of machine
+// is under resource pressure, by the UID of machines, so that only sustained
+// pressure is reported rather than every transient one.
+type pressureTracker struct {
+	mu        sync.Mutex
+	threshold int
+	counts    map[string]int
+}
+
+// newPressureTracker returns nil if the threshold is not positive, which
+// doesn't track pressure at all.
+func newPressureTracker(threshold int) *pressureTracker {
+	if threshold <= 0 {
+		return nil
+	}
+	return &pressureTracker{threshold: threshold, counts: make(map[string]int)}
+}
+
+// observe records the pressure of node in a probe, and returns true if the
+// node has been under pressure in enough consecutive probes.
+func (t *pressureTracker) observe(uid string, pressured bool) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !pressured {
+		delete(t.counts, uid)
+		return false
+	}
+	t.counts[uid]++
+	return t.counts[uid] >= t.threshold
+}
+
+func (t *pressureTracker) forget(uid string) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.counts, uid)
+}
+
+// nodePressures returns the pressure conditions of node which are true.
+func nodePressures(node *corev1.Node) []string {
+	var pressures []string
+	for _, condition := range node.Status.Conditions {
+		for _, pressure := range pressureConditions {
+			if condition.Type == pressure && condition.Status == corev1.ConditionTrue {
+				pressures = append(pressures, string(condition.Type))
+			}
+		}
+	}
+	return pressures
+}
+
+// syncSustainedPressure sets the SustainedPressure condition of machine if
+// its node has been under pressure in enough consecutive probes, and removes
+// it once the pressure is gone.
+func (c *Controller) syncSustainedPressure(machine *platformv1.Machine, node *corev1.Node) {
+	if c.pressure == nil {
+		return
+	}
+	pressures := nodePressures(node)
+	if !c.pressure.observe(string(machine.UID), len(pressures) > 0) {
+		if len(pressures) == 0 {
+			removeCondition(machine, conditionTypeSustainedPressure)
+		}
+		return
+	}
+	message := fmt.Sprintf("node has %s in %d consecutive probes", strings.Join(pressures, ", "), c.pressure.threshold)
+	if condition := machine.GetCondition(conditionTypeSustainedPressure); condition != nil && condition.Message == message {
+		return
+	}
+	machine.SetCondition(platformv1.MachineCondition{
+		Type:    conditionTypeSustainedPressure,
+		Status:  platformv1.ConditionTrue,
+		Reason:  reasonNodePressure,
+		Message: message,
+	})
+}
@@ -0,0 +1,80 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+
+	platformv1 "tkestack.io/tke/api/platform/v1"
+	typesv1 "tkestack.io/tke/pkg/platform/types/v1"
+)
+
+func TestController_syncSustainedPressure(t *testing.T) {
+	machine := newMachineForTest("1", nil, platformv1.MachineRunning, nil)
+	machine.Name = "machine"
+	node := &corev1.Node{ObjectMeta: v1.ObjectMeta{Name: machine.Spec.IP}}
+	client := kubefake.NewSimpleClientset(node)
+	c := newControllerForTest(machine)
+	c.pressure = newPressureTracker(3)
+	c.clientsetFor = func(cluster *typesv1.Cluster) (kubernetes.Interface, error) {
+		return client, nil
+	}
+	probe := func(pressure corev1.ConditionStatus) bool {
+		t.Helper()
+		got, err := client.CoreV1().Nodes().Get(context.Background(), node.Name, v1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		got.Status.Conditions = []corev1.NodeCondition{
+			{Type: corev1.NodeReady, Status: corev1.ConditionTrue},
+			{Type: corev1.NodeMemoryPressure, Status: pressure},
+		}
+		if _, err := client.CoreV1().Nodes().UpdateStatus(context.Background(), got, v1.UpdateOptions{}); err != nil {
+			t.Fatal(err)
+		}
+		c.syncNodeInfo(context.Background(), machine, &typesv1.Cluster{}, nil)
+		condition := machine.GetCondition(conditionTypeSustainedPressure)
+		return condition != nil && condition.Status == platformv1.ConditionTrue
+	}
+
+	if probe(corev1.ConditionTrue) || probe(corev1.ConditionTrue) {
+		t.Fatalf("sustained pressure is reported before 3 consecutive pressured probes")
+	}
+	// transient pressure is gone
+	if probe(corev1.ConditionFalse) {
+		t.Fatalf("sustained pressure is reported without pressure")
+	}
+	for i := 0; i < 2; i++ {
+		if probe(corev1.ConditionTrue) {
+			t.Fatalf("sustained pressure is reported after %d pressured probes", i+1)
+		}
+	}
+	if !probe(corev1.ConditionTrue) {
+		t.Fatalf("sustained pressure is not reported after 3 consecutive pressured probes")
+	}
+	if probe(corev1.ConditionFalse) {
+		t.Errorf("sustained pressure is still reported after pressure is gone")
+	}
+}
@@ -0,0 +1,105 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// controllerProbe tracks the workers and health checks of the controller for
+// its liveness and readiness probes.
+type controllerProbe struct {
+	// workers is the number of workers started by Run.
+	workers int32
+	// running is the number of workers still running.
+	running int32
+	// healthChecks is the number of health checks in flight.
+	healthChecks int64
+}
+
+// Healthz returns an error if the controller isn't alive, which is when its
+// workers aren't all running once started, or more health checks are in
+// flight than the machines could have, i.e. health checks are leaked.
+func (c *Controller) Healthz() error {
+	workers := atomic.LoadInt32(&c.probe.workers)
+	if workers == 0 {
+		return nil
+	}
+	if running := atomic.LoadInt32(&c.probe.running); running < workers {
+		return fmt.Errorf("%d of %d workers are running", running, workers)
+	}
+	machines, err := c.lister.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	// a machine has at most one health check in flight, but the one of a
+	// machine just deleted from cache may not be done yet
+	inFlight := atomic.LoadInt64(&c.probe.healthChecks)
+	if limit := int64(len(machines)) + int64(workers); inFlight > limit {
+		return fmt.Errorf("%d health checks are in flight for %d machines", inFlight, len(machines))
+	}
+	return nil
+}
+
+// Readyz returns an error until the machine caches are synced and workers
+// are started, then if the controller isn't alive.
+func (c *Controller) Readyz() error {
+	if c.listerSynced == nil || !c.listerSynced() {
+		return errors.New("machine caches are not synced")
+	}
+	if atomic.LoadInt32(&c.probe.workers) == 0 {
+		return errors.New("workers are not started")
+	}
+	return c.Healthz()
+}
+
+// ProbeHandler serves Healthz on /healthz and Readyz on /readyz, for the
+// liveness and readiness probes of kubelet.
+func (c *Controller) ProbeHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", probeHandlerFunc(c.Healthz))
+	mux.HandleFunc("/readyz", probeHandlerFunc(c.Readyz))
+	return mux
+}
+
+func probeHandlerFunc(probe func() error) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := probe(); err != nil {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}
+}
+
+// startWorker runs the worker until stopCh is closed, counting it running.
+func (c *Controller) startWorker(worker func(), stopCh <-chan struct{}) {
+	atomic.AddInt32(&c.probe.running, 1)
+	go func() {
+		defer atomic.AddInt32(&c.probe.running, -1)
+		wait.Until(worker, time.Second, stopCh)
+	}()
+}
@@ -0,0 +1,97 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/workqueue"
+
+	platformv1 "tkestack.io/tke/api/platform/v1"
+)
+
+func TestController_Readyz(t *testing.T) {
+	c := newControllerForTest()
+	c.queue = workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	var synced int32
+	c.listerSynced = func() bool { return atomic.LoadInt32(&synced) == 1 }
+	probe := func(path string) int {
+		recorder := httptest.NewRecorder()
+		c.ProbeHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+		return recorder.Code
+	}
+
+	stopCh := make(chan struct{})
+	done := make(chan error)
+	go func() { done <- c.Run(2, stopCh) }()
+
+	if err := c.Readyz(); err == nil {
+		t.Errorf("Readyz() = nil before caches are synced, want not ready")
+	}
+	if code := probe("/readyz"); code != http.StatusServiceUnavailable {
+		t.Errorf("/readyz = %d before caches are synced, want %d", code, http.StatusServiceUnavailable)
+	}
+	if err := c.Healthz(); err != nil {
+		t.Errorf("Healthz() = %v before caches are synced, want alive", err)
+	}
+
+	atomic.StoreInt32(&synced, 1)
+	err := wait.PollImmediate(10*time.Millisecond, 5*time.Second, func() (bool, error) {
+		return c.Readyz() == nil, nil
+	})
+	if err != nil {
+		t.Errorf("Readyz() = %v after caches are synced, want ready", c.Readyz())
+	}
+	if code := probe("/readyz"); code != http.StatusOK {
+		t.Errorf("/readyz = %d after caches are synced, want %d", code, http.StatusOK)
+	}
+	if code := probe("/healthz"); code != http.StatusOK {
+		t.Errorf("/healthz = %d, want %d", code, http.StatusOK)
+	}
+
+	close(stopCh)
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestController_HealthzLeakedHealthChecks(t *testing.T) {
+	machine := newMachineForTest("1", nil, platformv1.MachineRunning, nil)
+	machine.Name = "machine"
+	c := newControllerForTest(machine)
+	c.probe = controllerProbe{workers: 1, running: 1, healthChecks: 2}
+	if err := c.Healthz(); err != nil {
+		t.Errorf("Healthz() = %v, want alive", err)
+	}
+
+	c.probe.healthChecks = 3
+	if err := c.Healthz(); err == nil {
+		t.Errorf("Healthz() = nil with more health checks than machines, want leaked health checks")
+	}
+
+	c.probe = controllerProbe{workers: 2, running: 1}
+	if err := c.Healthz(); err == nil {
+		t.Errorf("Healthz() = nil with a stopped worker, want not alive")
+	}
+}
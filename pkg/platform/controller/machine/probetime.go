@@ -0,0 +1,41 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"time"
+
+	platformv1 "tkestack.io/tke/api/platform/v1"
+	machineprovider "tkestack.io/tke/pkg/platform/provider/machine"
+)
+
+// coalesceProbeTime keeps the probe time of conditions written before, if
+// nothing else of machine changed since old, and the health check condition
+// was written within the probe time write interval. The probe time is then
+// written at most once per interval rather than on every probe.
+func (c *Controller) coalesceProbeTime(old, new *platformv1.Machine) {
+	if c.probeTimeWriteInterval <= 0 {
+		return
+	}
+	condition := old.GetCondition(machineprovider.ConditionTypeHealthCheck)
+	if condition == nil || time.Since(condition.LastProbeTime.Time) >= c.probeTimeWriteInterval {
+		return
+	}
+	new.Status.Conditions = old.DeepCopy().Status.Conditions
+}
@@ -0,0 +1,99 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fakeplatformv1 "tkestack.io/tke/api/client/clientset/versioned/typed/platform/v1/fake"
+	platformv1 "tkestack.io/tke/api/platform/v1"
+	machineprovider "tkestack.io/tke/pkg/platform/provider/machine"
+	typesv1 "tkestack.io/tke/pkg/platform/types/v1"
+)
+
+func TestController_onUpdateCoalesceProbeTime(t *testing.T) {
+	healthy := platformv1.ConditionTrue
+	providerName := registerFakeProvider(t, &fakeProvider{
+		onHealthCheck: func(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster) *platformv1.Machine {
+			if healthy == platformv1.ConditionFalse {
+				machine.Status.Phase = platformv1.MachineFailed
+			}
+			machine.SetCondition(platformv1.MachineCondition{
+				Type:          machineprovider.ConditionTypeHealthCheck,
+				Status:        healthy,
+				LastProbeTime: v1.Now(),
+			})
+			return machine
+		},
+	})
+	tests := []struct {
+		name      string
+		interval  time.Duration
+		probedAgo time.Duration
+		health    platformv1.ConditionStatus
+		wantPatch bool
+	}{
+		{name: "within window", interval: time.Minute, probedAgo: 10 * time.Second, health: platformv1.ConditionTrue},
+		{name: "after window", interval: time.Minute, probedAgo: 2 * time.Minute, health: platformv1.ConditionTrue, wantPatch: true},
+		{name: "disabled", probedAgo: 10 * time.Second, health: platformv1.ConditionTrue, wantPatch: true},
+		{name: "status changed within window", interval: time.Minute, probedAgo: 10 * time.Second, health: platformv1.ConditionFalse, wantPatch: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			healthy = tt.health
+			probed := v1.NewTime(time.Now().Add(-tt.probedAgo))
+			machine := newMachineForTest("1", nil, platformv1.MachineRunning, nil)
+			machine.Name = "machine"
+			machine.Spec.Type = providerName
+			setReadyCondition(machine)
+			recordCluster(machine)
+			for i := range machine.Status.Conditions {
+				machine.Status.Conditions[i].LastProbeTime = probed
+			}
+			c := newControllerForTest(machine)
+			c.getCluster = fakeGetCluster
+			c.probeTimeWriteInterval = tt.interval
+
+			if err := c.onUpdate(context.Background(), machine.DeepCopy()); err != nil {
+				t.Fatalf("onUpdate() error = %v", err)
+			}
+			patches := 0
+			for _, action := range c.platformClient.(*fakeplatformv1.FakePlatformV1).Actions() {
+				if action.GetVerb() == "patch" {
+					patches++
+				}
+			}
+			if got := patches > 0; got != tt.wantPatch {
+				t.Errorf("patched = %v, want %v", got, tt.wantPatch)
+			}
+			got, err := c.platformClient.Machines().Get(context.Background(), machine.Name, v1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			written := got.GetCondition(machineprovider.ConditionTypeHealthCheck).LastProbeTime
+			if coalesced := written.Equal(&probed); coalesced == tt.wantPatch {
+				t.Errorf("probe time = %s, want coalesced %v", written, !tt.wantPatch)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"sync"
+	"time"
+
+	machineprovider "tkestack.io/tke/pkg/platform/provider/machine"
+)
+
+// ProgressEvent is an event of the provisioning progress of a machine.
+type ProgressEvent struct {
+	Machine string
+	// Step is the create step of provider, e.g. EnsureKubelet.
+	Step  string
+	State machineprovider.StepState
+	// Message is the failure of a failed step.
+	Message string
+	Time    time.Time
+}
+
+// progressHub delivers the provisioning progress of machines to subscribers,
+// e.g. UIs. Events are sent without blocking the controller, so a subscriber
+// which doesn't keep up misses the events beyond its buffer.
+type progressHub struct {
+	mu          sync.Mutex
+	subscribers map[string]map[chan ProgressEvent]struct{}
+}
+
+func newProgressHub() *progressHub {
+	return &progressHub{subscribers: make(map[string]map[chan ProgressEvent]struct{})}
+}
+
+// SubscribeProgress returns a channel of the provisioning progress events of
+// the named machine, or of all machines if the name is empty, buffering up
+// to size events. The returned function unsubscribes and closes the channel.
+func (c *Controller) SubscribeProgress(name string, size int) (<-chan ProgressEvent, func()) {
+	return c.progress.subscribe(name, size)
+}
+
+func (h *progressHub) subscribe(name string, size int) (<-chan ProgressEvent, func()) {
+	ch := make(chan ProgressEvent, size)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.subscribers[name] == nil {
+		h.subscribers[name] = make(map[chan ProgressEvent]struct{})
+	}
+	h.subscribers[name][ch] = struct{}{}
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			h.mu.Lock()
+			defer h.mu.Unlock()
+			delete(h.subscribers[name], ch)
+			if len(h.subscribers[name]) == 0 {
+				delete(h.subscribers, name)
+			}
+			close(ch)
+		})
+	}
+}
+
+// publish sends the event to the subscribers of its machine and of all
+// machines, dropping it for those whose buffer is full.
+func (h *progressHub) publish(event ProgressEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, name := range []string{event.Machine, ""} {
+		for ch := range h.subscribers[name] {
+			select {
+			case ch <- event:
+			default:
+			}
+		}
+	}
+}
+
+// reporter returns the step reporter of provider publishing the progress of
+// the named machine.
+func (h *progressHub) reporter(name string) machineprovider.StepReporter {
+	return func(step string, state machineprovider.StepState, err error) {
+		event := ProgressEvent{Machine: name, Step: step, State: state, Time: time.Now()}
+		if err != nil {
+			event.Message = err.Error()
+		}
+		h.publish(event)
+	}
+}
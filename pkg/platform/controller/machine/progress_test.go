@@ -0,0 +1,114 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	platformv1 "tkestack.io/tke/api/platform/v1"
+	machineprovider "tkestack.io/tke/pkg/platform/provider/machine"
+	typesv1 "tkestack.io/tke/pkg/platform/types/v1"
+)
+
+func TestController_onCreateProgress(t *testing.T) {
+	installed := false
+	install := machineprovider.Handler(func(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster) error {
+		if !installed {
+			installed = true
+			return errors.New("connection refused")
+		}
+		return nil
+	})
+	join := machineprovider.Handler(func(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster) error {
+		return nil
+	})
+	providerName := registerFakeProvider(t, &fakeProvider{
+		DelegateProvider: machineprovider.DelegateProvider{
+			CreateHandlers: []machineprovider.Handler{install, join},
+		},
+	})
+	machine := newMachineForTest("1", nil, platformv1.MachineInitializing, []platformv1.MachineCondition{})
+	machine.Name = "machine"
+	machine.Spec.Type = providerName
+	c := newControllerForTest(machine)
+	c.getCluster = fakeGetCluster
+	c.progress = newProgressHub()
+	events, unsubscribe := c.SubscribeProgress(machine.Name, 10)
+	others, unsubscribeOthers := c.SubscribeProgress("other", 10)
+	defer unsubscribeOthers()
+
+	for i := 0; i < 3; i++ {
+		latest, err := c.platformClient.Machines().Get(context.Background(), machine.Name, v1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = c.onCreate(context.Background(), latest)
+	}
+	unsubscribe()
+
+	type step struct {
+		Step    string
+		State   machineprovider.StepState
+		Message string
+	}
+	var got []step
+	for event := range events {
+		if event.Machine != machine.Name || event.Time.IsZero() {
+			t.Errorf("unexpected event %+v", event)
+		}
+		got = append(got, step{event.Step, event.State, event.Message})
+	}
+	want := []step{
+		{install.Name(), machineprovider.StepStarted, ""},
+		{install.Name(), machineprovider.StepFailed, "connection refused"},
+		{install.Name(), machineprovider.StepStarted, ""},
+		{install.Name(), machineprovider.StepCompleted, ""},
+		{join.Name(), machineprovider.StepStarted, ""},
+		{join.Name(), machineprovider.StepCompleted, ""},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("progress events = %+v, want %+v", got, want)
+	}
+	if n := len(others); n != 0 {
+		t.Errorf("subscriber of another machine got %d events, want none", n)
+	}
+}
+
+func TestProgressHub_nonBlocking(t *testing.T) {
+	h := newProgressHub()
+	events, unsubscribe := h.subscribe("", 1)
+	defer unsubscribe()
+
+	report := h.reporter("machine")
+	report("install", machineprovider.StepStarted, nil)
+	report("install", machineprovider.StepCompleted, nil)
+
+	if event := <-events; event.State != machineprovider.StepStarted {
+		t.Errorf("first event = %+v, want started", event)
+	}
+	select {
+	case event := <-events:
+		t.Errorf("event %+v beyond the buffer should be dropped", event)
+	default:
+	}
+}
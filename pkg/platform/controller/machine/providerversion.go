@@ -0,0 +1,73 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"fmt"
+
+	platformv1 "tkestack.io/tke/api/platform/v1"
+	machineprovider "tkestack.io/tke/pkg/platform/provider/machine"
+)
+
+const (
+	conditionTypeProviderVersionDrift = "ProviderVersionDrift"
+	reasonProviderUpgraded            = "ProviderUpgraded"
+)
+
+// recordProviderVersion records the version of provider which provisioned
+// the machine, nothing is recorded if the provider has no version.
+func recordProviderVersion(machine *platformv1.Machine) {
+	metadata, err := machineprovider.GetProviderMetadata(machine.Spec.Type)
+	if err != nil || metadata.Version == "" {
+		return
+	}
+	if machine.Annotations == nil {
+		machine.Annotations = make(map[string]string)
+	}
+	machine.Annotations[platformv1.MachineProviderVersionAnno] = metadata.Version
+}
+
+// checkProviderVersionDrift sets the ProviderVersionDrift condition if the
+// machine was provisioned by another version of the current provider, so
+// that machines predating a provider upgrade are visible. Machines without
+// recorded provider version are left unchanged.
+func checkProviderVersionDrift(machine *platformv1.Machine) {
+	provisioned, ok := machine.Annotations[platformv1.MachineProviderVersionAnno]
+	if !ok {
+		return
+	}
+	metadata, err := machineprovider.GetProviderMetadata(machine.Spec.Type)
+	if err != nil || metadata.Version == "" {
+		return
+	}
+	if provisioned == metadata.Version {
+		removeCondition(machine, conditionTypeProviderVersionDrift)
+		return
+	}
+	message := fmt.Sprintf("machine was provisioned by provider %s, current version is %s", provisioned, metadata.Version)
+	if condition := machine.GetCondition(conditionTypeProviderVersionDrift); condition != nil && condition.Message == message {
+		return
+	}
+	machine.SetCondition(platformv1.MachineCondition{
+		Type:    conditionTypeProviderVersionDrift,
+		Status:  platformv1.ConditionTrue,
+		Reason:  reasonProviderUpgraded,
+		Message: message,
+	})
+}
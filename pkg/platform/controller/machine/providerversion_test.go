@@ -0,0 +1,75 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"fmt"
+	"testing"
+
+	platformv1 "tkestack.io/tke/api/platform/v1"
+	machineprovider "tkestack.io/tke/pkg/platform/provider/machine"
+)
+
+func TestCheckProviderVersionDrift(t *testing.T) {
+	providerName := fmt.Sprintf("Fake-%s", t.Name())
+	machineprovider.RegisterWithMetadata(providerName, &fakeProvider{}, machineprovider.ProviderMetadata{Version: "v2"})
+
+	provisioned := newMachineForTest("1", nil, platformv1.MachineRunning, nil)
+	provisioned.Spec.Type = providerName
+	recordProviderVersion(provisioned)
+	if version := provisioned.Annotations[platformv1.MachineProviderVersionAnno]; version != "v2" {
+		t.Fatalf("recorded provider version = %q, want v2", version)
+	}
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		wantDrift   bool
+	}{
+		{name: "same version", annotations: map[string]string{platformv1.MachineProviderVersionAnno: "v2"}},
+		{name: "older version", annotations: map[string]string{platformv1.MachineProviderVersionAnno: "v1"}, wantDrift: true},
+		{name: "unknown version"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			machine := newMachineForTest("1", nil, platformv1.MachineRunning, nil)
+			machine.Spec.Type = providerName
+			machine.Annotations = tt.annotations
+
+			checkProviderVersionDrift(machine)
+			condition := machine.GetCondition(conditionTypeProviderVersionDrift)
+			if got := condition != nil && condition.Status == platformv1.ConditionTrue; got != tt.wantDrift {
+				t.Errorf("provider version drift = %v, want %v", got, tt.wantDrift)
+			}
+			if condition := machine.GetCondition(machineprovider.ConditionTypeHealthCheck); condition == nil || condition.Status != platformv1.ConditionTrue {
+				t.Errorf("health condition = %+v, want unchanged", condition)
+			}
+		})
+	}
+
+	drifted := newMachineForTest("1", nil, platformv1.MachineRunning, nil)
+	drifted.Spec.Type = providerName
+	drifted.Annotations = map[string]string{platformv1.MachineProviderVersionAnno: "v1"}
+	checkProviderVersionDrift(drifted)
+	drifted.Annotations[platformv1.MachineProviderVersionAnno] = "v2"
+	checkProviderVersionDrift(drifted)
+	if condition := drifted.GetCondition(conditionTypeProviderVersionDrift); condition != nil {
+		t.Errorf("provider version drift condition = %+v, want removed after reprovisioning", condition)
+	}
+}
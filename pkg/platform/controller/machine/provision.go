@@ -0,0 +1,96 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	platformv1 "tkestack.io/tke/api/platform/v1"
+	machineprovider "tkestack.io/tke/pkg/platform/provider/machine"
+	typesv1 "tkestack.io/tke/pkg/platform/types/v1"
+	"tkestack.io/tke/pkg/util/log"
+)
+
+const (
+	conditionTypeProvisionGuard = "ProvisionGuard"
+	reasonNodeAlreadyReady      = "NodeAlreadyReady"
+)
+
+// errNodeAlreadyReady is returned by onCreate when it refuses to provision
+// a machine whose node is already Ready.
+var errNodeAlreadyReady = errors.New("node of machine is already ready")
+
+// hasPendingCreateStep returns true if a create step of the machine is
+// waiting to be executed or failed, i.e. provisioning is in progress.
+func hasPendingCreateStep(machine *platformv1.Machine) bool {
+	for _, condition := range machine.Status.Conditions {
+		if condition.Status == platformv1.ConditionTrue {
+			continue
+		}
+		if condition.Reason == machineprovider.ReasonWaiting || condition.Reason == machineprovider.ReasonFailedInit {
+			return true
+		}
+	}
+	return false
+}
+
+// guardProvision refuses to start provisioning a machine whose node is
+// already Ready, which means the machine is actually running and its phase
+// is stale or corrupted, rather than reinstalling it. The machine is marked
+// by a condition for operators to fix it.
+func (c *Controller) guardProvision(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster) error {
+	if hasPendingCreateStep(machine) {
+		return nil
+	}
+	client, err := c.clientsetFor(cluster)
+	if err != nil {
+		return err
+	}
+	node, err := getNode(ctx, client, machine)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !isNodeReady(node) {
+		return nil
+	}
+
+	message := fmt.Sprintf("node %s is already ready, refuse to provision the machine again", node.Name)
+	log.FromContext(ctx).Info("Refuse to provision machine whose node is ready", "node", node.Name)
+	if condition := machine.GetCondition(conditionTypeProvisionGuard); condition == nil || condition.Message != message {
+		machine = machine.DeepCopy()
+		machine.SetCondition(platformv1.MachineCondition{
+			Type:    conditionTypeProvisionGuard,
+			Status:  platformv1.ConditionFalse,
+			Reason:  reasonNodeAlreadyReady,
+			Message: message,
+		})
+		if _, err := c.platformClient.Machines().Update(ctx, machine, metav1.UpdateOptions{}); err != nil {
+			return err
+		}
+	}
+	return errNodeAlreadyReady
+}
@@ -0,0 +1,106 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+
+	platformv1 "tkestack.io/tke/api/platform/v1"
+	typesv1 "tkestack.io/tke/pkg/platform/types/v1"
+)
+
+// connectRecorder is a proxy which records the hosts tunneled to and refuses
+// the tunnels.
+type connectRecorder struct {
+	mu    sync.Mutex
+	hosts []string
+}
+
+func (r *connectRecorder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method == http.MethodConnect {
+		r.mu.Lock()
+		r.hosts = append(r.hosts, req.Host)
+		r.mu.Unlock()
+	}
+	w.WriteHeader(http.StatusBadGateway)
+}
+
+func (r *connectRecorder) tunneled(host string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, h := range r.hosts {
+		if h == host {
+			return true
+		}
+	}
+	return false
+}
+
+func newClusterWithProxy(proxyURL string) *typesv1.Cluster {
+	cluster := &typesv1.Cluster{Cluster: &platformv1.Cluster{
+		ObjectMeta: v1.ObjectMeta{
+			Name:        "isolated",
+			Annotations: map[string]string{platformv1.ClusterProxyURLAnno: proxyURL},
+		},
+	}}
+	cluster.RegisterRestConfig(&rest.Config{Host: "https://10.0.0.1:6443"})
+	return cluster
+}
+
+func TestClusterProxy(t *testing.T) {
+	recorder := &connectRecorder{}
+	proxy := httptest.NewServer(recorder)
+	defer proxy.Close()
+	cluster := newClusterWithProxy(proxy.URL)
+
+	client, err := cluster.Clientset()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.CoreV1().Nodes().Get(context.Background(), "node", v1.GetOptions{}); err == nil {
+		t.Fatalf("get node through a refusing proxy succeeded")
+	}
+	if !recorder.tunneled("10.0.0.1:6443") {
+		t.Errorf("cluster API isn't reached through the proxy, tunneled hosts = %v", recorder.hosts)
+	}
+
+	c := &Controller{kubeletProber: newKubeletProber("/healthz")}
+	machine := newMachineForTest("1", nil, platformv1.MachineRunning, nil)
+	machine.Spec.IP = "10.0.0.2"
+	if err := c.checkKubelet(context.Background(), machine, cluster); err == nil {
+		t.Fatalf("kubelet probe through a refusing proxy succeeded")
+	}
+	if !recorder.tunneled("10.0.0.2:10250") {
+		t.Errorf("kubelet isn't probed through the proxy, tunneled hosts = %v", recorder.hosts)
+	}
+}
+
+func TestClusterProxyInvalid(t *testing.T) {
+	cluster := newClusterWithProxy("ftp://proxy.example.com")
+	if _, err := cluster.Clientset(); err == nil {
+		t.Errorf("Clientset() error = nil, want error for unsupported proxy scheme")
+	}
+}
@@ -0,0 +1,85 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	platformv1 "tkestack.io/tke/api/platform/v1"
+	machineprovider "tkestack.io/tke/pkg/platform/provider/machine"
+)
+
+// setReadyCondition derives the MachineConditionReady condition, which other
+// controllers gate on, from the phase and the health condition of machine.
+// Unlike SetCondition it leaves Status.Reason and Status.Message to the
+// health condition, and keeps the condition untouched while nothing changed
+// so that it doesn't churn patches.
+func setReadyCondition(machine *platformv1.Machine) {
+	ready := readyCondition(machine)
+	if old := machine.GetCondition(platformv1.MachineConditionReady); old != nil {
+		if old.Status == ready.Status && old.Reason == ready.Reason && old.Message == ready.Message {
+			return
+		}
+		if old.Status == ready.Status {
+			ready.LastTransitionTime = old.LastTransitionTime
+		}
+	}
+	replaceCondition(machine, ready)
+}
+
+func readyCondition(machine *platformv1.Machine) platformv1.MachineCondition {
+	now := metav1.Now()
+	condition := platformv1.MachineCondition{
+		Type:               platformv1.MachineConditionReady,
+		Status:             platformv1.ConditionFalse,
+		LastProbeTime:      now,
+		LastTransitionTime: now,
+	}
+	health := machine.GetCondition(machineprovider.ConditionTypeHealthCheck)
+	switch machine.Status.Phase {
+	case platformv1.MachineInitializing:
+		condition.Reason = platformv1.MachineReadyReasonProvisioning
+	case platformv1.MachineUpgrading:
+		condition.Reason = platformv1.MachineReadyReasonUpgrading
+	case platformv1.MachineTerminating:
+		condition.Reason = platformv1.MachineReadyReasonTerminating
+	case platformv1.MachineFailed:
+		condition.Reason = platformv1.MachineReadyReasonUnhealthy
+		if health != nil {
+			condition.Message = health.Message
+		}
+	default:
+		switch {
+		case health == nil && machine.Status.Phase == platformv1.MachineRunning,
+			health != nil && health.Status == platformv1.ConditionTrue:
+			condition.Status = platformv1.ConditionTrue
+			condition.Reason = platformv1.MachineReadyReasonHealthy
+		case health != nil && health.Status == platformv1.ConditionUnknown:
+			condition.Status = platformv1.ConditionUnknown
+			condition.Reason = platformv1.MachineReadyReasonHealthUnknown
+			condition.Message = health.Message
+		default:
+			condition.Reason = platformv1.MachineReadyReasonUnhealthy
+			if health != nil {
+				condition.Message = health.Message
+			}
+		}
+	}
+
+	return condition
+}
@@ -0,0 +1,105 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"context"
+	"testing"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	platformv1 "tkestack.io/tke/api/platform/v1"
+	machineprovider "tkestack.io/tke/pkg/platform/provider/machine"
+	typesv1 "tkestack.io/tke/pkg/platform/types/v1"
+)
+
+func TestController_onUpdateReadyCondition(t *testing.T) {
+	tests := []struct {
+		name       string
+		health     platformv1.ConditionStatus
+		wantStatus platformv1.ConditionStatus
+		wantReason string
+	}{
+		{"healthy", platformv1.ConditionTrue, platformv1.ConditionTrue, platformv1.MachineReadyReasonHealthy},
+		{"unhealthy", platformv1.ConditionFalse, platformv1.ConditionFalse, platformv1.MachineReadyReasonUnhealthy},
+		{"unknown", platformv1.ConditionUnknown, platformv1.ConditionUnknown, platformv1.MachineReadyReasonHealthUnknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			providerName := registerFakeProvider(t, &fakeProvider{
+				onUpdate: func(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster, options machineprovider.UpdateOptions) error {
+					return nil
+				},
+				onHealthCheck: func(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster) *platformv1.Machine {
+					machine.SetCondition(platformv1.MachineCondition{
+						Type:    machineprovider.ConditionTypeHealthCheck,
+						Status:  tt.health,
+						Reason:  "Probe",
+						Message: "probe " + tt.name,
+					})
+					return machine
+				},
+			})
+			machine := newMachineForTest("1", nil, platformv1.MachineRunning, nil)
+			machine.Name = "machine"
+			machine.Spec.Type = providerName
+			c := newControllerForTest(machine)
+			c.getCluster = fakeGetCluster
+
+			_ = c.onUpdate(context.Background(), machine.DeepCopy())
+			got, err := c.platformClient.Machines().Get(context.Background(), machine.Name, v1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			ready := got.GetCondition(platformv1.MachineConditionReady)
+			if ready == nil {
+				t.Fatalf("machine has no %s condition", platformv1.MachineConditionReady)
+			}
+			if ready.Status != tt.wantStatus || ready.Reason != tt.wantReason {
+				t.Errorf("%s condition = %s/%s, want %s/%s", platformv1.MachineConditionReady, ready.Status, ready.Reason, tt.wantStatus, tt.wantReason)
+			}
+			if got.IsReady() != (tt.wantStatus == platformv1.ConditionTrue) {
+				t.Errorf("IsReady() = %v with %s health", got.IsReady(), tt.health)
+			}
+			if tt.health != platformv1.ConditionTrue && ready.Message != "probe "+tt.name {
+				t.Errorf("%s condition message = %q, want the health check message", platformv1.MachineConditionReady, ready.Message)
+			}
+			if tt.health == platformv1.ConditionFalse && got.Status.Reason != "Probe" {
+				t.Errorf("status reason = %q, want it left to the health condition", got.Status.Reason)
+			}
+		})
+	}
+}
+
+func TestSetReadyConditionStable(t *testing.T) {
+	machine := newMachineForTest("1", nil, platformv1.MachineRunning, nil)
+	setReadyCondition(machine)
+	first := *machine.GetCondition(platformv1.MachineConditionReady)
+
+	setReadyCondition(machine)
+	if got := *machine.GetCondition(platformv1.MachineConditionReady); got != first {
+		t.Errorf("%s condition changed without health change: %+v, want %+v", platformv1.MachineConditionReady, got, first)
+	}
+
+	machine.Status.Phase = platformv1.MachineUpgrading
+	setReadyCondition(machine)
+	got := machine.GetCondition(platformv1.MachineConditionReady)
+	if got.Status != platformv1.ConditionFalse || got.Reason != platformv1.MachineReadyReasonUpgrading {
+		t.Errorf("%s condition = %s/%s while upgrading, want %s/%s", platformv1.MachineConditionReady, got.Status, got.Reason, platformv1.ConditionFalse, platformv1.MachineReadyReasonUpgrading)
+	}
+}
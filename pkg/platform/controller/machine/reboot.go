@@ -0,0 +1,187 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	platformv1 "tkestack.io/tke/api/platform/v1"
+	clusterapi "tkestack.io/tke/pkg/platform/apiserver/cluster"
+	"tkestack.io/tke/pkg/platform/apiserver/cluster/drain"
+	machineprovider "tkestack.io/tke/pkg/platform/provider/machine"
+	typesv1 "tkestack.io/tke/pkg/platform/types/v1"
+	"tkestack.io/tke/pkg/util/apiclient"
+	"tkestack.io/tke/pkg/util/log"
+)
+
+const (
+	rebootStateDraining  = "Draining"
+	rebootStateRebooting = "Rebooting"
+
+	conditionTypeReboot      = "Reboot"
+	reasonRebootNotSupported = "RebootNotSupported"
+
+	// rebootPollPeriod is the period to check whether the node is back.
+	rebootPollPeriod = 10 * time.Second
+)
+
+// errWaitingForReboot is returned while the node of machine is rebooting,
+// the machine is requeued after rebootPollPeriod.
+var errWaitingForReboot = errors.New("waiting for reboot")
+
+// reboot drives a requested reboot of the machine node step by step:
+// the node is cordoned and drained, rebooted by the provider, and
+// uncordoned once it comes back Ready with a new boot id.
+func (c *Controller) reboot(ctx context.Context, provider machineprovider.Provider, machine *platformv1.Machine, cluster *typesv1.Cluster) error {
+	machine = machine.DeepCopy()
+	state := machine.Annotations[platformv1.MachineRebootStateAnno]
+	logger := log.FromContext(ctx).WithValues("rebootState", state)
+
+	rebooter, ok := provider.(machineprovider.Rebooter)
+	if !ok {
+		logger.Info("Machine provider doesn't support reboot", "provider", provider.Name())
+		delete(machine.Annotations, platformv1.MachineRebootRequestedAnno)
+		delete(machine.Annotations, platformv1.MachineRebootStateAnno)
+		machine.SetCondition(platformv1.MachineCondition{
+			Type:    conditionTypeReboot,
+			Status:  platformv1.ConditionFalse,
+			Reason:  reasonRebootNotSupported,
+			Message: fmt.Sprintf("provider %s doesn't support reboot", provider.Name()),
+		})
+		_, err := c.platformClient.Machines().Update(ctx, machine, metav1.UpdateOptions{})
+		return err
+	}
+
+	client, err := c.clientsetFor(cluster)
+	if err != nil {
+		return err
+	}
+	node, err := getNode(ctx, client, machine)
+	if err != nil {
+		return err
+	}
+
+	switch state {
+	case "":
+		logger.Info("Reboot is requested", "node", node.Name)
+		machine.Annotations[platformv1.MachineRebootStateAnno] = rebootStateDraining
+		machine.Annotations[platformv1.MachineRebootBootIDAnno] = node.Status.NodeInfo.BootID
+		if _, err := c.platformClient.Machines().Update(ctx, machine, metav1.UpdateOptions{}); err != nil {
+			return err
+		}
+		return errRequeue
+	case rebootStateDraining:
+		if err := clusterapi.DrainNodeWithGracePeriod(ctx, client, node, drainGracePeriodSeconds(machine)); err != nil {
+			return err
+		}
+		logger.Info("Node is drained, reboot it", "node", node.Name)
+		if err := rebooter.OnReboot(ctx, machine, cluster); err != nil {
+			return err
+		}
+		machine.Annotations[platformv1.MachineRebootStateAnno] = rebootStateRebooting
+		if _, err := c.platformClient.Machines().Update(ctx, machine, metav1.UpdateOptions{}); err != nil {
+			return err
+		}
+		return errWaitingForReboot
+	case rebootStateRebooting:
+		if node.Status.NodeInfo.BootID == machine.Annotations[platformv1.MachineRebootBootIDAnno] || !isNodeReady(node) {
+			return errWaitingForReboot
+		}
+		if err := setUnschedulable(ctx, client, node, false); err != nil {
+			return err
+		}
+		logger.Info("Node is back after reboot", "node", node.Name)
+		delete(machine.Annotations, platformv1.MachineRebootRequestedAnno)
+		delete(machine.Annotations, platformv1.MachineRebootStateAnno)
+		delete(machine.Annotations, platformv1.MachineRebootBootIDAnno)
+		machine.SetCondition(platformv1.MachineCondition{
+			Type:   conditionTypeReboot,
+			Status: platformv1.ConditionTrue,
+		})
+		_, err := c.platformClient.Machines().Update(ctx, machine, metav1.UpdateOptions{})
+		return err
+	default:
+		return fmt.Errorf("unknown reboot state %q", state)
+	}
+}
+
+// drainGracePeriodSeconds returns the grace period to evict pods of machine
+// node, which could be overridden by annotation of machine.
+func drainGracePeriodSeconds(machine *platformv1.Machine) int {
+	value, ok := machine.Annotations[platformv1.MachineDrainGraceSecondsAnno]
+	if !ok {
+		return clusterapi.DefaultDrainGracePeriodSeconds
+	}
+	seconds, err := strconv.Atoi(value)
+	if err != nil || seconds < 0 {
+		return clusterapi.DefaultDrainGracePeriodSeconds
+	}
+	return seconds
+}
+
+// getNode returns the node of machine, by the resolved node name if any.
+// If the node of the name no longer exists, e.g. it's deleted and added again
+// under another name, the node is resolved by machine IP again and the
+// resolved name is updated.
+func getNode(ctx context.Context, client kubernetes.Interface, machine *platformv1.Machine) (*corev1.Node, error) {
+	name := machine.Status.NodeName
+	if name != "" {
+		node, err := client.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{})
+		if !apierrors.IsNotFound(err) {
+			return node, err
+		}
+	}
+	node, err := apiclient.GetNodeByMachineIP(ctx, client, machine.Spec.IP)
+	if err != nil || name == "" || node.Name == name {
+		return node, err
+	}
+	log.FromContext(ctx).Info("Node of machine has been renamed", "from", name, "to", node.Name)
+	machine.Status.NodeName = node.Name
+	return node, nil
+}
+
+func isNodeReady(node *corev1.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func setUnschedulable(ctx context.Context, client kubernetes.Interface, node *corev1.Node, unschedulable bool) error {
+	helper := drain.NewCordonHelper(node.DeepCopy())
+	if !helper.UpdateIfRequired(unschedulable) {
+		return nil
+	}
+	err, patchErr := helper.PatchOrReplace(ctx, client)
+	if patchErr != nil {
+		return patchErr
+	}
+	return err
+}
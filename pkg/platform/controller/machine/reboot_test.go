@@ -0,0 +1,235 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	platformv1 "tkestack.io/tke/api/platform/v1"
+	clusterapi "tkestack.io/tke/pkg/platform/apiserver/cluster"
+	"tkestack.io/tke/pkg/platform/apiserver/cluster/drain"
+	typesv1 "tkestack.io/tke/pkg/platform/types/v1"
+	"tkestack.io/tke/pkg/util/apiclient"
+)
+
+// rebootingProvider is a fake provider which supports reboot.
+type rebootingProvider struct {
+	fakeProvider
+	reboots int
+}
+
+func (p *rebootingProvider) OnReboot(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster) error {
+	p.reboots++
+	return nil
+}
+
+func TestController_reboot(t *testing.T) {
+	provider := &rebootingProvider{}
+	providerName := registerFakeProvider(t, provider)
+	machine := newMachineForTest("1", nil, platformv1.MachineRunning, nil)
+	machine.Name = "machine"
+	machine.Spec.Type = providerName
+	machine.Annotations = map[string]string{platformv1.MachineRebootRequestedAnno: ""}
+	c := newControllerForTest(machine)
+	c.getCluster = fakeGetCluster
+
+	node := &corev1.Node{
+		ObjectMeta: v1.ObjectMeta{Name: machine.Spec.IP},
+		Status: corev1.NodeStatus{
+			NodeInfo:   corev1.NodeSystemInfo{BootID: "boot-1"},
+			Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}},
+		},
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: v1.ObjectMeta{Name: "pod", Namespace: "default"},
+		Spec:       corev1.PodSpec{NodeName: node.Name},
+	}
+	client := kubefake.NewSimpleClientset(node, pod)
+	c.clientsetFor = func(cluster *typesv1.Cluster) (kubernetes.Interface, error) {
+		return client, nil
+	}
+	step := func(want error) *platformv1.Machine {
+		t.Helper()
+		current, err := c.platformClient.Machines().Get(context.Background(), machine.Name, v1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := c.onUpdate(context.Background(), current); !errors.Is(err, want) {
+			t.Fatalf("onUpdate() error = %v, want %v", err, want)
+		}
+		got, err := c.platformClient.Machines().Get(context.Background(), machine.Name, v1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return got
+	}
+	getNode := func() *corev1.Node {
+		got, err := client.CoreV1().Nodes().Get(context.Background(), node.Name, v1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return got
+	}
+
+	got := step(errRequeue)
+	if state := got.Annotations[platformv1.MachineRebootStateAnno]; state != rebootStateDraining {
+		t.Fatalf("reboot state = %q, want %q", state, rebootStateDraining)
+	}
+
+	got = step(errWaitingForReboot)
+	if state := got.Annotations[platformv1.MachineRebootStateAnno]; state != rebootStateRebooting {
+		t.Fatalf("reboot state = %q, want %q", state, rebootStateRebooting)
+	}
+	if !getNode().Spec.Unschedulable {
+		t.Errorf("node should be cordoned before reboot")
+	}
+	if _, err := client.CoreV1().Pods(pod.Namespace).Get(context.Background(), pod.Name, v1.GetOptions{}); err == nil {
+		t.Errorf("pod should be drained before reboot")
+	}
+	if provider.reboots != 1 {
+		t.Errorf("reboots = %d, want 1", provider.reboots)
+	}
+
+	// node has not been rebooted yet
+	step(errWaitingForReboot)
+
+	rebooted := getNode()
+	rebooted.Status.NodeInfo.BootID = "boot-2"
+	if _, err := client.CoreV1().Nodes().Update(context.Background(), rebooted, v1.UpdateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	got = step(nil)
+	if getNode().Spec.Unschedulable {
+		t.Errorf("node should be uncordoned after reboot")
+	}
+	for _, anno := range []string{platformv1.MachineRebootRequestedAnno, platformv1.MachineRebootStateAnno, platformv1.MachineRebootBootIDAnno} {
+		if _, ok := got.Annotations[anno]; ok {
+			t.Errorf("annotation %s should be cleared after reboot", anno)
+		}
+	}
+	if provider.reboots != 1 {
+		t.Errorf("reboots = %d, want 1", provider.reboots)
+	}
+}
+
+func TestController_rebootDrainGracePeriod(t *testing.T) {
+	provider := &rebootingProvider{}
+	providerName := registerFakeProvider(t, provider)
+	machine := newMachineForTest("1", nil, platformv1.MachineRunning, nil)
+	machine.Name = "machine"
+	machine.Spec.Type = providerName
+	machine.Annotations = map[string]string{
+		platformv1.MachineRebootRequestedAnno:   "",
+		platformv1.MachineRebootStateAnno:       rebootStateDraining,
+		platformv1.MachineDrainGraceSecondsAnno: "30",
+	}
+	c := newControllerForTest(machine)
+	c.getCluster = fakeGetCluster
+
+	node := &corev1.Node{ObjectMeta: v1.ObjectMeta{Name: machine.Spec.IP}}
+	pod := &corev1.Pod{
+		ObjectMeta: v1.ObjectMeta{Name: "pod", Namespace: "default"},
+		Spec:       corev1.PodSpec{NodeName: node.Name},
+	}
+	client := kubefake.NewSimpleClientset(node, pod)
+	client.Resources = []*v1.APIResourceList{
+		{GroupVersion: "policy/v1beta1"},
+		{GroupVersion: "v1", APIResources: []v1.APIResource{{Name: drain.EvictionSubresource, Kind: drain.EvictionKind}}},
+	}
+	var gracePeriods []int64
+	client.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "eviction" {
+			return false, nil, nil
+		}
+		eviction := action.(k8stesting.CreateAction).GetObject().(*policyv1beta1.Eviction)
+		gracePeriods = append(gracePeriods, *eviction.DeleteOptions.GracePeriodSeconds)
+		return true, nil, client.Tracker().Delete(corev1.SchemeGroupVersion.WithResource("pods"), eviction.Namespace, eviction.Name)
+	})
+	c.clientsetFor = func(cluster *typesv1.Cluster) (kubernetes.Interface, error) {
+		return client, nil
+	}
+
+	if err := c.onUpdate(context.Background(), machine); !errors.Is(err, errWaitingForReboot) {
+		t.Fatalf("onUpdate() error = %v, want %v", err, errWaitingForReboot)
+	}
+	if len(gracePeriods) != 1 || gracePeriods[0] != 30 {
+		t.Errorf("eviction grace periods = %v, want [30]", gracePeriods)
+	}
+}
+
+func TestDrainGracePeriodSeconds(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        int
+	}{
+		{"default", nil, clusterapi.DefaultDrainGracePeriodSeconds},
+		{"override", map[string]string{platformv1.MachineDrainGraceSecondsAnno: "0"}, 0},
+		{"invalid", map[string]string{platformv1.MachineDrainGraceSecondsAnno: "-1"}, clusterapi.DefaultDrainGracePeriodSeconds},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			machine := &platformv1.Machine{ObjectMeta: v1.ObjectMeta{Annotations: tt.annotations}}
+			if got := drainGracePeriodSeconds(machine); got != tt.want {
+				t.Errorf("drainGracePeriodSeconds() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetNodeRenamed(t *testing.T) {
+	machine := newMachineForTest("1", nil, platformv1.MachineRunning, nil)
+	machine.Status.NodeName = "old-node"
+	client := kubefake.NewSimpleClientset(&corev1.Node{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "new-node",
+			Labels: map[string]string{string(apiclient.LabelMachineIPV4): machine.Spec.IP},
+		},
+	})
+
+	node, err := getNode(context.Background(), client, machine)
+	if err != nil {
+		t.Fatalf("getNode() error = %v", err)
+	}
+	if node.Name != "new-node" {
+		t.Errorf("getNode() = %s, want new-node", node.Name)
+	}
+	if name := machine.Status.NodeName; name != "new-node" {
+		t.Errorf("resolved node name = %q, want new-node", name)
+	}
+
+	if err := client.CoreV1().Nodes().Delete(context.Background(), "new-node", v1.DeleteOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := getNode(context.Background(), client, machine); err == nil {
+		t.Errorf("getNode() expected error after node is deleted")
+	}
+	if name := machine.Status.NodeName; name != "new-node" {
+		t.Errorf("resolved node name = %q, want it kept until the node is resolved again", name)
+	}
+}
@@ -0,0 +1,58 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/util/uuid"
+
+	"tkestack.io/tke/pkg/util/log"
+)
+
+// eventAnnotationReconcileID is the event annotation of the ID of reconcile
+// in which the event is recorded. The ID isn't put in the message, so that
+// repeated events of different reconciles are still aggregated.
+const eventAnnotationReconcileID = "platform.tkestack.io/reconcile-id"
+
+type reconcileIDKey struct{}
+
+// withReconcileID returns a copy of ctx with a new reconcile ID, which is
+// added to its logger too, so that all the log lines and events of a single
+// reconcile could be correlated.
+func withReconcileID(ctx context.Context) context.Context {
+	id := string(uuid.NewUUID())
+	ctx = context.WithValue(ctx, reconcileIDKey{}, id)
+	return log.FromContext(ctx).WithValues("reconcileID", id).WithContext(ctx)
+}
+
+// reconcileID returns the reconcile ID of ctx if any.
+func reconcileID(ctx context.Context) string {
+	id, _ := ctx.Value(reconcileIDKey{}).(string)
+	return id
+}
+
+// eventAnnotations returns the annotations of events recorded in ctx.
+func eventAnnotations(ctx context.Context) map[string]string {
+	id := reconcileID(ctx)
+	if id == "" {
+		return nil
+	}
+	return map[string]string{eventAnnotationReconcileID: id}
+}
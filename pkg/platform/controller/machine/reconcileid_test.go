@@ -0,0 +1,111 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	platformv1 "tkestack.io/tke/api/platform/v1"
+	machineprovider "tkestack.io/tke/pkg/platform/provider/machine"
+	"tkestack.io/tke/pkg/util/log"
+)
+
+// annotationRecorder records the annotations of events.
+type annotationRecorder struct {
+	annotations []map[string]string
+}
+
+func (r *annotationRecorder) Event(object runtime.Object, eventtype, reason, message string) {
+	r.annotations = append(r.annotations, nil)
+}
+
+func (r *annotationRecorder) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+	r.annotations = append(r.annotations, nil)
+}
+
+func (r *annotationRecorder) AnnotatedEventf(object runtime.Object, annotations map[string]string, eventtype, reason, messageFmt string, args ...interface{}) {
+	r.annotations = append(r.annotations, annotations)
+}
+
+func TestController_syncMachineReconcileID(t *testing.T) {
+	providerName := registerFakeProvider(t, &fakeProvider{})
+	machine := newMachineForTest("1", nil, platformv1.MachineRunning, []platformv1.MachineCondition{
+		{
+			Type:    machineprovider.ConditionTypeHealthCheck,
+			Status:  platformv1.ConditionFalse,
+			Message: "node is not ready",
+		},
+	})
+	machine.Name = "machine"
+	machine.Spec.Type = providerName
+	core, logs := observer.New(zapcore.InfoLevel)
+	recorder := &annotationRecorder{}
+	c := newControllerForTest(machine)
+	c.log = log.NewLogger(zap.New(core))
+	c.getCluster = fakeGetCluster
+	c.recorder = recorder
+
+	reconcileIDs := func() map[string]int {
+		ids := map[string]int{}
+		for _, entry := range logs.TakeAll() {
+			id, ok := entry.ContextMap()["reconcileID"].(string)
+			if !ok {
+				t.Errorf("log %q has no reconcile ID", entry.Message)
+				continue
+			}
+			ids[id]++
+		}
+		return ids
+	}
+
+	if err := c.syncMachine(machine.Name); err != nil {
+		t.Fatalf("syncMachine() error = %v", err)
+	}
+	first := reconcileIDs()
+	if len(first) != 1 {
+		t.Fatalf("reconcile IDs = %v, want the same ID in all log lines of a reconcile", first)
+	}
+	var id string
+	for id = range first {
+	}
+	if first[id] < 2 {
+		t.Errorf("%d log lines of reconcile, want more than one to correlate", first[id])
+	}
+	if len(recorder.annotations) == 0 {
+		t.Fatal("no event recorded for the failed health check")
+	}
+	for _, annotations := range recorder.annotations {
+		if annotations[eventAnnotationReconcileID] != id {
+			t.Errorf("event annotations = %v, want reconcile ID %s", annotations, id)
+		}
+	}
+
+	if err := c.syncMachine(machine.Name); err != nil {
+		t.Fatalf("syncMachine() error = %v", err)
+	}
+	second := reconcileIDs()
+	if len(second) != 1 || second[id] != 0 {
+		t.Errorf("reconcile IDs = %v of the second reconcile, want a new ID other than %s", second, id)
+	}
+}
@@ -0,0 +1,95 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"reflect"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	platformv1 "tkestack.io/tke/api/platform/v1"
+)
+
+// reconcileStatsInterval is the min interval to persist reconcile stats.
+const reconcileStatsInterval = time.Minute
+
+// reconcileStats counts the meaningful reconciles of machines, which are
+// persisted in machine status along with a write made anyway, and at most
+// once per interval to avoid write amplification. The pending counts are kept
+// by the UID of machines.
+type reconcileStats struct {
+	mu       sync.Mutex
+	interval time.Duration
+	now      func() time.Time
+	pending  map[string]int
+}
+
+func newReconcileStats(interval time.Duration) *reconcileStats {
+	return &reconcileStats{
+		interval: interval,
+		now:      time.Now,
+		pending:  make(map[string]int),
+	}
+}
+
+// record counts a meaningful reconcile of the machine which is about to be
+// written, the stats are set into the machine once the interval passes.
+func (s *reconcileStats) record(machine *platformv1.Machine) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	uid := string(machine.UID)
+	s.pending[uid]++
+	now := s.now()
+	if last := machine.Status.LastReconcileTime; last != nil && now.Sub(last.Time) < s.interval {
+		return
+	}
+	machine.Status.ReconcileCount += int32(s.pending[uid])
+	delete(s.pending, uid)
+	lastReconcileTime := metav1.NewTime(now)
+	machine.Status.LastReconcileTime = &lastReconcileTime
+}
+
+func (s *reconcileStats) forget(uid string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.pending, uid)
+}
+
+// isMeaningfulChange returns true if the machine is changed by reconcile,
+// besides the probe time of conditions which changes on every probe.
+func isMeaningfulChange(old, new *platformv1.Machine) bool {
+	return !reflect.DeepEqual(withoutProbeTime(old), withoutProbeTime(new))
+}
+
+func withoutProbeTime(machine *platformv1.Machine) *platformv1.Machine {
+	machine = machine.DeepCopy()
+	for i := range machine.Status.Conditions {
+		machine.Status.Conditions[i].LastProbeTime = metav1.Time{}
+	}
+	return machine
+}
@@ -0,0 +1,87 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	platformv1 "tkestack.io/tke/api/platform/v1"
+	"tkestack.io/tke/pkg/util/log"
+)
+
+const (
+	// reasonResetFailed is set on machines reset by ResetFailedMachines.
+	reasonResetFailed = "ResetFailed"
+)
+
+// ResetFailedMachines resets all Failed machines of the given cluster to
+// Initializing, so that they are provisioned again by the controller.
+// Machines that are being deleted or are locked are left untouched.
+// It returns the number of machines that have been reset.
+func (c *Controller) ResetFailedMachines(ctx context.Context, clusterName string) (int, error) {
+	machines, err := c.lister.List(labels.Everything())
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		count int
+		errs  []error
+	)
+	for _, machine := range machines {
+		if !canResetMachine(machine, clusterName) {
+			continue
+		}
+		newMachine := machine.DeepCopy()
+		newMachine.Status.Phase = platformv1.MachineInitializing
+		newMachine.Status.Conditions = nil
+		newMachine.Status.Reason = reasonResetFailed
+		newMachine.Status.Message = "Machine has been reset from failed phase"
+		if _, err := c.platformClient.Machines().UpdateStatus(ctx, newMachine, metav1.UpdateOptions{}); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		log.FromContext(ctx).Info("Reset failed machine", "machine", machine.Name)
+		count++
+	}
+
+	return count, utilerrors.NewAggregate(errs)
+}
+
+// canResetMachine returns true if the machine belongs to the cluster, is
+// Failed and is neither being deleted nor locked.
+func canResetMachine(machine *platformv1.Machine, clusterName string) bool {
+	if machine.Spec.ClusterName != clusterName {
+		return false
+	}
+	if machine.Status.Phase != platformv1.MachineFailed {
+		return false
+	}
+	if machine.DeletionTimestamp != nil {
+		return false
+	}
+	if machine.Status.Locked != nil && *machine.Status.Locked {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,102 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"context"
+	"testing"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+	"tkestack.io/tke/api/client/clientset/versioned/fake"
+	platformv1lister "tkestack.io/tke/api/client/listers/platform/v1"
+	platformv1 "tkestack.io/tke/api/platform/v1"
+	typesv1 "tkestack.io/tke/pkg/platform/types/v1"
+)
+
+func newControllerForTest(machines ...*platformv1.Machine) *Controller {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	objects := make([]runtime.Object, 0, len(machines))
+	for _, machine := range machines {
+		_ = indexer.Add(machine)
+		objects = append(objects, machine)
+	}
+	return &Controller{
+		lister:         platformv1lister.NewMachineLister(indexer),
+		platformClient: fake.NewSimpleClientset(objects...).PlatformV1(),
+		clientsetFor: func(cluster *typesv1.Cluster) (kubernetes.Interface, error) {
+			return kubefake.NewSimpleClientset(), nil
+		},
+	}
+}
+
+func TestController_ResetFailedMachines(t *testing.T) {
+	named := func(name string, m *platformv1.Machine) *platformv1.Machine {
+		m.Name = name
+		return m
+	}
+	locked := true
+	lockedMachine := named("locked", newMachineForTest("1", nil, platformv1.MachineFailed, nil))
+	lockedMachine.Status.Locked = &locked
+	deletingMachine := named("deleting", newMachineForTest("1", nil, platformv1.MachineFailed, nil))
+	now := v1.Now()
+	deletingMachine.DeletionTimestamp = &now
+	otherCluster := named("other", newMachineForTest("1", nil, platformv1.MachineFailed, nil))
+	otherCluster.Spec.ClusterName = "other"
+
+	c := newControllerForTest(
+		named("failed-1", newMachineForTest("1", nil, platformv1.MachineFailed, nil)),
+		named("failed-2", newMachineForTest("1", nil, platformv1.MachineFailed, nil)),
+		named("running", newMachineForTest("1", nil, platformv1.MachineRunning, nil)),
+		named("initializing", newMachineForTest("1", nil, platformv1.MachineInitializing, nil)),
+		lockedMachine,
+		deletingMachine,
+		otherCluster,
+	)
+
+	count, err := c.ResetFailedMachines(context.Background(), "global")
+	if err != nil {
+		t.Fatalf("ResetFailedMachines() error = %v", err)
+	}
+	if count != 2 {
+		t.Errorf("ResetFailedMachines() = %d, want 2", count)
+	}
+
+	want := map[string]platformv1.MachinePhase{
+		"failed-1":     platformv1.MachineInitializing,
+		"failed-2":     platformv1.MachineInitializing,
+		"running":      platformv1.MachineRunning,
+		"initializing": platformv1.MachineInitializing,
+		"locked":       platformv1.MachineFailed,
+		"deleting":     platformv1.MachineFailed,
+		"other":        platformv1.MachineFailed,
+	}
+	for name, phase := range want {
+		machine, err := c.platformClient.Machines().Get(context.Background(), name, v1.GetOptions{})
+		if err != nil {
+			t.Fatalf("get machine %s error = %v", name, err)
+		}
+		if machine.Status.Phase != phase {
+			t.Errorf("machine %s phase = %s, want %s", name, machine.Status.Phase, phase)
+		}
+	}
+}
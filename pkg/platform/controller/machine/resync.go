@@ -0,0 +1,178 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+
+	platformv1 "tkestack.io/tke/api/platform/v1"
+	machineprovider "tkestack.io/tke/pkg/platform/provider/machine"
+)
+
+// processedVersions records the resourceVersion at which machines have been
+// reconciled successfully, it's used to drop redundant informer resyncs.
+type processedVersions struct {
+	mu       sync.Mutex
+	versions map[string]string
+}
+
+func newProcessedVersions() *processedVersions {
+	return &processedVersions{versions: make(map[string]string)}
+}
+
+func (p *processedVersions) set(key, version string) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.versions[key] = version
+}
+
+func (p *processedVersions) get(key string) string {
+	if p == nil {
+		return ""
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.versions[key]
+}
+
+func (p *processedVersions) forget(key string) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.versions, key)
+}
+
+// specDebouncer holds back the reconcile of machines until their spec hasn't
+// changed for the window, so rapid edits are reconciled once by the last spec.
+type specDebouncer struct {
+	window  time.Duration
+	mu      sync.Mutex
+	changes map[string]time.Time
+}
+
+// newSpecDebouncer returns nil if window is zero, which disables debouncing.
+func newSpecDebouncer(window time.Duration) *specDebouncer {
+	if window <= 0 {
+		return nil
+	}
+	return &specDebouncer{window: window, changes: make(map[string]time.Time)}
+}
+
+// touch records the spec change of key at now.
+func (d *specDebouncer) touch(key string, now time.Time) {
+	if d == nil {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.changes[key] = now
+}
+
+// remaining returns how long key should wait for the window after its last
+// spec change to pass, the change is dropped once it has passed.
+func (d *specDebouncer) remaining(key string, now time.Time) time.Duration {
+	if d == nil {
+		return 0
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	changed, ok := d.changes[key]
+	if !ok {
+		return 0
+	}
+	if wait := changed.Add(d.window).Sub(now); wait > 0 {
+		return wait
+	}
+	delete(d.changes, key)
+	return 0
+}
+
+func (d *specDebouncer) forget(key string) {
+	if d == nil {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.changes, key)
+}
+
+// isRedundantResync returns true if the update is delivered by an informer
+// resync, and the machine has been reconciled at the same resourceVersion
+// with no health check due.
+func (c *Controller) isRedundantResync(old, new *platformv1.Machine) bool {
+	if c.processed == nil || old.ResourceVersion != new.ResourceVersion {
+		return false
+	}
+	key, err := cache.MetaNamespaceKeyFunc(new)
+	if err != nil {
+		return false
+	}
+	if c.processed.get(key) != new.ResourceVersion {
+		return false
+	}
+	return !healthCheckDue(new)
+}
+
+// healthCheckDue returns true if the machine health should be probed again.
+func healthCheckDue(machine *platformv1.Machine) bool {
+	if !(machine.Status.Phase == platformv1.MachineRunning ||
+		machine.Status.Phase == platformv1.MachineFailed) {
+		return false
+	}
+	condition := machine.GetCondition(machineprovider.ConditionTypeHealthCheck)
+	return condition == nil || time.Since(condition.LastProbeTime.Time) >= resyncInternal
+}
+
+func (c *Controller) deleteMachine(obj interface{}) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		return
+	}
+	var uid types.UID
+	machine, err := asMachine(obj)
+	if err != nil {
+		warnUnexpectedObject("delete", err)
+	} else {
+		deleteConditionMetrics(machine)
+		uid = machine.UID
+	}
+	c.forgetMachine(key, uid)
+}
+
+// forgetMachine drops the state tracked for the machine, by its key and UID.
+func (c *Controller) forgetMachine(key string, uid types.UID) {
+	c.processed.forget(key)
+	c.specDebounce.forget(key)
+	c.createSteps.forget(key)
+	c.retryBudget.forget(key)
+	c.podCounter.forget(string(uid))
+	c.pressure.forget(string(uid))
+	c.recoveryStreaks.reset(string(uid))
+	c.healthErrors.reset(string(uid))
+	c.reconcileStats.forget(string(uid))
+}
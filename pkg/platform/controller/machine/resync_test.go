@@ -0,0 +1,169 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"k8s.io/client-go/util/workqueue"
+	platformv1 "tkestack.io/tke/api/platform/v1"
+	"tkestack.io/tke/pkg/util/log"
+)
+
+func TestController_skipRedundantResync(t *testing.T) {
+	machine := newMachineForTest("1", nil, platformv1.MachineInitializing,
+		[]platformv1.MachineCondition{{Status: platformv1.ConditionFalse}})
+	machine.Name = "machine"
+	c := &Controller{
+		queue:     workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+		log:       log.WithName("MachineController"),
+		processed: newProcessedVersions(),
+	}
+	defer c.queue.ShutDown()
+
+	// not reconciled yet, resync is delivered
+	c.updateMachine(machine, machine)
+	if c.queue.Len() != 1 {
+		t.Fatalf("queue length = %d, want 1 before machine is reconciled", c.queue.Len())
+	}
+	key, _ := c.queue.Get()
+	c.queue.Done(key)
+	c.queue.Forget(key)
+
+	c.processed.set("machine", machine.ResourceVersion)
+	c.updateMachine(machine, machine)
+	if c.queue.Len() != 0 {
+		t.Errorf("queue length = %d, want 0 for a resync without changes", c.queue.Len())
+	}
+
+	updated := machine.DeepCopy()
+	updated.ResourceVersion = "2"
+	updated.Spec.IP = "127.0.0.2"
+	c.updateMachine(machine, updated)
+	if c.queue.Len() != 1 {
+		t.Errorf("queue length = %d, want 1 after spec changed", c.queue.Len())
+	}
+}
+
+func TestController_updateMachineRecreated(t *testing.T) {
+	old := newMachineForTest("1", nil, platformv1.MachineRunning, nil)
+	old.Name = "machine"
+	old.UID = "old"
+	c := &Controller{
+		queue:           workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+		log:             log.WithName("MachineController"),
+		processed:       newProcessedVersions(),
+		podCounter:      newPodCounter(podCountInterval),
+		pressure:        newPressureTracker(2),
+		recoveryStreaks: newRecoveryStreaks(3),
+		healthErrors:    newErrorDeduplicator(repeatedErrorLogInterval),
+		reconcileStats:  newReconcileStats(reconcileStatsInterval),
+	}
+	defer c.queue.ShutDown()
+	c.processed.set("machine", old.ResourceVersion)
+	c.podCounter.due(string(old.UID))
+	c.pressure.observe(string(old.UID), true)
+	c.recoveryStreaks.succeed(string(old.UID))
+	c.healthErrors.allow(string(old.UID), "node is not ready")
+	c.reconcileStats.pending[string(old.UID)] = 1
+
+	// recreated with the same name and contents while the watch is down,
+	// which is only seen as an update on relist
+	recreated := old.DeepCopy()
+	recreated.UID = "new"
+	c.updateMachine(old, recreated)
+	if c.queue.Len() != 1 {
+		t.Fatalf("queue length = %d, want 1 for a recreated machine", c.queue.Len())
+	}
+	if got := c.processed.get("machine"); got != "" {
+		t.Errorf("processed version = %q, want it forgotten", got)
+	}
+	if !c.podCounter.due(string(recreated.UID)) {
+		t.Errorf("pods of recreated machine aren't due to be counted")
+	}
+	if len(c.podCounter.counted) != 1 || len(c.pressure.counts) != 0 {
+		t.Errorf("state of old machine is kept, pod counts = %v, pressure = %v", c.podCounter.counted, c.pressure.counts)
+	}
+	if c.pressure.observe(string(recreated.UID), true) {
+		t.Errorf("sustained pressure of old machine is carried over to recreated machine")
+	}
+	if len(c.recoveryStreaks.counts) != 0 || len(c.healthErrors.entries) != 0 || len(c.reconcileStats.pending) != 0 {
+		t.Errorf("state of old machine is kept, recovery streaks = %v, health errors = %v, pending reconciles = %v",
+			c.recoveryStreaks.counts, c.healthErrors.entries, c.reconcileStats.pending)
+	}
+}
+
+func TestController_debounceSpecUpdates(t *testing.T) {
+	machine := newMachineForTest("1", nil, platformv1.MachineRunning, nil)
+	machine.Name = "machine"
+	window := 100 * time.Millisecond
+	c := &Controller{
+		queue:        workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+		log:          log.WithName("MachineController"),
+		specDebounce: newSpecDebouncer(window),
+	}
+	defer c.queue.ShutDown()
+
+	var lastEdit time.Time
+	old := machine
+	for i := 2; i <= 4; i++ {
+		updated := old.DeepCopy()
+		updated.ResourceVersion = fmt.Sprint(i)
+		updated.Spec.IP = fmt.Sprintf("127.0.0.%d", i)
+		c.updateMachine(old, updated)
+		lastEdit = time.Now()
+		old = updated
+		time.Sleep(window / 2)
+	}
+
+	// process the queue as the workers do until the machine is reconciled
+	var reconciled int
+	for reconciled == 0 {
+		key, _ := c.queue.Get()
+		if key != "machine" {
+			t.Fatalf("key = %v, want machine", key)
+		}
+		if wait := c.specDebounce.remaining(key.(string), time.Now()); wait > 0 {
+			c.queue.AddAfter(key, wait)
+		} else {
+			reconciled++
+			if elapsed := time.Since(lastEdit); elapsed < window {
+				t.Errorf("machine is reconciled %s after the last edit, want after the debounce window %s", elapsed, window)
+			}
+		}
+		c.queue.Done(key)
+	}
+	time.Sleep(2 * window)
+	if c.queue.Len() != 0 {
+		t.Errorf("queue length = %d, want rapid spec changes reconciled once", c.queue.Len())
+	}
+}
+
+func TestNewSpecDebouncerDisabled(t *testing.T) {
+	d := newSpecDebouncer(0)
+	if d != nil {
+		t.Fatalf("debouncer = %v, want nil for zero window", d)
+	}
+	d.touch("machine", time.Now())
+	if wait := d.remaining("machine", time.Now()); wait != 0 {
+		t.Errorf("remaining = %s, want 0 when disabled", wait)
+	}
+}
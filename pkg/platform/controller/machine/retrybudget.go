@@ -0,0 +1,130 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"errors"
+	"net"
+	"sync"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+
+	machineconfig "tkestack.io/tke/pkg/platform/controller/machine/config"
+)
+
+// The categories of errors which machines are retried by.
+const (
+	errorCategoryProvider = "provider"
+	errorCategoryConflict = "conflict"
+	errorCategoryNetwork  = "network"
+	errorCategoryOther    = "other"
+)
+
+// providerError marks the errors returned by machine providers, so that they
+// are retried by their own budget.
+type providerError struct {
+	err error
+}
+
+func (e *providerError) Error() string {
+	return e.err.Error()
+}
+
+func (e *providerError) Unwrap() error {
+	return e.err
+}
+
+// errorCategory returns the category of the error machine is retried by.
+func errorCategory(err error) string {
+	var providerErr *providerError
+	var netErr net.Error
+	switch {
+	case errors.As(err, &providerErr):
+		return errorCategoryProvider
+	case apierrors.IsConflict(err):
+		return errorCategoryConflict
+	case errors.As(err, &netErr), utilnet.IsConnectionRefused(err), utilnet.IsConnectionReset(err),
+		utilnet.IsProbableEOF(err), apierrors.IsServerTimeout(err), apierrors.IsTimeout(err):
+		return errorCategoryNetwork
+	default:
+		return errorCategoryOther
+	}
+}
+
+// retryBudget counts the retries of machines by error category, each capped
+// by its own budget, so that a noisy category doesn't exhaust the retries of
+// others. The retries of machine are counted until it's synced successfully.
+type retryBudget struct {
+	mu      sync.Mutex
+	budgets map[string]int
+	retries map[string]map[string]int
+}
+
+// newRetryBudget returns nil if no category has a budget, which retries
+// machines without limit.
+func newRetryBudget(configuration machineconfig.MachineControllerConfiguration) *retryBudget {
+	budgets := map[string]int{}
+	for category, budget := range map[string]int{
+		errorCategoryProvider: configuration.ProviderErrorRetries,
+		errorCategoryConflict: configuration.ConflictErrorRetries,
+		errorCategoryNetwork:  configuration.NetworkErrorRetries,
+	} {
+		if budget > 0 {
+			budgets[category] = budget
+		}
+	}
+	if len(budgets) == 0 {
+		return nil
+	}
+	return &retryBudget{budgets: budgets, retries: make(map[string]map[string]int)}
+}
+
+// retry counts a retry of the machine failed with err, and returns false if
+// the budget of the error category is exhausted.
+func (b *retryBudget) retry(key string, err error) bool {
+	category := errorCategory(err)
+	machineRetries.WithLabelValues(category).Inc()
+	if b == nil {
+		return true
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	retries, ok := b.retries[key]
+	if !ok {
+		retries = make(map[string]int)
+		b.retries[key] = retries
+	}
+	retries[category]++
+	if budget, ok := b.budgets[category]; ok && retries[category] > budget {
+		machineRetriesExhausted.WithLabelValues(category).Inc()
+		return false
+	}
+	return true
+}
+
+func (b *retryBudget) forget(key string) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.retries, key)
+}
@@ -0,0 +1,122 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	machineconfig "tkestack.io/tke/pkg/platform/controller/machine/config"
+)
+
+var (
+	errProviderForTest = &providerError{err: errors.New("ssh: handshake failed")}
+	errConflictForTest = apierrors.NewConflict(schema.GroupResource{Resource: "machines"}, "machine", errors.New("modified"))
+	errNetworkForTest  = fmt.Errorf("get node: %w", &net.OpError{Op: "dial", Err: errors.New("connection refused")})
+)
+
+func TestErrorCategory(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{err: errProviderForTest, want: errorCategoryProvider},
+		{err: fmt.Errorf("create: %w", errProviderForTest), want: errorCategoryProvider},
+		{err: errConflictForTest, want: errorCategoryConflict},
+		{err: errNetworkForTest, want: errorCategoryNetwork},
+		{err: apierrors.NewServerTimeout(schema.GroupResource{Resource: "nodes"}, "get", 1), want: errorCategoryNetwork},
+		{err: errors.New("unknown"), want: errorCategoryOther},
+	}
+	for _, tt := range tests {
+		if got := errorCategory(tt.err); got != tt.want {
+			t.Errorf("errorCategory(%v) = %s, want %s", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestRetryBudget(t *testing.T) {
+	budget := newRetryBudget(machineconfig.MachineControllerConfiguration{
+		ProviderErrorRetries: 2,
+		ConflictErrorRetries: 1,
+	})
+	retries := func(category string) float64 {
+		return testutil.ToFloat64(machineRetries.WithLabelValues(category))
+	}
+	exhausted := func(category string) float64 {
+		return testutil.ToFloat64(machineRetriesExhausted.WithLabelValues(category))
+	}
+	providerRetries, providerExhausted := retries(errorCategoryProvider), exhausted(errorCategoryProvider)
+	conflictRetries := retries(errorCategoryConflict)
+
+	for i := 0; i < 2; i++ {
+		if !budget.retry("machine", errProviderForTest) {
+			t.Fatalf("provider error isn't retried on retry %d within budget 2", i+1)
+		}
+	}
+	if budget.retry("machine", errProviderForTest) {
+		t.Errorf("provider error is retried after its budget is exhausted")
+	}
+	// the exhausted provider budget doesn't mask the conflict one
+	if !budget.retry("machine", errConflictForTest) {
+		t.Errorf("conflict error isn't retried within its own budget")
+	}
+	if budget.retry("machine", errConflictForTest) {
+		t.Errorf("conflict error is retried after its budget is exhausted")
+	}
+	for i := 0; i < 10; i++ {
+		if !budget.retry("machine", errNetworkForTest) {
+			t.Fatalf("network error without budget isn't retried")
+		}
+	}
+	// other machines have budgets of their own
+	if !budget.retry("other", errProviderForTest) {
+		t.Errorf("provider error of another machine isn't retried")
+	}
+
+	if got := retries(errorCategoryProvider) - providerRetries; got != 4 {
+		t.Errorf("provider retries = %v, want 4", got)
+	}
+	if got := exhausted(errorCategoryProvider) - providerExhausted; got != 1 {
+		t.Errorf("exhausted provider budgets = %v, want 1", got)
+	}
+	if got := retries(errorCategoryConflict) - conflictRetries; got != 2 {
+		t.Errorf("conflict retries = %v, want 2", got)
+	}
+
+	budget.forget("machine")
+	if !budget.retry("machine", errProviderForTest) {
+		t.Errorf("provider error isn't retried after the machine is synced")
+	}
+}
+
+func TestNewRetryBudgetUnlimited(t *testing.T) {
+	budget := newRetryBudget(machineconfig.MachineControllerConfiguration{})
+	if budget != nil {
+		t.Fatalf("newRetryBudget() = %+v, want nil without budgets", budget)
+	}
+	if !budget.retry("machine", errProviderForTest) {
+		t.Errorf("nil budget doesn't retry")
+	}
+}
@@ -0,0 +1,81 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/cache"
+
+	platformv1 "tkestack.io/tke/api/platform/v1"
+)
+
+// stagger spreads the first health checks of existing machines over a
+// window on start, so that they don't hit the cluster APIs all at once.
+// The delays are drawn from the source, which is seeded in tests.
+type stagger struct {
+	mu     sync.Mutex
+	window time.Duration
+	rand   *rand.Rand
+}
+
+// newStagger returns nil if the window is not positive, which doesn't
+// delay at all.
+func newStagger(window time.Duration, source rand.Source) *stagger {
+	if window <= 0 {
+		return nil
+	}
+	return &stagger{window: window, rand: rand.New(source)}
+}
+
+// delay returns a random delay in [0, window).
+func (s *stagger) delay() time.Duration {
+	if s == nil {
+		return 0
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return time.Duration(s.rand.Int63n(int64(s.window)))
+}
+
+// startupDelay returns the delay to process a machine listed on start,
+// only machines whose health is checked are delayed.
+func (c *Controller) startupDelay(machine *platformv1.Machine) time.Duration {
+	if c.stagger == nil || c.listerSynced() {
+		return 0
+	}
+	if !(machine.Status.Phase == platformv1.MachineRunning ||
+		machine.Status.Phase == platformv1.MachineFailed) {
+		return 0
+	}
+	return c.stagger.delay()
+}
+
+func (c *Controller) enqueueAfter(obj *platformv1.Machine, delay time.Duration) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		runtime.HandleError(fmt.Errorf("couldn't get key for object %+v: %v", obj, err))
+		return
+	}
+	c.queueFor(obj).AddAfter(key, delay)
+}
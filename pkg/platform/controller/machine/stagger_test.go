@@ -0,0 +1,145 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+
+	"tkestack.io/tke/api/client/clientset/versioned/fake"
+	"tkestack.io/tke/api/client/informers/externalversions"
+	platformv1 "tkestack.io/tke/api/platform/v1"
+	"tkestack.io/tke/cmd/tke-platform-controller/app/options"
+	machineconfig "tkestack.io/tke/pkg/platform/controller/machine/config"
+	"tkestack.io/tke/pkg/util/log"
+)
+
+// addRecordingQueue records items added by Add with zero delay, besides
+// the delays of items added by AddAfter.
+type addRecordingQueue struct {
+	delayRecordingQueue
+}
+
+func (q *addRecordingQueue) Add(item interface{}) {
+	q.delays[item] = 0
+}
+
+func TestController_startupStagger(t *testing.T) {
+	window := 10 * time.Minute
+	synced := false
+	run := func() map[interface{}]time.Duration {
+		queue := &addRecordingQueue{delayRecordingQueue{delays: map[interface{}]time.Duration{}}}
+		c := &Controller{
+			queue:        queue,
+			log:          log.WithName("MachineController"),
+			listerSynced: func() bool { return synced },
+			stagger:      newStagger(window, rand.NewSource(1)),
+		}
+		for i := 0; i < 20; i++ {
+			machine := newMachineForTest("1", nil, platformv1.MachineRunning, nil)
+			machine.Name = fmt.Sprintf("machine-%d", i)
+			c.addMachine(machine)
+		}
+		initializing := newMachineForTest("1", nil, platformv1.MachineInitializing, nil)
+		initializing.Name = "initializing"
+		c.addMachine(initializing)
+		return queue.delays
+	}
+
+	delays := run()
+	if delays["initializing"] != 0 {
+		t.Errorf("initializing machine is delayed %s, want processed immediately", delays["initializing"])
+	}
+	delete(delays, "initializing")
+	min, max := window, time.Duration(0)
+	for key, delay := range delays {
+		if delay < 0 || delay >= window {
+			t.Errorf("%s is delayed %s, want within [0, %s)", key, delay, window)
+		}
+		if delay < min {
+			min = delay
+		}
+		if delay > max {
+			max = delay
+		}
+	}
+	if max-min < window/2 {
+		t.Errorf("delays range in [%s, %s], want spread across the window %s", min, max, window)
+	}
+	if again := run(); !reflect.DeepEqual(withoutKey(again, "initializing"), delays) {
+		t.Errorf("delays are not deterministic with the same source")
+	}
+
+	synced = true
+	for key, delay := range run() {
+		if delay != 0 {
+			t.Errorf("%s is delayed %s after caches synced, want no delay", key, delay)
+		}
+	}
+}
+
+func TestController_resumeHealthChecksStaggeredByDefault(t *testing.T) {
+	opts := options.NewMachineControllerOptions()
+	var configuration machineconfig.MachineControllerConfiguration
+	if err := opts.ApplyTo(&configuration); err != nil {
+		t.Fatal(err)
+	}
+	client := fake.NewSimpleClientset()
+	informerFactory := externalversions.NewSharedInformerFactory(client, 0)
+	machineInformer := informerFactory.Platform().V1().Machines()
+	c := NewController(client.PlatformV1(), nil, machineInformer, configuration, platformv1.MachineFinalize)
+	c.queue.ShutDown()
+	queue := &addRecordingQueue{delayRecordingQueue{delays: map[interface{}]time.Duration{}}}
+	c.queue = queue
+	machines := 20
+	for i := 0; i < machines; i++ {
+		machine := newMachineForTest("1", nil, platformv1.MachineRunning, nil)
+		machine.Name = fmt.Sprintf("machine-%d", i)
+		if err := machineInformer.Informer().GetStore().Add(machine); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	c.resumeHealthChecks()
+
+	window := configuration.StartupStaggerWindow
+	if len(queue.delays) != machines {
+		t.Fatalf("%d machines are resumed, want %d", len(queue.delays), machines)
+	}
+	min, max := window, time.Duration(0)
+	for _, delay := range queue.delays {
+		if delay < min {
+			min = delay
+		}
+		if delay > max {
+			max = delay
+		}
+	}
+	if max-min < window/2 {
+		t.Errorf("delays range in [%s, %s], want spread across the default window %s", min, max, window)
+	}
+}
+
+func withoutKey(m map[interface{}]time.Duration, key interface{}) map[interface{}]time.Duration {
+	delete(m, key)
+	return m
+}
@@ -0,0 +1,72 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"sync"
+)
+
+// reasonRecovering is set on failed machines which passed some, but not yet
+// enough, consecutive health checks.
+const reasonRecovering = "Recovering"
+
+// recoveryStreaks counts the consecutive successful health checks of failed
+// machines by their UID, so that a flapping node doesn't flip its machine
+// between Failed and Running on each check, and a machine recreated with the
+// same name starts afresh.
+type recoveryStreaks struct {
+	mu        sync.Mutex
+	threshold int
+	counts    map[string]int
+}
+
+// newRecoveryStreaks returns nil if machines recover on the first success,
+// which doesn't hold recovery at all.
+func newRecoveryStreaks(threshold int) *recoveryStreaks {
+	if threshold <= 1 {
+		return nil
+	}
+	return &recoveryStreaks{threshold: threshold, counts: make(map[string]int)}
+}
+
+// streak returns the number of consecutive successful health checks of the
+// failed machine so far.
+func (s *recoveryStreaks) streak(uid string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.counts[uid]
+}
+
+// succeed records a successful health check of the failed machine which isn't
+// recovered yet, and returns the length of streak.
+func (s *recoveryStreaks) succeed(uid string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.counts[uid]++
+	return s.counts[uid]
+}
+
+func (s *recoveryStreaks) reset(uid string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.counts, uid)
+}
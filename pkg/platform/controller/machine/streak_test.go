@@ -0,0 +1,79 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"context"
+	"testing"
+
+	platformv1 "tkestack.io/tke/api/platform/v1"
+	machineprovider "tkestack.io/tke/pkg/platform/provider/machine"
+	typesv1 "tkestack.io/tke/pkg/platform/types/v1"
+)
+
+func TestController_checkHealthRecoveryStreak(t *testing.T) {
+	healthy := true
+	provider := &fakeProvider{
+		onHealthCheck: func(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster) *platformv1.Machine {
+			condition := platformv1.MachineCondition{
+				Type:   machineprovider.ConditionTypeHealthCheck,
+				Status: platformv1.ConditionTrue,
+			}
+			machine.Status.Phase = platformv1.MachineRunning
+			if !healthy {
+				condition.Status = platformv1.ConditionFalse
+				condition.Reason = machineprovider.FailedHealthCheckReason
+				machine.Status.Phase = platformv1.MachineFailed
+			}
+			machine.SetCondition(condition)
+			return machine
+		},
+	}
+	c := &Controller{recoveryStreaks: newRecoveryStreaks(3)}
+	machine := newMachineForTest("1", nil, platformv1.MachineFailed, []platformv1.MachineCondition{{
+		Type:   machineprovider.ConditionTypeHealthCheck,
+		Status: platformv1.ConditionFalse,
+	}})
+	machine.Name = "machine"
+	check := func(want platformv1.MachinePhase) {
+		t.Helper()
+		machine = c.checkHealth(context.Background(), provider, machine, &typesv1.Cluster{}, false)
+		if machine.Status.Phase != want {
+			t.Fatalf("phase = %s, want %s", machine.Status.Phase, want)
+		}
+	}
+
+	check(platformv1.MachineFailed)
+	if condition := machine.GetCondition(machineprovider.ConditionTypeHealthCheck); condition.Reason != reasonRecovering {
+		t.Errorf("health condition reason = %s, want %s", condition.Reason, reasonRecovering)
+	}
+	check(platformv1.MachineFailed)
+
+	// a failure breaks the streak
+	healthy = false
+	check(platformv1.MachineFailed)
+	healthy = true
+	check(platformv1.MachineFailed)
+	check(platformv1.MachineFailed)
+	check(platformv1.MachineRunning)
+	if condition := machine.GetCondition(machineprovider.ConditionTypeHealthCheck); condition.Status != platformv1.ConditionTrue {
+		t.Errorf("health condition = %+v, want true after recovered", condition)
+	}
+	check(platformv1.MachineRunning)
+}
@@ -0,0 +1,68 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	platformv1 "tkestack.io/tke/api/platform/v1"
+	machineprovider "tkestack.io/tke/pkg/platform/provider/machine"
+	"tkestack.io/tke/pkg/util/log"
+)
+
+const (
+	conditionTypeTerminalFailure = "TerminalFailure"
+	reasonTerminalError          = "TerminalError"
+)
+
+// failTerminal marks the machine Failed permanently on a terminal error of
+// the provider. The provider isn't called for the machine again until the
+// condition is removed, e.g. by resetting the failed machine.
+func (c *Controller) failTerminal(ctx context.Context, machine *platformv1.Machine, terminal *machineprovider.TerminalError) error {
+	log.FromContext(ctx).Info("Machine provider failed terminally, stop retrying", "reason", terminal.Error())
+
+	message := terminal.Error()
+	if terminal.Guidance != "" {
+		message = fmt.Sprintf("%s: %s", message, terminal.Guidance)
+	}
+	oldPhase, oldHealth := machine.Status.Phase, healthStatus(machine)
+	machine.Status.Phase = platformv1.MachineFailed
+	machine.SetCondition(platformv1.MachineCondition{
+		Type:    conditionTypeTerminalFailure,
+		Status:  platformv1.ConditionTrue,
+		Reason:  reasonTerminalError,
+		Message: message,
+	})
+	machine.Status.Reason = reasonTerminalError
+	machine.Status.Message = message
+	if _, err := c.platformClient.Machines().Update(ctx, machine, metav1.UpdateOptions{}); err != nil {
+		return err
+	}
+	c.recordLifecycle(machine, oldPhase, oldHealth)
+	return nil
+}
+
+// isTerminallyFailed returns true if the machine has failed terminally.
+func isTerminallyFailed(machine *platformv1.Machine) bool {
+	condition := machine.GetCondition(conditionTypeTerminalFailure)
+	return condition != nil && condition.Status == platformv1.ConditionTrue
+}
@@ -0,0 +1,107 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	platformv1 "tkestack.io/tke/api/platform/v1"
+	machineprovider "tkestack.io/tke/pkg/platform/provider/machine"
+	typesv1 "tkestack.io/tke/pkg/platform/types/v1"
+)
+
+func TestController_onUpdateTerminalError(t *testing.T) {
+	calls := 0
+	providerName := registerFakeProvider(t, &fakeProvider{
+		onUpdate: func(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster, options machineprovider.UpdateOptions) error {
+			calls++
+			return machineprovider.Terminal(errors.New("disk is broken"), "replace the disk and reset the machine")
+		},
+	})
+	machine := newMachineForTest("1", nil, platformv1.MachineRunning, nil)
+	machine.Name = "machine"
+	machine.Spec.Type = providerName
+	c := newControllerForTest(machine)
+	c.getCluster = fakeGetCluster
+	get := func() *platformv1.Machine {
+		got, err := c.platformClient.Machines().Get(context.Background(), machine.Name, v1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return got
+	}
+
+	if err := c.onUpdate(context.Background(), machine.DeepCopy()); err != nil {
+		t.Fatalf("onUpdate() error = %v, want nil to stop retrying", err)
+	}
+	got := get()
+	if got.Status.Phase != platformv1.MachineFailed {
+		t.Errorf("phase = %s, want %s", got.Status.Phase, platformv1.MachineFailed)
+	}
+	condition := got.GetCondition(conditionTypeTerminalFailure)
+	if condition == nil || condition.Reason != reasonTerminalError ||
+		!strings.Contains(condition.Message, "replace the disk") {
+		t.Errorf("terminal failure condition = %+v, want one with the guidance", condition)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := c.onUpdate(context.Background(), get()); err != nil {
+			t.Fatalf("onUpdate() error = %v", err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("provider is called %d times, want once until the machine is reset", calls)
+	}
+	if got := get(); got.Status.Phase != platformv1.MachineFailed {
+		t.Errorf("phase = %s after resyncs, want %s", got.Status.Phase, platformv1.MachineFailed)
+	}
+}
+
+func TestController_onCreateTerminalError(t *testing.T) {
+	providerName := registerFakeProvider(t, &fakeProvider{
+		DelegateProvider: machineprovider.DelegateProvider{
+			CreateHandlers: []machineprovider.Handler{
+				func(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster) error {
+					return fmt.Errorf("install: %w", machineprovider.Terminal(errors.New("unsupported OS"), ""))
+				},
+			},
+		},
+	})
+	machine := newMachineForTest("1", nil, platformv1.MachineInitializing, []platformv1.MachineCondition{})
+	machine.Name = "machine"
+	machine.Spec.Type = providerName
+	c := newControllerForTest(machine)
+	c.getCluster = fakeGetCluster
+
+	if err := c.onCreate(context.Background(), machine.DeepCopy()); err != nil {
+		t.Fatalf("onCreate() error = %v, want nil to stop retrying", err)
+	}
+	got, err := c.platformClient.Machines().Get(context.Background(), machine.Name, v1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status.Phase != platformv1.MachineFailed || !isTerminallyFailed(got) {
+		t.Errorf("machine phase = %s, conditions = %+v, want failed terminally", got.Status.Phase, got.Status.Conditions)
+	}
+}
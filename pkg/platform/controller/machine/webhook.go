@@ -0,0 +1,117 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	platformv1 "tkestack.io/tke/api/platform/v1"
+	"tkestack.io/tke/pkg/util/log"
+)
+
+// HealthNotification is the payload posted to the health webhook when
+// a machine fails or recovers.
+type HealthNotification struct {
+	Machine   string                  `json:"machine"`
+	Cluster   string                  `json:"cluster"`
+	Phase     platformv1.MachinePhase `json:"phase"`
+	Reason    string                  `json:"reason"`
+	Message   string                  `json:"message"`
+	Timestamp metav1.Time             `json:"timestamp"`
+}
+
+// healthWebhook posts machine health transitions to an outbound webhook.
+type healthWebhook struct {
+	url     string
+	client  *http.Client
+	backoff wait.Backoff
+}
+
+func newHealthWebhook(url string, timeout time.Duration, retries int) *healthWebhook {
+	if url == "" {
+		return nil
+	}
+	return &healthWebhook{
+		url:    url,
+		client: &http.Client{Timeout: timeout},
+		backoff: wait.Backoff{
+			Duration: time.Second,
+			Factor:   2,
+			Steps:    retries + 1,
+		},
+	}
+}
+
+// notify posts the notification, retrying on failure.
+func (w *healthWebhook) notify(ctx context.Context, notification HealthNotification) error {
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+
+	var lastErr error
+	err = wait.ExponentialBackoff(w.backoff, func() (bool, error) {
+		lastErr = w.post(ctx, body)
+		if lastErr != nil {
+			log.FromContext(ctx).Error(lastErr, "Post machine health webhook failed", "url", w.url)
+			return false, nil
+		}
+		return true, nil
+	})
+	if err == wait.ErrWaitTimeout {
+		return lastErr
+	}
+	return err
+}
+
+func (w *healthWebhook) post(ctx context.Context, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func newHealthNotification(machine *platformv1.Machine) HealthNotification {
+	return HealthNotification{
+		Machine:   machine.Name,
+		Cluster:   machine.Spec.ClusterName,
+		Phase:     machine.Status.Phase,
+		Reason:    machine.Status.Reason,
+		Message:   machine.Status.Message,
+		Timestamp: metav1.Now(),
+	}
+}
@@ -0,0 +1,70 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	platformv1 "tkestack.io/tke/api/platform/v1"
+)
+
+func TestController_healthWebhook(t *testing.T) {
+	notifications := make(chan HealthNotification, 1)
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		// fail the first attempt to exercise retry
+		if attempts == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		var notification HealthNotification
+		if err := json.NewDecoder(r.Body).Decode(&notification); err != nil {
+			t.Errorf("decode payload error = %v", err)
+		}
+		notifications <- notification
+	}))
+	defer server.Close()
+
+	webhook := newHealthWebhook(server.URL, time.Second, 3)
+	webhook.backoff.Duration = time.Millisecond
+	c := &Controller{webhook: webhook}
+
+	machine := newMachineForTest("1", nil, platformv1.MachineFailed, nil)
+	machine.Name = "machine"
+	machine.Status.Reason = "FailedHealthCheck"
+	machine.Status.Message = "node not found"
+	c.recordHealthTransition(context.Background(), machine, platformv1.MachineRunning, nil)
+
+	select {
+	case got := <-notifications:
+		if got.Machine != machine.Name || got.Cluster != machine.Spec.ClusterName ||
+			got.Phase != platformv1.MachineFailed || got.Reason != machine.Status.Reason ||
+			got.Message != machine.Status.Message || got.Timestamp.IsZero() {
+			t.Errorf("unexpected payload %+v", got)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("webhook is not notified on failure")
+	}
+}
@@ -0,0 +1,78 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	platformversionedclient "tkestack.io/tke/api/client/clientset/versioned/typed/platform/v1"
+	platformv1 "tkestack.io/tke/api/platform/v1"
+)
+
+// writeLimitedClient bounds the number of concurrent machine writes to the
+// platform API across all workers, to protect the apiserver besides the
+// rate limiter.
+type writeLimitedClient struct {
+	platformversionedclient.PlatformV1Interface
+	writes semaphore
+}
+
+// newWriteLimitedClient returns the client itself if writes is nil.
+func newWriteLimitedClient(client platformversionedclient.PlatformV1Interface, writes semaphore) platformversionedclient.PlatformV1Interface {
+	if writes == nil {
+		return client
+	}
+	return &writeLimitedClient{PlatformV1Interface: client, writes: writes}
+}
+
+func (c *writeLimitedClient) Machines() platformversionedclient.MachineInterface {
+	return &writeLimitedMachines{MachineInterface: c.PlatformV1Interface.Machines(), writes: c.writes}
+}
+
+type writeLimitedMachines struct {
+	platformversionedclient.MachineInterface
+	writes semaphore
+}
+
+func (m *writeLimitedMachines) Update(ctx context.Context, machine *platformv1.Machine, opts metav1.UpdateOptions) (*platformv1.Machine, error) {
+	if err := m.writes.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer m.writes.release()
+	return m.MachineInterface.Update(ctx, machine, opts)
+}
+
+func (m *writeLimitedMachines) UpdateStatus(ctx context.Context, machine *platformv1.Machine, opts metav1.UpdateOptions) (*platformv1.Machine, error) {
+	if err := m.writes.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer m.writes.release()
+	return m.MachineInterface.UpdateStatus(ctx, machine, opts)
+}
+
+func (m *writeLimitedMachines) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (*platformv1.Machine, error) {
+	if err := m.writes.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer m.writes.release()
+	return m.MachineInterface.Patch(ctx, name, pt, data, opts, subresources...)
+}
@@ -0,0 +1,113 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"tkestack.io/tke/api/client/clientset/versioned/fake"
+	platformversionedclient "tkestack.io/tke/api/client/clientset/versioned/typed/platform/v1"
+	platformv1 "tkestack.io/tke/api/platform/v1"
+)
+
+// concurrentWriteCounter records the max number of concurrent machine writes,
+// it counts before calling the fake clientset which serializes the calls.
+type concurrentWriteCounter struct {
+	platformversionedclient.PlatformV1Interface
+	running int32
+	max     int32
+}
+
+func (c *concurrentWriteCounter) Machines() platformversionedclient.MachineInterface {
+	return &countingMachines{MachineInterface: c.PlatformV1Interface.Machines(), counter: c}
+}
+
+func (c *concurrentWriteCounter) write() {
+	running := atomic.AddInt32(&c.running, 1)
+	defer atomic.AddInt32(&c.running, -1)
+	for {
+		max := atomic.LoadInt32(&c.max)
+		if running <= max || atomic.CompareAndSwapInt32(&c.max, max, running) {
+			break
+		}
+	}
+	time.Sleep(10 * time.Millisecond)
+}
+
+type countingMachines struct {
+	platformversionedclient.MachineInterface
+	counter *concurrentWriteCounter
+}
+
+func (m *countingMachines) Update(ctx context.Context, machine *platformv1.Machine, opts v1.UpdateOptions) (*platformv1.Machine, error) {
+	m.counter.write()
+	return m.MachineInterface.Update(ctx, machine, opts)
+}
+
+func (m *countingMachines) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (*platformv1.Machine, error) {
+	m.counter.write()
+	return m.MachineInterface.Patch(ctx, name, pt, data, opts, subresources...)
+}
+
+func TestWriteLimitedClient(t *testing.T) {
+	var machines []*platformv1.Machine
+	objects := fake.NewSimpleClientset()
+	for i := 0; i < 20; i++ {
+		machine := newMachineForTest("1", nil, platformv1.MachineRunning, nil)
+		machine.Name = fmt.Sprintf("machine-%d", i)
+		if _, err := objects.PlatformV1().Machines().Create(context.Background(), machine, v1.CreateOptions{}); err != nil {
+			t.Fatal(err)
+		}
+		machines = append(machines, machine)
+	}
+	counter := &concurrentWriteCounter{PlatformV1Interface: objects.PlatformV1()}
+	client := newWriteLimitedClient(counter, newSemaphore(3))
+
+	var wg sync.WaitGroup
+	for i, machine := range machines {
+		wg.Add(1)
+		go func(i int, machine *platformv1.Machine) {
+			defer wg.Done()
+			var err error
+			if i%2 == 0 {
+				_, err = client.Machines().Update(context.Background(), machine, v1.UpdateOptions{})
+			} else {
+				_, err = client.Machines().Patch(context.Background(), machine.Name, types.MergePatchType, []byte(`{"metadata":{"labels":{"a":"b"}}}`), v1.PatchOptions{})
+			}
+			if err != nil {
+				t.Errorf("write %s error = %v", machine.Name, err)
+			}
+		}(i, machine)
+	}
+	wg.Wait()
+
+	if max := atomic.LoadInt32(&counter.max); max > 3 || max == 0 {
+		t.Errorf("max concurrent writes = %d, want bounded by 3", max)
+	}
+	if client := newWriteLimitedClient(counter, newSemaphore(0)); client != counter {
+		t.Errorf("newWriteLimitedClient() should not wrap the client without limit")
+	}
+}
@@ -28,6 +28,7 @@ import (
 	"k8s.io/client-go/tools/clientcmd"
 	platformv1client "tkestack.io/tke/api/client/clientset/versioned/typed/platform/v1"
 	"tkestack.io/tke/api/platform"
+	"tkestack.io/tke/pkg/app/version"
 	"tkestack.io/tke/pkg/platform/provider/baremetal/config"
 	"tkestack.io/tke/pkg/platform/provider/baremetal/constants"
 	"tkestack.io/tke/pkg/platform/provider/baremetal/validation"
@@ -45,7 +46,9 @@ func RegisterProvider() {
 		log.Errorf("init machine provider error: %s", err)
 		return
 	}
-	machineprovider.Register(p.Name(), p)
+	machineprovider.RegisterWithMetadata(p.Name(), p, machineprovider.ProviderMetadata{
+		Version: version.Get().GitVersion,
+	})
 }
 
 type Provider struct {
@@ -105,6 +108,10 @@ func NewProvider() (*Provider, error) {
 			p.EnsureUpgrade,
 			p.EnsurePostUpgradeHook,
 		},
+		KubeletRestartHandlers: []machineprovider.Handler{
+			p.EnsureMarkNode,
+			p.EnsureKubeletRestart,
+		},
 		DeleteHandlers: []machineprovider.Handler{
 			p.EnsureRemoveNode,
 		},
@@ -99,3 +99,14 @@ func (p *Provider) EnsurePostUpgradeHook(ctx context.Context, machine *platformv
 	}
 	return util.ExcuteCustomizedHook(ctx, cluster, platformv1.HookPostUpgrade, mc)
 }
+
+// EnsureKubeletRestart restarts kubelet to pick up the changed configuration,
+// the readiness of node afterwards is verified by the machine controller.
+func (p *Provider) EnsureKubeletRestart(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster) error {
+	machineSSH, err := machine.Spec.SSH()
+	if err != nil {
+		return err
+	}
+	_, err = machineSSH.CombinedOutput("systemctl restart kubelet")
+	return err
+}
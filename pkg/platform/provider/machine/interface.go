@@ -24,14 +24,20 @@ import (
 	"fmt"
 	"reflect"
 	"runtime"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"tkestack.io/tke/pkg/util/apiclient"
 	"tkestack.io/tke/pkg/util/log"
 
 	"github.com/thoas/go-funk"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/kubernetes"
 	"tkestack.io/tke/api/platform"
 
 	platformv1 "tkestack.io/tke/api/platform/v1"
@@ -49,8 +55,290 @@ const (
 
 	ConditionTypeHealthCheck = "HealthCheck"
 	FailedHealthCheckReason  = "FailedHealthCheck"
+	ReasonNodeNotRegistered  = "NodeNotRegistered"
+	// ReasonNodeGone is the reason of health check condition when the node
+	// of a best-effort machine is gone.
+	ReasonNodeGone = "NodeGone"
+	// ReasonNodeNetworkNotReady is the reason of health check condition when
+	// the network of node just joined isn't configured yet.
+	ReasonNodeNetworkNotReady = "NodeNetworkNotReady"
+
+	// ConditionTypeInsufficientPermissions is true if the health check is
+	// forbidden to get the node of machine.
+	ConditionTypeInsufficientPermissions = "InsufficientPermissions"
+
+	ConditionTypeRecreate  = "Recreate"
+	ReasonRecreateRequired = "RecreateRequired"
+
+	ConditionTypeJoinTokenValid = "JoinTokenValid"
+	ReasonJoinTokenExpired      = "JoinTokenExpired"
+
+	// ConditionTypeConnectivity is false if a connectivity check of machine
+	// fails, with the prerequisites which aren't met, e.g. blocked ports.
+	ConditionTypeConnectivity = "Connectivity"
+	ReasonPortsBlocked        = "PortsBlocked"
 )
 
+type contextKey int
+
+const (
+	keyConditionReporter contextKey = iota
+	keyStepReporter
+	keyNodeReporter
+)
+
+// HealthCheckOptions configures the default health check of machine node.
+type HealthCheckOptions struct {
+	// JoinGracePeriod is the period after the machine joined the cluster
+	// during which a missing node isn't considered a failure.
+	JoinGracePeriod time.Duration
+	// HeartbeatThreshold is the max age of node heartbeat, zero means the
+	// heartbeat isn't checked.
+	HeartbeatThreshold time.Duration
+	// HeartbeatAnnotation is the node annotation written by a heartbeat agent
+	// with a RFC3339 timestamp, which is checked against HeartbeatThreshold
+	// besides the Ready condition. Empty means there is no heartbeat agent.
+	HeartbeatAnnotation string
+	// NodeNetworkCheck is the check of node network readiness, the zero
+	// value checks nothing.
+	NodeNetworkCheck NodeNetworkCheck
+	// NodeGetTimeout is the timeout of getting the node of machine, so that
+	// a hung connection to the cluster API doesn't block the health check.
+	// Zero means no specific timeout.
+	NodeGetTimeout time.Duration
+}
+
+// UpdateOptions are the hints of controller to OnUpdate.
+type UpdateOptions struct {
+	// ForceResync requests a complete reconfiguration rather than the
+	// incremental one.
+	ForceResync bool
+	// KubeletRestart hints that spec labels mapping to the kubelet
+	// configuration, as declared by ProviderMetadata.KubeletConfigLabels,
+	// changed, so the kubelet should be reconfigured and restarted.
+	KubeletRestart bool
+}
+
+// NodeNetworkCheck is the check of node network readiness by health check,
+// since a node could be Ready before the CNI is fully configured.
+type NodeNetworkCheck struct {
+	// PodCIDR requires the node to have PodCIDR assigned.
+	PodCIDR bool
+	// NetworkAvailable requires the NetworkUnavailable condition of node, if
+	// any, to be False.
+	NetworkAvailable bool
+}
+
+// ErrNodeNetworkNotReady is returned by the health check when the network of
+// node isn't ready by the NodeNetworkCheck.
+var ErrNodeNetworkNotReady = errors.New("node network is not ready")
+
+// forbiddenWarning warns once that the health check lacks the permission to get nodes.
+var forbiddenWarning sync.Once
+
+type conditionReporter struct {
+	mu         sync.Mutex
+	conditions []platformv1.MachineCondition
+}
+
+// WithConditionReporter returns a context to which the provider could report
+// conditions by ReportCondition, and a function returning the reported ones.
+func WithConditionReporter(ctx context.Context) (context.Context, func() []platformv1.MachineCondition) {
+	reporter := &conditionReporter{}
+	return context.WithValue(ctx, keyConditionReporter, reporter), func() []platformv1.MachineCondition {
+		reporter.mu.Lock()
+		defer reporter.mu.Unlock()
+		return append([]platformv1.MachineCondition(nil), reporter.conditions...)
+	}
+}
+
+// ReportCondition reports a provider specific condition, e.g. GPUDriverInstalled,
+// which is merged into the machine status by the controller. It does nothing
+// if the context has no condition reporter.
+func ReportCondition(ctx context.Context, condition platformv1.MachineCondition) {
+	reporter, ok := ctx.Value(keyConditionReporter).(*conditionReporter)
+	if !ok {
+		return
+	}
+	reporter.mu.Lock()
+	defer reporter.mu.Unlock()
+	reporter.conditions = append(reporter.conditions, condition)
+}
+
+// RequiredPort is a port which must be open for the machine to be
+// provisioned.
+type RequiredPort struct {
+	Port int
+	// Protocol is TCP if empty.
+	Protocol corev1.Protocol
+	// Purpose is what the port is used by, e.g. kubelet API.
+	Purpose string
+	// From is where the port must be reachable from, e.g. control plane.
+	From string
+}
+
+func (p RequiredPort) String() string {
+	protocol := p.Protocol
+	if protocol == "" {
+		protocol = corev1.ProtocolTCP
+	}
+	s := fmt.Sprintf("%d/%s", p.Port, protocol)
+	if p.Purpose != "" {
+		s += fmt.Sprintf(" (%s)", p.Purpose)
+	}
+	if p.From != "" {
+		s += " from " + p.From
+	}
+	return s
+}
+
+// ReportBlockedPorts reports the Connectivity condition as false with the
+// required ports which are found blocked by a connectivity check, so that
+// users know the firewall rules to open. It's called by OnCreate along with
+// returning err, and the controller removes the condition once a create
+// step succeeds.
+func ReportBlockedPorts(ctx context.Context, err error, ports ...RequiredPort) {
+	blocked := make([]string, 0, len(ports))
+	for _, port := range ports {
+		blocked = append(blocked, port.String())
+	}
+	message := fmt.Sprintf("ports must be open: %s", strings.Join(blocked, ", "))
+	if err != nil {
+		message = fmt.Sprintf("%s: %v", message, err)
+	}
+	ReportCondition(ctx, platformv1.MachineCondition{
+		Type:    ConditionTypeConnectivity,
+		Status:  platformv1.ConditionFalse,
+		Reason:  ReasonPortsBlocked,
+		Message: message,
+	})
+}
+
+type nodeReporter struct {
+	mu   sync.Mutex
+	node *corev1.Node
+}
+
+// WithNodeReporter returns a context to which the health check could report
+// the node of machine it got by ReportNode, and a function returning the
+// reported one, so that the node isn't got again after the health check.
+func WithNodeReporter(ctx context.Context) (context.Context, func() *corev1.Node) {
+	reporter := &nodeReporter{}
+	return context.WithValue(ctx, keyNodeReporter, reporter), func() *corev1.Node {
+		reporter.mu.Lock()
+		defer reporter.mu.Unlock()
+		return reporter.node
+	}
+}
+
+// ReportNode reports the node of machine got by the health check. It does
+// nothing if the context has no node reporter.
+func ReportNode(ctx context.Context, node *corev1.Node) {
+	reporter, ok := ctx.Value(keyNodeReporter).(*nodeReporter)
+	if !ok {
+		return
+	}
+	reporter.mu.Lock()
+	defer reporter.mu.Unlock()
+	reporter.node = node
+}
+
+// StepState is the state of a create step of machine.
+type StepState string
+
+const (
+	StepStarted   StepState = "Started"
+	StepCompleted StepState = "Completed"
+	StepFailed    StepState = "Failed"
+)
+
+// StepReporter receives the progress of create steps of machine, it must
+// not block.
+type StepReporter func(step string, state StepState, err error)
+
+// WithStepReporter returns a context to which the provider reports the
+// progress of create steps by ReportStep.
+func WithStepReporter(ctx context.Context, reporter StepReporter) context.Context {
+	return context.WithValue(ctx, keyStepReporter, reporter)
+}
+
+// ReportStep reports the progress of a create step, err is the failure of
+// a failed step. It does nothing if the context has no step reporter.
+func ReportStep(ctx context.Context, step string, state StepState, err error) {
+	reporter, ok := ctx.Value(keyStepReporter).(StepReporter)
+	if !ok || reporter == nil {
+		return
+	}
+	reporter(step, state, err)
+}
+
+// ErrRecreateRequired could be returned (or wrapped) by OnUpdate when the
+// change can't be applied in-place and the machine must be initialized again.
+var ErrRecreateRequired = errors.New("machine requires recreation")
+
+// ErrJoinTokenExpired could be returned (or wrapped) by OnCreate when the
+// token to join the node to the cluster has expired, so that retrying is
+// pointless until the token is renewed.
+var ErrJoinTokenExpired = errors.New("join token has expired")
+
+// TerminalError marks a failure of provider which retrying can't resolve,
+// e.g. the machine hardware is broken. The controller fails the machine
+// permanently with the guidance for operators rather than retrying. It could
+// be returned (or wrapped) by OnCreate and OnUpdate.
+type TerminalError struct {
+	Err error
+	// Guidance tells operators how to resolve the failure.
+	Guidance string
+}
+
+// Terminal returns an error which marks err as terminal.
+func Terminal(err error, guidance string) error {
+	if err == nil {
+		return nil
+	}
+	return &TerminalError{Err: err, Guidance: guidance}
+}
+
+// IsTerminal returns the terminal error in the chain of err if any.
+func IsTerminal(err error) (*TerminalError, bool) {
+	var terminal *TerminalError
+	if errors.As(err, &terminal) {
+		return terminal, true
+	}
+	return nil, false
+}
+
+func (e *TerminalError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *TerminalError) Unwrap() error {
+	return e.Err
+}
+
+// LogError attaches the output of a failed operation, e.g. the install log,
+// to the error. It could be returned (or wrapped) by OnCreate handlers.
+type LogError struct {
+	Err error
+	Log string
+}
+
+// WithLog returns an error which carries the log of the failed operation.
+func WithLog(err error, log string) error {
+	if err == nil {
+		return nil
+	}
+	return &LogError{Err: err, Log: log}
+}
+
+func (e *LogError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *LogError) Unwrap() error {
+	return e.Err
+}
+
 type APIProvider interface {
 	Validate(machine *platform.Machine) field.ErrorList
 	ValidateUpdate(machine *platform.Machine, oldMachine *platform.Machine) field.ErrorList
@@ -65,11 +353,41 @@ type ControllerProvider interface {
 	AfterCreate(machine *platform.Machine) error
 
 	OnCreate(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster) error
-	OnUpdate(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster) error
+	OnUpdate(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster, options UpdateOptions) error
 	OnDelete(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster) error
 	// OnHealthCheck could be implemented by user, and default implementation is checking
 	// tenant cluster node status by machine IP
-	OnHealthCheck(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster) *platformv1.Machine
+	OnHealthCheck(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster, options HealthCheckOptions) *platformv1.Machine
+}
+
+// Rebooter could be implemented by providers which are able to reboot
+// machines, it's used by the controller to reboot machines on request.
+type Rebooter interface {
+	OnReboot(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster) error
+}
+
+// HealthChecker could be implemented by providers which know the health of
+// machines better than the node status, e.g. by BMC status of baremetal.
+// The controller prefers it to OnHealthCheck when implemented, the returned
+// condition is of type ConditionTypeHealthCheck.
+type HealthChecker interface {
+	CheckHealth(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster) (platformv1.MachineCondition, error)
+}
+
+// AsyncUpdater could be implemented by providers whose update converges
+// asynchronously, e.g. waiting on an operation of the cloud. The controller
+// prefers it to OnUpdate when implemented. Done is false if another pass is
+// needed, the machine is then requeued with backoff until it's done.
+type AsyncUpdater interface {
+	OnAsyncUpdate(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster, options UpdateOptions) (done bool, err error)
+}
+
+// PreChecker could be implemented by providers which are able to check the
+// machine before provisioning, e.g. connectivity, disk space and OS version.
+// The controller calls PreCheck before the first create step, and fails the
+// machine with the returned error rather than provisioning it.
+type PreChecker interface {
+	PreCheck(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster) error
 }
 
 // Provider defines a set of response interfaces for specific machine
@@ -105,6 +423,9 @@ type DelegateProvider struct {
 	CreateHandlers []Handler
 	DeleteHandlers []Handler
 	UpdateHandlers []Handler
+	// KubeletRestartHandlers are run by OnUpdate, instead of UpdateHandlers,
+	// when a restart of kubelet is hinted outside of upgrading.
+	KubeletRestartHandlers []Handler
 }
 
 func (p *DelegateProvider) Name() string {
@@ -160,6 +481,7 @@ func (p *DelegateProvider) OnCreate(ctx context.Context, machine *platformv1.Mac
 			Reason:  ReasonSkip,
 			Message: "Skip current condition",
 		})
+		ReportStep(ctx, condition.Type, StepCompleted, nil)
 	} else {
 		handler := p.getCreateHandler(condition.Type)
 		if handler == nil {
@@ -167,10 +489,12 @@ func (p *DelegateProvider) OnCreate(ctx context.Context, machine *platformv1.Mac
 		}
 		ctx := log.FromContext(ctx).WithName("MachineProvider.OnCreate").WithName(handler.Name()).WithContext(ctx)
 		log.FromContext(ctx).Info("Doing")
+		ReportStep(ctx, condition.Type, StepStarted, nil)
 		startTime := time.Now()
 		err = handler(ctx, machine, cluster)
 		log.FromContext(ctx).Info("Done", "error", err, "cost", time.Since(startTime).String())
 		if err != nil {
+			ReportStep(ctx, condition.Type, StepFailed, err)
 			machine.SetCondition(platformv1.MachineCondition{
 				Type:    condition.Type,
 				Status:  platformv1.ConditionFalse,
@@ -179,6 +503,7 @@ func (p *DelegateProvider) OnCreate(ctx context.Context, machine *platformv1.Mac
 			})
 			return err
 		}
+		ReportStep(ctx, condition.Type, StepCompleted, nil)
 
 		machine.SetCondition(platformv1.MachineCondition{
 			Type:   condition.Type,
@@ -201,11 +526,15 @@ func (p *DelegateProvider) OnCreate(ctx context.Context, machine *platformv1.Mac
 	return nil
 }
 
-func (p *DelegateProvider) OnUpdate(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster) error {
-	if machine.Status.Phase != platformv1.MachineUpgrading {
-		return nil
+func (p *DelegateProvider) OnUpdate(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster, options UpdateOptions) error {
+	handlers := p.UpdateHandlers
+	if machine.Status.Phase != platformv1.MachineUpgrading && !options.ForceResync {
+		if !options.KubeletRestart {
+			return nil
+		}
+		handlers = p.KubeletRestartHandlers
 	}
-	for _, handler := range p.UpdateHandlers {
+	for _, handler := range handlers {
 		ctx := log.FromContext(ctx).WithName("MachineProvider.OnUpdate").WithName(handler.Name()).WithContext(ctx)
 		log.FromContext(ctx).Info("Doing")
 		startTime := time.Now()
@@ -242,42 +571,218 @@ func (p *DelegateProvider) OnDelete(ctx context.Context, machine *platformv1.Mac
 	return nil
 }
 
-func (p *DelegateProvider) OnHealthCheck(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster) *platformv1.Machine {
+func (p *DelegateProvider) OnHealthCheck(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster, options HealthCheckOptions) *platformv1.Machine {
 	if !(machine.Status.Phase == platformv1.MachineRunning ||
 		machine.Status.Phase == platformv1.MachineFailed) {
 		return machine
 	}
 
+	clientset, err := cluster.Clientset()
+	if err == nil {
+		err = checkMachineNode(ctx, clientset, machine, options)
+	}
+	p.setHealthCondition(ctx, machine, err, options.JoinGracePeriod)
+
+	return machine
+}
+
+// setHealthCondition updates the health status of machine by the result of
+// health check. A node not found right after join is probably registering,
+// so the health is unknown rather than failed during the join grace period,
+// and so is the node not found of best-effort machines.
+func (p *DelegateProvider) setHealthCondition(ctx context.Context, machine *platformv1.Machine, err error, joinGracePeriod time.Duration) {
 	healthCheckCondition := platformv1.MachineCondition{
 		Type:   ConditionTypeHealthCheck,
 		Status: platformv1.ConditionFalse,
 	}
+	permissionCondition := platformv1.MachineCondition{
+		Type:   ConditionTypeInsufficientPermissions,
+		Status: platformv1.ConditionFalse,
+	}
 
-	clientset, err := cluster.Clientset()
-	if err != nil {
+	switch {
+	case apierrors.IsForbidden(err):
+		forbiddenWarning.Do(func() {
+			log.Warn("Machine health check is forbidden to get nodes, grant the permission to check machine health", log.Err(err))
+		})
+		healthCheckCondition.Status = platformv1.ConditionUnknown
+		healthCheckCondition.Reason = ConditionTypeInsufficientPermissions
+		healthCheckCondition.Message = err.Error()
+		permissionCondition.Status = platformv1.ConditionTrue
+		permissionCondition.Message = err.Error()
+	case apierrors.IsNotFound(err) && p.inJoinGracePeriod(machine, joinGracePeriod):
+		healthCheckCondition.Status = platformv1.ConditionUnknown
+		healthCheckCondition.Reason = ReasonNodeNotRegistered
+		healthCheckCondition.Message = err.Error()
+	case apierrors.IsNotFound(err) && isBestEffort(machine):
+		// best-effort machines are expected to vanish, which isn't a failure
+		healthCheckCondition.Status = platformv1.ConditionUnknown
+		healthCheckCondition.Reason = ReasonNodeGone
+		healthCheckCondition.Message = fmt.Sprintf("node of best-effort machine is gone: %v", err)
+	case errors.Is(err, ErrNodeNetworkNotReady) && p.inJoinGracePeriod(machine, joinGracePeriod):
+		healthCheckCondition.Status = platformv1.ConditionUnknown
+		healthCheckCondition.Reason = ReasonNodeNetworkNotReady
+		healthCheckCondition.Message = err.Error()
+	case err != nil:
 		machine.Status.Phase = platformv1.MachineFailed
 
 		healthCheckCondition.Reason = FailedHealthCheckReason
 		healthCheckCondition.Message = err.Error()
-	} else {
-		_, err = apiclient.GetNodeByMachineIP(ctx, clientset, machine.Spec.IP)
-		if err != nil {
-			machine.Status.Phase = platformv1.MachineFailed
-
-			healthCheckCondition.Reason = FailedHealthCheckReason
-			healthCheckCondition.Message = err.Error()
-		} else {
-			machine.Status.Phase = platformv1.MachineRunning
+	default:
+		machine.Status.Phase = platformv1.MachineRunning
 
-			healthCheckCondition.Status = platformv1.ConditionTrue
-		}
+		healthCheckCondition.Status = platformv1.ConditionTrue
 	}
 
+	// SetCondition keeps the previous transition time, so reset it when status flips.
+	if old := machine.GetCondition(ConditionTypeHealthCheck); old != nil && old.Status != healthCheckCondition.Status {
+		healthCheckCondition.LastTransitionTime = metav1.Now()
+	}
+	if old := machine.GetCondition(ConditionTypeInsufficientPermissions); old != nil && old.Status != permissionCondition.Status {
+		permissionCondition.LastTransitionTime = metav1.Now()
+	}
+	// The health check condition is set last, which determines the reason and message of machine.
+	if permissionCondition.Status == platformv1.ConditionTrue || machine.GetCondition(ConditionTypeInsufficientPermissions) != nil {
+		machine.SetCondition(permissionCondition)
+	}
 	machine.SetCondition(healthCheckCondition)
 
 	log.FromContext(ctx).Info("Update machine health status", "phase", machine.Status.Phase)
+}
 
-	return machine
+// isBestEffort returns true if the machine is marked best-effort by
+// annotation, i.e. its node is expected to vanish.
+func isBestEffort(machine *platformv1.Machine) bool {
+	bestEffort, _ := strconv.ParseBool(machine.Annotations[platformv1.MachineBestEffortAnno])
+	return bestEffort
+}
+
+// inJoinGracePeriod returns true if the running machine completed its last
+// create step within the join grace period.
+func (p *DelegateProvider) inJoinGracePeriod(machine *platformv1.Machine, period time.Duration) bool {
+	if period <= 0 || machine.Status.Phase != platformv1.MachineRunning || len(p.CreateHandlers) == 0 {
+		return false
+	}
+	last := machine.GetCondition(p.CreateHandlers[len(p.CreateHandlers)-1].Name())
+	if last == nil || last.Status != platformv1.ConditionTrue {
+		return false
+	}
+	return time.Since(last.LastProbeTime.Time) < period
+}
+
+// checkMachineNode returns an error if the node of the machine isn't healthy
+// by options, a cordoned node is healthy as long as it's Ready.
+func checkMachineNode(ctx context.Context, client kubernetes.Interface, machine *platformv1.Machine, options HealthCheckOptions) error {
+	node, err := getMachineNode(ctx, client, machine, options.NodeGetTimeout)
+	if err != nil {
+		return err
+	}
+	ReportNode(ctx, node)
+	threshold := options.HeartbeatThreshold
+	for _, condition := range node.Status.Conditions {
+		if condition.Type != corev1.NodeReady {
+			continue
+		}
+		if condition.Status != corev1.ConditionTrue {
+			return fmt.Errorf("node %s is not ready: %s", node.Name, condition.Message)
+		}
+		if threshold > 0 && !condition.LastHeartbeatTime.IsZero() {
+			if age := time.Since(condition.LastHeartbeatTime.Time); age > threshold {
+				return fmt.Errorf("node %s has not posted status for %s", node.Name, age.Round(time.Second))
+			}
+		}
+	}
+	if key := options.HeartbeatAnnotation; key != "" && threshold > 0 {
+		if err := checkHeartbeatAnnotation(node, key, threshold); err != nil {
+			return err
+		}
+	}
+	return checkNodeNetwork(node, options.NodeNetworkCheck)
+}
+
+// checkNodeNetwork returns ErrNodeNetworkNotReady if the network of node
+// isn't ready by check.
+func checkNodeNetwork(node *corev1.Node, check NodeNetworkCheck) error {
+	if check.PodCIDR && node.Spec.PodCIDR == "" && len(node.Spec.PodCIDRs) == 0 {
+		return fmt.Errorf("%w: node %s has no PodCIDR assigned", ErrNodeNetworkNotReady, node.Name)
+	}
+	if !check.NetworkAvailable {
+		return nil
+	}
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeNetworkUnavailable && condition.Status != corev1.ConditionFalse {
+			return fmt.Errorf("%w: node %s network is unavailable: %s", ErrNodeNetworkNotReady, node.Name, condition.Message)
+		}
+	}
+	return nil
+}
+
+// checkHeartbeatAnnotation returns an error if the heartbeat annotation of
+// node is missing or older than threshold.
+func checkHeartbeatAnnotation(node *corev1.Node, key string, threshold time.Duration) error {
+	value, ok := node.Annotations[key]
+	if !ok {
+		return fmt.Errorf("node %s has no heartbeat annotation %s", node.Name, key)
+	}
+	heartbeat, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return fmt.Errorf("node %s has invalid heartbeat annotation %s: %v", node.Name, key, err)
+	}
+	if age := time.Since(heartbeat); age > threshold {
+		return fmt.Errorf("node %s has no heartbeat by annotation %s for %s", node.Name, key, age.Round(time.Second))
+	}
+	return nil
+}
+
+// getMachineNode returns the node of the machine. The node name resolved by
+// machine IP is recorded in machine status, so that subsequent probes skip
+// the resolution. It is dropped once the node disappears.
+func getMachineNode(ctx context.Context, client kubernetes.Interface, machine *platformv1.Machine, timeout time.Duration) (*corev1.Node, error) {
+	if name := machine.Status.NodeName; name != "" {
+		node, err := getNodeWithTimeout(ctx, timeout, func(ctx context.Context) (*corev1.Node, error) {
+			return client.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{})
+		})
+		if !apierrors.IsNotFound(err) {
+			return node, err
+		}
+		machine.Status.NodeName = ""
+	}
+
+	node, err := getNodeWithTimeout(ctx, timeout, func(ctx context.Context) (*corev1.Node, error) {
+		return apiclient.GetNodeByMachineIP(ctx, client, machine.Spec.IP)
+	})
+	if err != nil {
+		return nil, err
+	}
+	machine.Status.NodeName = node.Name
+
+	return node, nil
+}
+
+// getNodeWithTimeout gets the node within timeout if any. It returns once the
+// timeout expires even if get doesn't honor the context.
+func getNodeWithTimeout(ctx context.Context, timeout time.Duration, get func(ctx context.Context) (*corev1.Node, error)) (*corev1.Node, error) {
+	if timeout <= 0 {
+		return get(ctx)
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	type result struct {
+		node *corev1.Node
+		err  error
+	}
+	results := make(chan result, 1)
+	go func() {
+		node, err := get(ctx)
+		results <- result{node: node, err: err}
+	}()
+	select {
+	case r := <-results:
+		return r.node, r.err
+	case <-ctx.Done():
+		return nil, fmt.Errorf("get node timed out after %s: %w", timeout, ctx.Err())
+	}
 }
 
 func (p *DelegateProvider) NeedUpdate(old, new *platformv1.Machine) bool {
@@ -330,11 +835,25 @@ func (p *DelegateProvider) getCreateCurrentCondition(c *platformv1.Machine) (*pl
 		}, nil
 	}
 
+	started := false
 	for _, condition := range c.Status.Conditions {
+		if p.getCreateHandler(condition.Type) == nil {
+			continue
+		}
+		started = true
 		if condition.Status == platformv1.ConditionFalse || condition.Status == platformv1.ConditionUnknown {
 			return &condition, nil
 		}
 	}
+	// only conditions not owned by create handlers, e.g. machine is recreating
+	if !started {
+		return &platformv1.MachineCondition{
+			Type:    p.CreateHandlers[0].Name(),
+			Status:  platformv1.ConditionUnknown,
+			Message: "waiting process",
+			Reason:  ReasonWaiting,
+		}, nil
+	}
 
 	return nil, errors.New("no condition need process")
 }
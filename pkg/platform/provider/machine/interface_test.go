@@ -0,0 +1,371 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2021 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	platformv1 "tkestack.io/tke/api/platform/v1"
+	typesv1 "tkestack.io/tke/pkg/platform/types/v1"
+)
+
+func newMachineForTest(ip string) *platformv1.Machine {
+	return &platformv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{Name: "machine"},
+		Spec: platformv1.MachineSpec{
+			ClusterName: "global",
+			IP:          ip,
+		},
+		Status: platformv1.MachineStatus{
+			Phase: platformv1.MachineRunning,
+		},
+	}
+}
+
+func TestGetMachineNode(t *testing.T) {
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "10.0.0.1"}}
+	client := fake.NewSimpleClientset(node)
+	machine := newMachineForTest("10.0.0.1")
+
+	got, err := getMachineNode(context.Background(), client, machine, 0)
+	if err != nil {
+		t.Fatalf("getMachineNode() error = %v", err)
+	}
+	if got.Name != node.Name {
+		t.Errorf("getMachineNode() = %s, want %s", got.Name, node.Name)
+	}
+	if name := machine.Status.NodeName; name != node.Name {
+		t.Errorf("resolved node name = %q, want %q", name, node.Name)
+	}
+
+	if err := client.CoreV1().Nodes().Delete(context.Background(), node.Name, metav1.DeleteOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := getMachineNode(context.Background(), client, machine, 0); err == nil {
+		t.Errorf("getMachineNode() expected error after node is deleted")
+	}
+	if machine.Status.NodeName != "" {
+		t.Errorf("resolved node name should be dropped after node is deleted")
+	}
+}
+
+func TestGetMachineNodeTimeout(t *testing.T) {
+	hang := make(chan struct{})
+	defer close(hang)
+	client := fake.NewSimpleClientset(&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "10.0.0.1"}})
+	client.PrependReactor("get", "nodes", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		<-hang
+		return false, nil, nil
+	})
+	machine := newMachineForTest("10.0.0.1")
+	machine.Status.NodeName = "10.0.0.1"
+
+	errs := make(chan error, 1)
+	go func() {
+		_, err := getMachineNode(context.Background(), client, machine, 10*time.Millisecond)
+		errs <- err
+	}()
+	select {
+	case err := <-errs:
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("getMachineNode() error = %v, want %v", err, context.DeadlineExceeded)
+		}
+	case <-time.After(wait.ForeverTestTimeout):
+		t.Fatal("getMachineNode() blocks on a hanging client")
+	}
+}
+
+func TestCheckMachineNode(t *testing.T) {
+	tests := []struct {
+		name          string
+		unschedulable bool
+		ready         corev1.ConditionStatus
+		heartbeatAge  time.Duration
+		wantErr       bool
+	}{
+		{name: "ready", ready: corev1.ConditionTrue},
+		{name: "cordoned but ready", unschedulable: true, ready: corev1.ConditionTrue},
+		{name: "not ready", ready: corev1.ConditionFalse, wantErr: true},
+		{name: "cordoned and not ready", unschedulable: true, ready: corev1.ConditionUnknown, wantErr: true},
+		{name: "ready with fresh heartbeat", ready: corev1.ConditionTrue, heartbeatAge: time.Minute},
+		{name: "ready with stale heartbeat", ready: corev1.ConditionTrue, heartbeatAge: time.Hour, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node := &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: "10.0.0.1"},
+				Spec:       corev1.NodeSpec{Unschedulable: tt.unschedulable},
+				Status: corev1.NodeStatus{
+					Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: tt.ready}},
+				},
+			}
+			if tt.heartbeatAge > 0 {
+				node.Status.Conditions[0].LastHeartbeatTime = metav1.NewTime(time.Now().Add(-tt.heartbeatAge))
+			}
+			if tt.unschedulable {
+				node.Spec.Taints = []corev1.Taint{{Key: corev1.TaintNodeUnschedulable, Effect: corev1.TaintEffectNoSchedule}}
+			}
+			client := fake.NewSimpleClientset(node)
+			machine := newMachineForTest("10.0.0.1")
+
+			options := HealthCheckOptions{HeartbeatThreshold: 10 * time.Minute}
+			ctx, reported := WithNodeReporter(context.Background())
+			if err := checkMachineNode(ctx, client, machine, options); (err != nil) != tt.wantErr {
+				t.Errorf("checkMachineNode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			// the node is reported even if unhealthy, to be reused
+			if got := reported(); got == nil || got.Name != node.Name {
+				t.Errorf("reported node = %v, want %s", got, node.Name)
+			}
+		})
+	}
+}
+
+func TestCheckMachineNodeHeartbeatAnnotation(t *testing.T) {
+	const key = "example.com/heartbeat"
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		wantErr     bool
+	}{
+		{name: "fresh heartbeat", annotations: map[string]string{key: time.Now().Add(-time.Minute).Format(time.RFC3339)}},
+		{name: "stale heartbeat", annotations: map[string]string{key: time.Now().Add(-time.Hour).Format(time.RFC3339)}, wantErr: true},
+		{name: "invalid heartbeat", annotations: map[string]string{key: "yesterday"}, wantErr: true},
+		{name: "missing heartbeat", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node := &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: "10.0.0.1", Annotations: tt.annotations},
+				Status: corev1.NodeStatus{
+					Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}},
+				},
+			}
+			client := fake.NewSimpleClientset(node)
+			machine := newMachineForTest("10.0.0.1")
+
+			options := HealthCheckOptions{HeartbeatThreshold: 10 * time.Minute, HeartbeatAnnotation: key}
+			if err := checkMachineNode(context.Background(), client, machine, options); (err != nil) != tt.wantErr {
+				t.Errorf("checkMachineNode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func ensureJoined(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster) error {
+	return nil
+}
+
+func TestCheckMachineNodeNetwork(t *testing.T) {
+	check := NodeNetworkCheck{PodCIDR: true, NetworkAvailable: true}
+	tests := []struct {
+		name        string
+		podCIDR     string
+		unavailable corev1.ConditionStatus
+		check       NodeNetworkCheck
+		wantErr     bool
+	}{
+		{name: "not checked", check: NodeNetworkCheck{}},
+		{name: "missing PodCIDR", check: check, wantErr: true},
+		{name: "network unavailable", podCIDR: "10.244.0.0/24", unavailable: corev1.ConditionTrue, check: check, wantErr: true},
+		{name: "network unavailable not checked", podCIDR: "10.244.0.0/24", unavailable: corev1.ConditionTrue, check: NodeNetworkCheck{PodCIDR: true}},
+		{name: "network ready", podCIDR: "10.244.0.0/24", unavailable: corev1.ConditionFalse, check: check},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node := &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: "10.0.0.1"},
+				Spec:       corev1.NodeSpec{PodCIDR: tt.podCIDR},
+				Status: corev1.NodeStatus{
+					Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}},
+				},
+			}
+			if tt.unavailable != "" {
+				node.Status.Conditions = append(node.Status.Conditions, corev1.NodeCondition{Type: corev1.NodeNetworkUnavailable, Status: tt.unavailable})
+			}
+			options := HealthCheckOptions{NodeNetworkCheck: tt.check}
+			err := checkMachineNode(context.Background(), fake.NewSimpleClientset(node), newMachineForTest("10.0.0.1"), options)
+			if (err != nil) != tt.wantErr || (err != nil && !errors.Is(err, ErrNodeNetworkNotReady)) {
+				t.Errorf("checkMachineNode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDelegateProvider_setHealthConditionNodeNetwork(t *testing.T) {
+	p := &DelegateProvider{CreateHandlers: []Handler{ensureJoined}}
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "10.0.0.1"},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}},
+		},
+	}
+	options := HealthCheckOptions{JoinGracePeriod: 2 * time.Minute, NodeNetworkCheck: NodeNetworkCheck{PodCIDR: true}}
+	machine := newMachineForTest("10.0.0.1")
+	machine.SetCondition(platformv1.MachineCondition{
+		Type:          Handler(ensureJoined).Name(),
+		Status:        platformv1.ConditionTrue,
+		LastProbeTime: metav1.NewTime(time.Now().Add(-30 * time.Second)),
+	})
+
+	err := checkMachineNode(context.Background(), fake.NewSimpleClientset(node), machine, options)
+	p.setHealthCondition(context.Background(), machine, err, options.JoinGracePeriod)
+	condition := machine.GetCondition(ConditionTypeHealthCheck)
+	if condition.Status != platformv1.ConditionUnknown || condition.Reason != ReasonNodeNetworkNotReady {
+		t.Errorf("health condition = %+v, want not yet healthy as the node has no PodCIDR", condition)
+	}
+}
+
+func TestDelegateProvider_setHealthConditionJoinGracePeriod(t *testing.T) {
+	p := &DelegateProvider{CreateHandlers: []Handler{ensureJoined}}
+	options := HealthCheckOptions{JoinGracePeriod: 2 * time.Minute}
+	tests := []struct {
+		name      string
+		joined    time.Duration
+		wantPhase platformv1.MachinePhase
+		want      platformv1.ConditionStatus
+	}{
+		{name: "within grace period", joined: 30 * time.Second, wantPhase: platformv1.MachineRunning, want: platformv1.ConditionUnknown},
+		{name: "after grace period", joined: 5 * time.Minute, wantPhase: platformv1.MachineFailed, want: platformv1.ConditionFalse},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			machine := newMachineForTest("10.0.0.1")
+			machine.SetCondition(platformv1.MachineCondition{
+				Type:          Handler(ensureJoined).Name(),
+				Status:        platformv1.ConditionTrue,
+				LastProbeTime: metav1.NewTime(time.Now().Add(-tt.joined)),
+			})
+			err := checkMachineNode(context.Background(), fake.NewSimpleClientset(), machine, options)
+
+			p.setHealthCondition(context.Background(), machine, err, options.JoinGracePeriod)
+			if machine.Status.Phase != tt.wantPhase {
+				t.Errorf("phase = %s, want %s", machine.Status.Phase, tt.wantPhase)
+			}
+			if got := machine.GetCondition(ConditionTypeHealthCheck).Status; got != tt.want {
+				t.Errorf("health status = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDelegateProvider_setHealthConditionBestEffort(t *testing.T) {
+	p := &DelegateProvider{}
+	tests := []struct {
+		name       string
+		bestEffort string
+		wantPhase  platformv1.MachinePhase
+		want       platformv1.ConditionStatus
+		wantReason string
+	}{
+		{name: "best effort", bestEffort: "true", wantPhase: platformv1.MachineRunning, want: platformv1.ConditionUnknown, wantReason: ReasonNodeGone},
+		{name: "not best effort", bestEffort: "false", wantPhase: platformv1.MachineFailed, want: platformv1.ConditionFalse, wantReason: FailedHealthCheckReason},
+		{name: "unmarked", wantPhase: platformv1.MachineFailed, want: platformv1.ConditionFalse, wantReason: FailedHealthCheckReason},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			machine := newMachineForTest("10.0.0.1")
+			if tt.bestEffort != "" {
+				machine.Annotations = map[string]string{platformv1.MachineBestEffortAnno: tt.bestEffort}
+			}
+			// the node disappears
+			err := checkMachineNode(context.Background(), fake.NewSimpleClientset(), machine, HealthCheckOptions{})
+
+			p.setHealthCondition(context.Background(), machine, err, 0)
+			if machine.Status.Phase != tt.wantPhase {
+				t.Errorf("phase = %s, want %s", machine.Status.Phase, tt.wantPhase)
+			}
+			condition := machine.GetCondition(ConditionTypeHealthCheck)
+			if condition.Status != tt.want || condition.Reason != tt.wantReason {
+				t.Errorf("health condition = %+v, want %s with reason %s", condition, tt.want, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestDelegateProvider_setHealthConditionForbidden(t *testing.T) {
+	p := &DelegateProvider{}
+	client := fake.NewSimpleClientset(&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "10.0.0.1"}})
+	forbidden := true
+	client.PrependReactor("*", "nodes", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if !forbidden {
+			return false, nil, nil
+		}
+		return true, nil, apierrors.NewForbidden(corev1.Resource("nodes"), "10.0.0.1", errors.New("RBAC denied"))
+	})
+	machine := newMachineForTest("10.0.0.1")
+
+	p.setHealthCondition(context.Background(), machine, checkMachineNode(context.Background(), client, machine, HealthCheckOptions{}), 0)
+	if machine.Status.Phase != platformv1.MachineRunning {
+		t.Errorf("phase = %s, want %s when forbidden", machine.Status.Phase, platformv1.MachineRunning)
+	}
+	if got := machine.GetCondition(ConditionTypeHealthCheck).Status; got != platformv1.ConditionUnknown {
+		t.Errorf("health status = %s, want %s when forbidden", got, platformv1.ConditionUnknown)
+	}
+	if condition := machine.GetCondition(ConditionTypeInsufficientPermissions); condition == nil || condition.Status != platformv1.ConditionTrue {
+		t.Errorf("%s condition = %+v, want true", ConditionTypeInsufficientPermissions, condition)
+	}
+
+	forbidden = false
+	p.setHealthCondition(context.Background(), machine, checkMachineNode(context.Background(), client, machine, HealthCheckOptions{}), 0)
+	if got := machine.GetCondition(ConditionTypeHealthCheck).Status; got != platformv1.ConditionTrue {
+		t.Errorf("health status = %s, want %s when permitted", got, platformv1.ConditionTrue)
+	}
+	if condition := machine.GetCondition(ConditionTypeInsufficientPermissions); condition.Status != platformv1.ConditionFalse {
+		t.Errorf("%s condition = %+v, want false when permitted", ConditionTypeInsufficientPermissions, condition)
+	}
+}
+
+func TestDelegateProvider_OnUpdateKubeletRestart(t *testing.T) {
+	var called []string
+	handler := func(name string) Handler {
+		return func(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster) error {
+			called = append(called, name)
+			return nil
+		}
+	}
+	p := &DelegateProvider{
+		UpdateHandlers:         []Handler{handler("upgrade")},
+		KubeletRestartHandlers: []Handler{handler("restart")},
+	}
+	machine := newMachineForTest("10.0.0.1")
+
+	if err := p.OnUpdate(context.Background(), machine, nil, UpdateOptions{}); err != nil || len(called) != 0 {
+		t.Fatalf("OnUpdate() error = %v, called %v, want nothing done", err, called)
+	}
+	if err := p.OnUpdate(context.Background(), machine, nil, UpdateOptions{KubeletRestart: true}); err != nil || len(called) != 1 || called[0] != "restart" {
+		t.Fatalf("OnUpdate() error = %v, called %v, want kubelet restarted", err, called)
+	}
+
+	called = nil
+	machine.Status.Phase = platformv1.MachineUpgrading
+	if err := p.OnUpdate(context.Background(), machine, nil, UpdateOptions{KubeletRestart: true}); err != nil || len(called) != 1 || called[0] != "upgrade" {
+		t.Errorf("OnUpdate() error = %v, called %v, want upgraded while upgrading", err, called)
+	}
+}
@@ -24,15 +24,45 @@ import (
 	"sync"
 )
 
+const (
+	// FeatureReboot is supported by providers implementing Rebooter.
+	FeatureReboot = "Reboot"
+	// FeatureHealthCheck is supported by providers implementing HealthChecker.
+	FeatureHealthCheck = "HealthCheck"
+	// FeaturePreCheck is supported by providers implementing PreChecker.
+	FeaturePreCheck = "PreCheck"
+)
+
 var (
 	providersMu sync.RWMutex
 	providers   = make(map[string]Provider)
+	metadatas   = make(map[string]ProviderMetadata)
 )
 
+// ProviderMetadata describes a registered provider.
+type ProviderMetadata struct {
+	Name    string
+	Version string
+	// Features are the optional capabilities supported by the provider,
+	// detected from the optional interfaces it implements if not given.
+	Features []string
+	// KubeletConfigLabels are the keys of machine spec labels which the
+	// provider renders into the kubelet configuration, e.g. --node-labels,
+	// so kubelet is restarted once any of them changes. Empty means no spec
+	// field maps to the kubelet configuration.
+	KubeletConfigLabels []string
+}
+
 // Register makes a provider available by the provided name.
 // If Register is called twice with the same name or if provider is nil,
 // it panics.
 func Register(name string, provider Provider) {
+	RegisterWithMetadata(name, provider, ProviderMetadata{})
+}
+
+// RegisterWithMetadata makes a provider available by the provided name along
+// with its metadata, it panics as Register does.
+func RegisterWithMetadata(name string, provider Provider, metadata ProviderMetadata) {
 	providersMu.Lock()
 	defer providersMu.Unlock()
 	if provider == nil {
@@ -41,7 +71,26 @@ func Register(name string, provider Provider) {
 	if _, dup := providers[name]; dup {
 		panic("machine: Register called twice for provider " + name)
 	}
+	metadata.Name = name
+	if metadata.Features == nil {
+		metadata.Features = supportedFeatures(provider)
+	}
 	providers[name] = provider
+	metadatas[name] = metadata
+}
+
+func supportedFeatures(provider Provider) []string {
+	features := []string{}
+	if _, ok := provider.(Rebooter); ok {
+		features = append(features, FeatureReboot)
+	}
+	if _, ok := provider.(HealthChecker); ok {
+		features = append(features, FeatureHealthCheck)
+	}
+	if _, ok := provider.(PreChecker); ok {
+		features = append(features, FeaturePreCheck)
+	}
+	return features
 }
 
 // Providers returns a sorted list of the names of the registered providers.
@@ -68,3 +117,15 @@ func GetProvider(name string) (Provider, error) {
 
 	return provider, nil
 }
+
+// GetProviderMetadata returns the metadata of provider by name
+func GetProviderMetadata(name string) (ProviderMetadata, error) {
+	providersMu.RLock()
+	metadata, ok := metadatas[name]
+	providersMu.RUnlock()
+	if !ok {
+		return ProviderMetadata{}, fmt.Errorf("machine: unknown provider %q (forgotten import?)", name)
+	}
+
+	return metadata, nil
+}
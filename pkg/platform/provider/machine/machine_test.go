@@ -0,0 +1,67 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2019 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the “License”); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an “AS IS” BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package machine
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	platformv1 "tkestack.io/tke/api/platform/v1"
+	typesv1 "tkestack.io/tke/pkg/platform/types/v1"
+)
+
+type rebootingProvider struct {
+	DelegateProvider
+}
+
+func (p *rebootingProvider) OnReboot(ctx context.Context, machine *platformv1.Machine, cluster *typesv1.Cluster) error {
+	return nil
+}
+
+func TestRegisterWithMetadata(t *testing.T) {
+	RegisterWithMetadata("test-with-metadata", &DelegateProvider{}, ProviderMetadata{
+		Version:  "v1.0.0",
+		Features: []string{"Custom"},
+	})
+	Register("test-without-metadata", &rebootingProvider{})
+
+	tests := []struct {
+		name string
+		want ProviderMetadata
+	}{
+		{"test-with-metadata", ProviderMetadata{Name: "test-with-metadata", Version: "v1.0.0", Features: []string{"Custom"}}},
+		{"test-without-metadata", ProviderMetadata{Name: "test-without-metadata", Features: []string{FeatureReboot}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetProviderMetadata(tt.name)
+			if err != nil {
+				t.Fatalf("GetProviderMetadata() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetProviderMetadata() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := GetProviderMetadata("unknown"); err == nil {
+		t.Errorf("GetProviderMetadata() of unknown provider should fail")
+	}
+}
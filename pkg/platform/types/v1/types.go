@@ -22,6 +22,8 @@ import (
 	"fmt"
 	"math/rand"
 	"net"
+	"net/http"
+	"net/url"
 	"path"
 	"time"
 
@@ -144,6 +146,15 @@ func (c *Cluster) setRESTConfigDefaults() error {
 	if c.restConfig.Burst == 0 {
 		c.restConfig.Burst = defaultBurst
 	}
+	if c.restConfig.Proxy == nil {
+		proxy, err := c.ProxyURL()
+		if err != nil {
+			return err
+		}
+		if proxy != nil {
+			c.restConfig.Proxy = http.ProxyURL(proxy)
+		}
+	}
 
 	return nil
 }
@@ -199,6 +210,28 @@ func (c *Cluster) Host() (string, error) {
 	return result, nil
 }
 
+// ProxyURL returns the proxy of cluster by annotation ClusterProxyURLAnno,
+// nil if the cluster isn't reached through a proxy.
+func (c *Cluster) ProxyURL() (*url.URL, error) {
+	if c.Cluster == nil {
+		return nil, nil
+	}
+	value := c.Annotations[platformv1.ClusterProxyURLAnno]
+	if value == "" {
+		return nil, nil
+	}
+	proxy, err := url.Parse(value)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid proxy url of cluster %s", c.Name)
+	}
+	switch proxy.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return nil, errors.Errorf("unsupported proxy scheme %q of cluster %s", proxy.Scheme, c.Name)
+	}
+	return proxy, nil
+}
+
 func (c *Cluster) HostForBootstrap() (string, error) {
 	for _, one := range c.Status.Addresses {
 		if one.Type == platformv1.AddressReal {
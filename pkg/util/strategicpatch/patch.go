@@ -29,6 +29,12 @@ import (
 
 // GetPatchBytes returns patch bytes for StrategicMergePatch.
 func GetPatchBytes(oldObj, newObj runtime.Object) ([]byte, error) {
+	return GetPatchBytesForType(oldObj, newObj, corev1.Node{})
+}
+
+// GetPatchBytesForType returns patch bytes for StrategicMergePatch, the patch
+// strategy is read from dataStruct which is the type of objects.
+func GetPatchBytesForType(oldObj, newObj runtime.Object, dataStruct interface{}) ([]byte, error) {
 	oldData, err := json.Marshal(oldObj)
 	if err != nil {
 		return nil, fmt.Errorf("failed to Marshal oldData: %w", err)
@@ -37,10 +43,15 @@ func GetPatchBytes(oldObj, newObj runtime.Object) ([]byte, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to Marshal newData: %w", err)
 	}
-	patchBytes, err := strategicpatch.CreateTwoWayMergePatch(oldData, newData, corev1.Node{})
+	patchBytes, err := strategicpatch.CreateTwoWayMergePatch(oldData, newData, dataStruct)
 	if err != nil {
 		return nil, fmt.Errorf("failed to CreateTwoWayMergePatch: %w", err)
 	}
 
 	return patchBytes, nil
 }
+
+// IsEmptyPatch returns true if the patch changes nothing.
+func IsEmptyPatch(patchBytes []byte) bool {
+	return len(patchBytes) == 0 || string(patchBytes) == "{}"
+}